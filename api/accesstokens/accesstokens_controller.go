@@ -0,0 +1,189 @@
+package accesstokens
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	accessTokenModels "github.com/equinor/radix-api/api/accesstokens/models"
+	"github.com/equinor/radix-api/api/utils/token"
+	"github.com/equinor/radix-api/models"
+	"github.com/gorilla/mux"
+)
+
+const rootPath = "/applications/{appName}/accesstokens"
+
+type accessTokenController struct {
+	*models.DefaultController
+	store       *token.PersonalAccessTokenStore
+	maxLifetime time.Duration
+}
+
+// NewAccessTokenController Constructor
+func NewAccessTokenController(store *token.PersonalAccessTokenStore, maxLifetime time.Duration) models.Controller {
+	return &accessTokenController{store: store, maxLifetime: maxLifetime}
+}
+
+// GetRoutes List the supported routes of this handler
+func (c *accessTokenController) GetRoutes() models.Routes {
+	routes := models.Routes{
+		models.Route{
+			Path:        rootPath,
+			Method:      http.MethodPost,
+			HandlerFunc: c.CreatePersonalAccessToken,
+		},
+		models.Route{
+			Path:        rootPath,
+			Method:      http.MethodGet,
+			HandlerFunc: c.GetPersonalAccessTokens,
+		},
+		models.Route{
+			Path:        rootPath + "/{tokenId}",
+			Method:      http.MethodDelete,
+			HandlerFunc: c.RevokePersonalAccessToken,
+		},
+	}
+
+	return routes
+}
+
+// CreatePersonalAccessToken Create a personal access token for the application
+func (c *accessTokenController) CreatePersonalAccessToken(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /applications/{appName}/accesstokens application createPersonalAccessToken
+	// ---
+	// summary: Create a personal access token for the application. The token value is only returned in this response
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: personalAccessToken
+	//   in: body
+	//   description: Name, scopes and expiry time of the token
+	//   required: true
+	//   schema:
+	//       "$ref": "#/definitions/CreatePersonalAccessTokenRequest"
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Token created"
+	//     schema:
+	//        "$ref": "#/definitions/CreatedPersonalAccessToken"
+	//   "400":
+	//     description: "Invalid name, scopes or expiry time"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	appName := mux.Vars(r)["appName"]
+	var request accessTokenModels.CreatePersonalAccessTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	created, err := NewHandler(accounts, c.store, c.maxLifetime).CreatePersonalAccessToken(r.Context(), appName, request)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, created)
+}
+
+// GetPersonalAccessTokens List the personal access tokens of the application
+func (c *accessTokenController) GetPersonalAccessTokens(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/accesstokens application getPersonalAccessTokens
+	// ---
+	// summary: Lists the personal access tokens of the application, oldest first. Token values are not returned
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//        type: "array"
+	//        items:
+	//           "$ref": "#/definitions/PersonalAccessToken"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	appName := mux.Vars(r)["appName"]
+	tokens, err := NewHandler(accounts, c.store, c.maxLifetime).GetPersonalAccessTokens(r.Context(), appName)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, tokens)
+}
+
+// RevokePersonalAccessToken Revoke a personal access token of the application
+func (c *accessTokenController) RevokePersonalAccessToken(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /applications/{appName}/accesstokens/{tokenId} application revokePersonalAccessToken
+	// ---
+	// summary: Revokes a personal access token of the application, so it can no longer be used
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: tokenId
+	//   in: path
+	//   description: ID of the token
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "204":
+	//     description: "Token revoked"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	tokenId := mux.Vars(r)["tokenId"]
+	if err := NewHandler(accounts, c.store, c.maxLifetime).RevokePersonalAccessToken(r.Context(), appName, tokenId); err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,24 @@
+package accesstokens
+
+import (
+	"fmt"
+
+	"github.com/equinor/radix-api/api/utils/problem"
+	radixhttp "github.com/equinor/radix-common/net/http"
+)
+
+// Error codes of personal access token errors
+const (
+	CodeInvalidPersonalAccessTokenRequest problem.Code = "invalid-personal-access-token-request"
+	CodePersonalAccessTokenNotFound       problem.Code = "personal-access-token-not-found"
+)
+
+// InvalidPersonalAccessTokenRequestError the request to create a personal access token is invalid
+func InvalidPersonalAccessTokenRequestError(reason string) error {
+	return problem.WithCode(CodeInvalidPersonalAccessTokenRequest, radixhttp.ValidationError("PersonalAccessToken", reason))
+}
+
+// PersonalAccessTokenNotFoundError the application has no personal access token with the id
+func PersonalAccessTokenNotFoundError(appName, id string) error {
+	return problem.WithCode(CodePersonalAccessTokenNotFound, radixhttp.NotFoundError(fmt.Sprintf("personal access token %s not found for application %s", id, appName)))
+}
@@ -0,0 +1,159 @@
+package accesstokens
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	accessTokenModels "github.com/equinor/radix-api/api/accesstokens/models"
+	"github.com/equinor/radix-api/api/kubequery"
+	"github.com/equinor/radix-api/api/middleware/auth"
+	"github.com/equinor/radix-api/api/utils/token"
+	"github.com/equinor/radix-api/models"
+	radixhttp "github.com/equinor/radix-common/net/http"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const maxNameLength = 100
+
+// Handler Manages the personal access tokens of applications. Only application administrators can manage tokens
+type Handler struct {
+	accounts    models.Accounts
+	store       *token.PersonalAccessTokenStore
+	maxLifetime time.Duration
+	now         func() time.Time
+}
+
+// NewHandler Constructor
+func NewHandler(accounts models.Accounts, store *token.PersonalAccessTokenStore, maxLifetime time.Duration) Handler {
+	return Handler{accounts: accounts, store: store, maxLifetime: maxLifetime, now: time.Now}
+}
+
+// CreatePersonalAccessToken creates a token for the application. The token value is only returned once
+func (h Handler) CreatePersonalAccessToken(ctx context.Context, appName string, request accessTokenModels.CreatePersonalAccessTokenRequest) (*accessTokenModels.CreatedPersonalAccessToken, error) {
+	if err := h.requireAdmin(ctx, appName); err != nil {
+		return nil, err
+	}
+
+	pat, err := h.newPersonalAccessToken(ctx, appName, request)
+	if err != nil {
+		return nil, err
+	}
+	// Requests with the token are authorized as the Kubernetes user of the creator, without groups
+	review, err := h.accounts.UserAccount.Client.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pat.CreatorUser = review.Status.UserInfo.Username
+	isUserAdmin, err := kubequery.IsUserRadixApplicationAdmin(ctx, h.accounts.ServiceAccount.Client, pat.CreatorUser, nil, appName)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserAdmin {
+		return nil, radixhttp.ForbiddenError(fmt.Sprintf("you must be administrator of the application %s as a user, and not only through a group, to create personal access tokens", appName))
+	}
+
+	pat, tokenValue, err := h.store.Create(ctx, pat)
+	if err != nil {
+		return nil, err
+	}
+	return &accessTokenModels.CreatedPersonalAccessToken{PersonalAccessToken: toModel(pat), Token: tokenValue}, nil
+}
+
+// GetPersonalAccessTokens lists the tokens of the application, oldest first
+func (h Handler) GetPersonalAccessTokens(ctx context.Context, appName string) ([]accessTokenModels.PersonalAccessToken, error) {
+	if err := h.requireAdmin(ctx, appName); err != nil {
+		return nil, err
+	}
+
+	pats, err := h.store.List(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]accessTokenModels.PersonalAccessToken, 0, len(pats))
+	for _, pat := range pats {
+		tokens = append(tokens, toModel(pat))
+	}
+	return tokens, nil
+}
+
+// RevokePersonalAccessToken deletes the token, so it can no longer be used
+func (h Handler) RevokePersonalAccessToken(ctx context.Context, appName, id string) error {
+	if err := h.requireAdmin(ctx, appName); err != nil {
+		return err
+	}
+
+	err := h.store.Delete(ctx, appName, id)
+	if kubeerrors.IsNotFound(err) {
+		return PersonalAccessTokenNotFoundError(appName, id)
+	}
+	return err
+}
+
+func (h Handler) requireAdmin(ctx context.Context, appName string) error {
+	isAdmin, err := kubequery.IsRadixApplicationAdmin(ctx, h.accounts.UserAccount.Client, appName)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return radixhttp.ForbiddenError(fmt.Sprintf("you must be administrator of the application %s to manage personal access tokens", appName))
+	}
+	return nil
+}
+
+func (h Handler) newPersonalAccessToken(ctx context.Context, appName string, request accessTokenModels.CreatePersonalAccessTokenRequest) (token.PersonalAccessToken, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" || len(name) > maxNameLength {
+		return token.PersonalAccessToken{}, InvalidPersonalAccessTokenRequestError(fmt.Sprintf("name must be between 1 and %d characters", maxNameLength))
+	}
+
+	if len(request.Scopes) == 0 {
+		return token.PersonalAccessToken{}, InvalidPersonalAccessTokenRequestError("at least one scope is required")
+	}
+	scopes := make([]token.Scope, 0, len(request.Scopes))
+	for _, value := range request.Scopes {
+		scope := token.Scope(value)
+		if !token.IsValidScope(scope) {
+			return token.PersonalAccessToken{}, InvalidPersonalAccessTokenRequestError(fmt.Sprintf("invalid scope %s, expected one of %v", value, token.Scopes))
+		}
+		scopes = append(scopes, scope)
+	}
+
+	now := h.now()
+	expires := now.Add(h.maxLifetime)
+	if request.ExpiresAt != nil {
+		expires = *request.ExpiresAt
+	}
+	if !expires.After(now) {
+		return token.PersonalAccessToken{}, InvalidPersonalAccessTokenRequestError("expiresAt must be in the future")
+	}
+	if expires.After(now.Add(h.maxLifetime)) {
+		return token.PersonalAccessToken{}, InvalidPersonalAccessTokenRequestError(fmt.Sprintf("expiresAt cannot be more than %s from now", h.maxLifetime))
+	}
+
+	return token.PersonalAccessToken{
+		AppName:   appName,
+		Name:      name,
+		Scopes:    scopes,
+		CreatedBy: auth.GetOriginator(ctx),
+		Expires:   expires,
+	}, nil
+}
+
+func toModel(pat token.PersonalAccessToken) accessTokenModels.PersonalAccessToken {
+	scopes := make([]string, 0, len(pat.Scopes))
+	for _, scope := range pat.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	return accessTokenModels.PersonalAccessToken{
+		ID:        pat.Id,
+		Name:      pat.Name,
+		Scopes:    scopes,
+		CreatedBy: pat.CreatedBy,
+		Created:   pat.Created,
+		ExpiresAt: pat.Expires,
+	}
+}
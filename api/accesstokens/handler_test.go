@@ -0,0 +1,119 @@
+package accesstokens
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	accessTokenModels "github.com/equinor/radix-api/api/accesstokens/models"
+	"github.com/equinor/radix-api/api/utils/problem"
+	"github.com/equinor/radix-api/api/utils/token"
+	"github.com/equinor/radix-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationapiv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	testing2 "k8s.io/client-go/testing"
+)
+
+const (
+	anyAppName   = "any-app"
+	otherAppName = "other-app"
+)
+
+func setupHandler(maxLifetime time.Duration) (Handler, *token.PersonalAccessTokenStore) {
+	return setupHandlerWithCreator(maxLifetime, "any-user")
+}
+
+func setupHandlerWithCreator(maxLifetime time.Duration, creatorUser string) (Handler, *token.PersonalAccessTokenStore) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "selfsubjectaccessreviews", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(testing2.CreateAction).GetObject().(*authorizationapiv1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Name == anyAppName
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "selfsubjectreviews", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(testing2.CreateAction).GetObject().(*authenticationv1.SelfSubjectReview)
+		review.Status.UserInfo = authenticationv1.UserInfo{Username: creatorUser, Groups: []string{"any-group", "system:authenticated"}}
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(testing2.CreateAction).GetObject().(*authorizationapiv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Name == anyAppName && review.Spec.User == "any-user" && len(review.Spec.Groups) == 0
+		return true, review, nil
+	})
+	store := token.NewPersonalAccessTokenStore(kubeClient, "radix-api-prod")
+	return NewHandler(models.Accounts{UserAccount: models.Account{Client: kubeClient}, ServiceAccount: models.Account{Client: kubeClient}}, store, maxLifetime), store
+}
+
+func Test_CreatePersonalAccessToken(t *testing.T) {
+	handler, store := setupHandler(24 * time.Hour)
+
+	created, err := handler.CreatePersonalAccessToken(context.Background(), anyAppName, accessTokenModels.CreatePersonalAccessTokenRequest{Name: " ci ", Scopes: []string{"pipelines:trigger"}})
+	require.NoError(t, err)
+	assert.Equal(t, "ci", created.Name)
+	assert.Equal(t, []string{"pipelines:trigger"}, created.Scopes)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), created.ExpiresAt, time.Minute)
+
+	pat, err := store.Validate(context.Background(), created.Token)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, pat.Id)
+	assert.Equal(t, anyAppName, pat.AppName)
+	assert.Equal(t, "any-user", pat.CreatorUser)
+
+	tokens, err := handler.GetPersonalAccessTokens(context.Background(), anyAppName)
+	require.NoError(t, err)
+	assert.Equal(t, []accessTokenModels.PersonalAccessToken{created.PersonalAccessToken}, tokens)
+}
+
+func Test_CreatePersonalAccessToken_InvalidRequest(t *testing.T) {
+	tooLate := time.Now().Add(48 * time.Hour)
+	past := time.Now().Add(-time.Minute)
+	scenarios := map[string]accessTokenModels.CreatePersonalAccessTokenRequest{
+		"missing name":        {Scopes: []string{"read"}},
+		"missing scopes":      {Name: "ci"},
+		"invalid scope":       {Name: "ci", Scopes: []string{"write"}},
+		"expires in the past": {Name: "ci", Scopes: []string{"read"}, ExpiresAt: &past},
+		"expires too late":    {Name: "ci", Scopes: []string{"read"}, ExpiresAt: &tooLate},
+	}
+
+	for name, request := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			handler, _ := setupHandler(24 * time.Hour)
+			_, err := handler.CreatePersonalAccessToken(context.Background(), anyAppName, request)
+			assert.Equal(t, CodeInvalidPersonalAccessTokenRequest, problem.GetCode(err))
+		})
+	}
+}
+
+func Test_PersonalAccessTokens_RequiresAdmin(t *testing.T) {
+	handler, _ := setupHandler(24 * time.Hour)
+
+	_, err := handler.CreatePersonalAccessToken(context.Background(), otherAppName, accessTokenModels.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{"read"}})
+	assert.Error(t, err)
+	_, err = handler.GetPersonalAccessTokens(context.Background(), otherAppName)
+	assert.Error(t, err)
+	assert.Error(t, handler.RevokePersonalAccessToken(context.Background(), otherAppName, "any-id"))
+}
+
+func Test_CreatePersonalAccessToken_RequiresUserAdmin(t *testing.T) {
+	handler, _ := setupHandlerWithCreator(24*time.Hour, "group-admin-user")
+
+	_, err := handler.CreatePersonalAccessToken(context.Background(), anyAppName, accessTokenModels.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{"read"}})
+	assert.Error(t, err, "a creator who is administrator only through a group cannot create tokens")
+}
+
+func Test_RevokePersonalAccessToken(t *testing.T) {
+	handler, store := setupHandler(24 * time.Hour)
+	created, err := handler.CreatePersonalAccessToken(context.Background(), anyAppName, accessTokenModels.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{"read"}})
+	require.NoError(t, err)
+
+	require.NoError(t, handler.RevokePersonalAccessToken(context.Background(), anyAppName, created.ID))
+	_, err = store.Validate(context.Background(), created.Token)
+	assert.ErrorIs(t, err, token.ErrInvalidPersonalAccessToken)
+
+	err = handler.RevokePersonalAccessToken(context.Background(), anyAppName, created.ID)
+	assert.Equal(t, CodePersonalAccessTokenNotFound, problem.GetCode(err))
+}
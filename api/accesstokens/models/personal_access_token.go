@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// PersonalAccessToken describes a personal access token of an application. The token value is only returned when the token is created
+// swagger:model PersonalAccessToken
+type PersonalAccessToken struct {
+	// ID of the token
+	//
+	// required: true
+	// example: 3f1c9a7e2b4d6e80
+	ID string `json:"id"`
+
+	// Name of the token
+	//
+	// required: true
+	// example: github-actions
+	Name string `json:"name"`
+
+	// Scopes the token can be used for
+	//
+	// required: true
+	// example: ["pipelines:trigger"]
+	Scopes []string `json:"scopes"`
+
+	// CreatedBy user who created the token
+	//
+	// required: true
+	// example: a_user@equinor.com
+	CreatedBy string `json:"createdBy"`
+
+	// Created time when the token was created
+	//
+	// required: true
+	// swagger:strfmt date-time
+	Created time.Time `json:"created"`
+
+	// ExpiresAt time when the token expires
+	//
+	// required: true
+	// swagger:strfmt date-time
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// CreatePersonalAccessTokenRequest describes a personal access token to create
+// swagger:model CreatePersonalAccessTokenRequest
+type CreatePersonalAccessTokenRequest struct {
+	// Name of the token
+	//
+	// required: true
+	// example: github-actions
+	Name string `json:"name"`
+
+	// Scopes the token can be used for: read, pipelines:trigger
+	//
+	// required: true
+	// example: ["pipelines:trigger"]
+	Scopes []string `json:"scopes"`
+
+	// ExpiresAt time when the token expires. Defaults to the maximum lifetime of tokens
+	//
+	// required: false
+	// swagger:strfmt date-time
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+// CreatedPersonalAccessToken a created personal access token, with the token value
+// swagger:model CreatedPersonalAccessToken
+type CreatedPersonalAccessToken struct {
+	PersonalAccessToken `json:",inline"`
+
+	// Token value to send as a Bearer token. It is only returned once, and cannot be read later
+	//
+	// required: true
+	Token string `json:"token"`
+}
@@ -4,35 +4,47 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/equinor/radix-api/api/utils/problem"
 	radixhttp "github.com/equinor/radix-common/net/http"
 )
 
+// Error codes of alerting errors
+const (
+	CodeMultipleAlertingConfigurations problem.Code = "multiple-alerting-configurations"
+	CodeAlertingNotEnabled             problem.Code = "alerting-not-enabled"
+	CodeInvalidAlertReceiver           problem.Code = "invalid-alert-receiver"
+	CodeInvalidAlert                   problem.Code = "invalid-alert"
+	CodeAlertingAlreadyEnabled         problem.Code = "alerting-already-enabled"
+	CodeAlertReceiverNotDefined        problem.Code = "alert-receiver-not-defined"
+	CodeInvalidSlackURL                problem.Code = "invalid-slack-url"
+)
+
 func MultipleAlertingConfigurationsError() error {
-	return radixhttp.CoverAllError(errors.New("multiple alert configurations found"), radixhttp.Server)
+	return problem.WithCode(CodeMultipleAlertingConfigurations, radixhttp.CoverAllError(errors.New("multiple alert configurations found"), radixhttp.Server))
 }
 
 func AlertingNotEnabledError() error {
-	return radixhttp.CoverAllError(errors.New("alerting is not enabled"), radixhttp.User)
+	return problem.WithCode(CodeAlertingNotEnabled, radixhttp.CoverAllError(errors.New("alerting is not enabled"), radixhttp.User))
 }
 
 func InvalidAlertReceiverError(alert, receiver string) error {
-	return radixhttp.CoverAllError(fmt.Errorf("invalid receiver %s for alert %s", receiver, alert), radixhttp.User)
+	return problem.WithCode(CodeInvalidAlertReceiver, radixhttp.CoverAllError(fmt.Errorf("invalid receiver %s for alert %s", receiver, alert), radixhttp.User))
 }
 
 func InvalidAlertError(alert string) error {
-	return radixhttp.CoverAllError(fmt.Errorf("alert %s is not valid", alert), radixhttp.User)
+	return problem.WithCode(CodeInvalidAlert, radixhttp.CoverAllError(fmt.Errorf("alert %s is not valid", alert), radixhttp.User))
 }
 
 func AlertingAlreadyEnabledError() error {
-	return radixhttp.CoverAllError(errors.New("alerting already enabled"), radixhttp.User)
+	return problem.WithCode(CodeAlertingAlreadyEnabled, radixhttp.CoverAllError(errors.New("alerting already enabled"), radixhttp.User))
 }
 
 func UpdateReceiverSecretNotDefinedError(receiverName string) error {
-	return radixhttp.CoverAllError(fmt.Errorf("receiver %s in receiverSecrets is not defined in receivers", receiverName), radixhttp.User)
+	return problem.WithCode(CodeAlertReceiverNotDefined, radixhttp.CoverAllError(fmt.Errorf("receiver %s in receiverSecrets is not defined in receivers", receiverName), radixhttp.User))
 }
 
 func InvalidSlackURLError(underlyingError error) error {
-	return radixhttp.CoverAllError(fmt.Errorf("invalid slack url: %v", underlyingError), radixhttp.User)
+	return problem.WithCode(CodeInvalidSlackURL, radixhttp.CoverAllError(fmt.Errorf("invalid slack url: %v", underlyingError), radixhttp.User))
 }
 
 func InvalidSlackURLSchemeError() error {
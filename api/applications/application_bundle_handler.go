@@ -0,0 +1,381 @@
+package applications
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/equinor/radix-api/api/alerting"
+	applicationModels "github.com/equinor/radix-api/api/applications/models"
+	"github.com/equinor/radix-api/api/environmentvariables"
+	envvarsModels "github.com/equinor/radix-api/api/environmentvariables/models"
+	"github.com/equinor/radix-api/api/kubequery"
+	apimodels "github.com/equinor/radix-api/api/models"
+	secretModels "github.com/equinor/radix-api/api/secrets/models"
+	"github.com/equinor/radix-operator/pkg/apis/kube"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	operatorUtils "github.com/equinor/radix-operator/pkg/apis/utils"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// importedAlertConfigName the name of alerting configs created by import, the same as when alerting is enabled
+const importedAlertConfigName = "alerting"
+
+// ExportApplication exports the registration, build secret names, private image hubs, alerting configs,
+// environment variable overrides and secret names of the application. Secret values are not exported
+func (ah *ApplicationHandler) ExportApplication(ctx context.Context, appName string) (*applicationModels.ApplicationBundle, error) {
+	rr, err := kubequery.GetRadixRegistration(ctx, ah.getUserAccount().RadixClient, appName)
+	if err != nil {
+		return nil, err
+	}
+	registration := applicationModels.NewApplicationRegistrationBuilder().WithRadixRegistration(rr).Build()
+	registration.SharedSecret = ""
+	bundle := applicationModels.ApplicationBundle{
+		Version:      applicationModels.ApplicationBundleVersion,
+		Exported:     time.Now().UTC().Truncate(time.Second),
+		Registration: registration,
+	}
+
+	if bundle.Alerting, err = exportAlerting(ctx, alerting.NewApplicationHandler(ah.accounts, appName)); err != nil {
+		return nil, err
+	}
+
+	// An application without a RadixApplication has no build secrets, private image hubs or environments
+	ra, err := kubequery.GetRadixApplication(ctx, ah.getUserAccount().RadixClient, appName)
+	if k8serrors.IsNotFound(err) {
+		return &bundle, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	bundle.BuildSecrets = getBuildSecretNames(ra)
+	bundle.PrivateImageHubs = getPrivateImageHubs(ra)
+
+	kubeUtil, err := ah.getUserKubeUtil()
+	if err != nil {
+		return nil, err
+	}
+	for _, env := range ra.Spec.Environments {
+		envBundle, err := ah.exportEnvironment(ctx, kubeUtil, appName, env.Name)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Environments = append(bundle.Environments, *envBundle)
+	}
+	return &bundle, nil
+}
+
+func (ah *ApplicationHandler) exportEnvironment(ctx context.Context, kubeUtil *kube.Kube, appName, envName string) (*applicationModels.EnvironmentBundle, error) {
+	envBundle := applicationModels.EnvironmentBundle{Name: envName}
+	var err error
+	if envBundle.Alerting, err = exportAlerting(ctx, alerting.NewEnvironmentHandler(ah.accounts, appName, envName)); err != nil {
+		return nil, err
+	}
+
+	// Environment variable overrides and secrets exist only for components of the active deployment
+	rdList, err := kubequery.GetRadixDeploymentsForEnvironment(ctx, ah.getUserAccount().RadixClient, appName, envName)
+	if err != nil {
+		return nil, err
+	}
+	rd, ok := apimodels.GetActiveDeploymentForAppEnv(appName, envName, rdList)
+	if !ok {
+		return &envBundle, nil
+	}
+
+	namespace := operatorUtils.GetEnvironmentNamespace(appName, envName)
+	for _, componentName := range getDeployComponentNames(&rd) {
+		overrides, err := getEnvVarOverrides(ctx, kubeUtil, namespace, componentName)
+		if err != nil {
+			return nil, err
+		}
+		envBundle.EnvVarOverrides = append(envBundle.EnvVarOverrides, overrides...)
+	}
+
+	noJobPayloadReq, err := labels.NewRequirement(kube.RadixSecretTypeLabel, selection.NotEquals, []string{string(kube.RadixSecretJobPayload)})
+	if err != nil {
+		return nil, err
+	}
+	secretList, err := kubequery.GetSecretsForEnvironment(ctx, ah.getServiceAccount().Client, appName, envName, *noJobPayloadReq)
+	if err != nil {
+		return nil, err
+	}
+	secretProviderClassList, err := kubequery.GetSecretProviderClassesForEnvironment(ctx, ah.getServiceAccount().SecretProviderClient, appName, envName)
+	if err != nil {
+		return nil, err
+	}
+	envBundle.Secrets = apimodels.BuildSecrets(ctx, secretList, secretProviderClassList, &rd)
+	return &envBundle, nil
+}
+
+func exportAlerting(ctx context.Context, handler alerting.Handler) (*applicationModels.AlertingBundle, error) {
+	config, err := handler.GetAlertingConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Enabled {
+		return nil, nil
+	}
+	return &applicationModels.AlertingBundle{
+		Receivers:            config.Receivers,
+		ReceiverSecretStatus: config.ReceiverSecretStatus,
+		Alerts:               config.Alerts,
+	}, nil
+}
+
+func getEnvVarOverrides(ctx context.Context, kubeUtil *kube.Kube, namespace, componentName string) ([]applicationModels.EnvVarOverride, error) {
+	envVarsConfigMap, _, envVarsMetadataMap, err := kubeUtil.GetEnvVarsConfigMapAndMetadataMap(ctx, namespace, componentName)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Environment variables changed in Radix console have metadata with the value in radixconfig.yaml
+	var overrides []applicationModels.EnvVarOverride
+	for _, name := range slices.Sorted(maps.Keys(envVarsMetadataMap)) {
+		value, ok := envVarsConfigMap.Data[name]
+		if !ok {
+			continue
+		}
+		overrides = append(overrides, applicationModels.EnvVarOverride{
+			Component:        componentName,
+			Name:             name,
+			Value:            value,
+			RadixConfigValue: envVarsMetadataMap[name].RadixConfigValue,
+		})
+	}
+	return overrides, nil
+}
+
+func getDeployComponentNames(rd *v1.RadixDeployment) []string {
+	var names []string
+	for _, component := range rd.Spec.Components {
+		names = append(names, component.Name)
+	}
+	for _, job := range rd.Spec.Jobs {
+		names = append(names, job.Name)
+	}
+	return names
+}
+
+// ImportApplication registers the application in the bundle, and imports its settings. Secret values are not part of the bundle,
+// and are reported as items requiring input. Environment settings are imported when the environment exists, and are reported as pending otherwise.
+// An application which is already registered with the same repository is not changed, so the bundle can be imported again after the first deployment
+func (ah *ApplicationHandler) ImportApplication(ctx context.Context, importRequest applicationModels.ApplicationImportRequest) (*applicationModels.ApplicationImportResponse, error) {
+	if err := validateApplicationBundle(importRequest.Bundle); err != nil {
+		return nil, err
+	}
+	bundle := importRequest.Bundle
+	appName := bundle.Registration.Name
+
+	response, err := ah.importRegistration(ctx, bundle.Registration, importRequest.AcknowledgeWarnings)
+	if err != nil || response.ApplicationRegistration == nil {
+		return response, err
+	}
+
+	namespaceErr := ah.waitForNamespace(ctx, operatorUtils.GetAppNamespace(appName))
+	response.Items = append(response.Items, ah.importApplicationSettings(ctx, bundle, namespaceErr)...)
+
+	kubeUtil, err := ah.getUserKubeUtil()
+	if err != nil {
+		return nil, err
+	}
+	for _, envBundle := range bundle.Environments {
+		response.Items = append(response.Items, ah.importEnvironment(ctx, kubeUtil, appName, envBundle)...)
+	}
+	return response, nil
+}
+
+func validateApplicationBundle(bundle *applicationModels.ApplicationBundle) error {
+	switch {
+	case bundle == nil:
+		return invalidApplicationBundleError("bundle is required")
+	case bundle.Version != applicationModels.ApplicationBundleVersion:
+		return invalidApplicationBundleError(fmt.Sprintf("unsupported version %d, expected %d", bundle.Version, applicationModels.ApplicationBundleVersion))
+	case bundle.Registration.Name == "":
+		return invalidApplicationBundleError("registration name is required")
+	case bundle.Registration.Repository == "":
+		return invalidApplicationBundleError("registration repository is required")
+	}
+	return nil
+}
+
+func (ah *ApplicationHandler) importRegistration(ctx context.Context, registration applicationModels.ApplicationRegistration, acknowledgeWarnings bool) (*applicationModels.ApplicationImportResponse, error) {
+	// The user is not allowed to read registrations of other applications, so the service account checks if the application exists
+	_, err := ah.getServiceAccount().RadixClient.RadixV1().RadixRegistrations().Get(ctx, registration.Name, metav1.GetOptions{})
+	if err == nil {
+		rr, err := kubequery.GetRadixRegistration(ctx, ah.getUserAccount().RadixClient, registration.Name)
+		if err != nil {
+			return nil, err
+		}
+		if rr.Spec.CloneURL != operatorUtils.GetGithubCloneURLFromRepo(registration.Repository) {
+			return nil, applicationRepositoryChangedError(registration.Name, registration.Repository)
+		}
+		existing := applicationModels.NewApplicationRegistrationBuilder().WithRadixRegistration(rr).Build()
+		return &applicationModels.ApplicationImportResponse{ApplicationRegistration: &existing}, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	registration.AppID = ""
+	registration.SharedSecret = ""
+	upsertResponse, err := ah.RegisterApplication(ctx, applicationModels.ApplicationRegistrationRequest{
+		ApplicationRegistration: &registration,
+		AcknowledgeWarnings:     acknowledgeWarnings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := &applicationModels.ApplicationImportResponse{
+		ApplicationRegistration: upsertResponse.ApplicationRegistration,
+		Warnings:                upsertResponse.Warnings,
+	}
+	if response.ApplicationRegistration != nil {
+		response.Items = append(response.Items, applicationModels.ImportItem{
+			Type:    applicationModels.ImportItemTypeSharedSecret,
+			Status:  applicationModels.ImportItemRequiresInput,
+			Message: "A new webhook shared secret was generated. Update the webhook of the GitHub repository",
+		})
+	}
+	return response, nil
+}
+
+// importApplicationSettings imports the settings stored in the namespace of the application. They are pending when namespaceErr is set
+func (ah *ApplicationHandler) importApplicationSettings(ctx context.Context, bundle *applicationModels.ApplicationBundle, namespaceErr error) []applicationModels.ImportItem {
+	appName := bundle.Registration.Name
+	importOrPending := func(create func() error, status applicationModels.ImportItemStatus, message string) (applicationModels.ImportItemStatus, string) {
+		if namespaceErr != nil {
+			return applicationModels.ImportItemPending, namespaceErr.Error()
+		}
+		return importStatus(create(), status, message)
+	}
+	var items []applicationModels.ImportItem
+
+	if len(bundle.BuildSecrets) > 0 {
+		status, message := importOrPending(func() error { return ah.createBuildSecrets(ctx, appName, bundle.BuildSecrets) }, applicationModels.ImportItemRequiresInput, "Set the value of the build secret")
+		for _, name := range bundle.BuildSecrets {
+			items = append(items, applicationModels.ImportItem{Type: applicationModels.ImportItemTypeBuildSecret, Name: name, Status: status, Message: message})
+		}
+	}
+
+	if len(bundle.PrivateImageHubs) > 0 {
+		status, message := importOrPending(func() error { return ah.createPrivateImageHubs(ctx, appName, bundle.PrivateImageHubs) }, applicationModels.ImportItemRequiresInput, "Set the password of the private image hub")
+		for _, imageHub := range bundle.PrivateImageHubs {
+			items = append(items, applicationModels.ImportItem{Type: applicationModels.ImportItemTypePrivateImageHub, Name: imageHub.Server, Status: status, Message: message})
+		}
+	}
+
+	if bundle.Alerting != nil {
+		status, message := importOrPending(func() error {
+			return ah.createRadixAlert(ctx, appName, operatorUtils.GetAppNamespace(appName), importedAlertConfigName, getRadixAlertSpec(*bundle.Alerting))
+		}, applicationModels.ImportItemImported, "")
+		items = append(items, getAlertingImportItems("", *bundle.Alerting, status, message)...)
+	}
+	return items
+}
+
+func (ah *ApplicationHandler) importEnvironment(ctx context.Context, kubeUtil *kube.Kube, appName string, envBundle applicationModels.EnvironmentBundle) []applicationModels.ImportItem {
+	namespace := operatorUtils.GetEnvironmentNamespace(appName, envBundle.Name)
+	_, err := ah.getServiceAccount().Client.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		err = fmt.Errorf("environment %s does not exist yet. Import the bundle again after the first deployment", envBundle.Name)
+	}
+	var items []applicationModels.ImportItem
+
+	if envBundle.Alerting != nil {
+		if err == nil {
+			status, message := importStatus(ah.createRadixAlert(ctx, appName, namespace, importedAlertConfigName, getRadixAlertSpec(*envBundle.Alerting)), applicationModels.ImportItemImported, "")
+			items = append(items, getAlertingImportItems(envBundle.Name, *envBundle.Alerting, status, message)...)
+		} else {
+			items = append(items, getAlertingImportItems(envBundle.Name, *envBundle.Alerting, applicationModels.ImportItemPending, err.Error())...)
+		}
+	}
+
+	for _, override := range envBundle.EnvVarOverrides {
+		item := applicationModels.ImportItem{Type: applicationModels.ImportItemTypeEnvVar, Environment: envBundle.Name, Component: override.Component, Name: override.Name}
+		if err == nil {
+			item.Status, item.Message = ah.importEnvVarOverride(ctx, kubeUtil, appName, envBundle.Name, override)
+		} else {
+			item.Status, item.Message = applicationModels.ImportItemPending, err.Error()
+		}
+		items = append(items, item)
+	}
+
+	// Only secrets which had a value need input, the others were not set in the exported application either
+	for _, secret := range envBundle.Secrets {
+		if secret.Status != secretModels.Consistent.String() {
+			continue
+		}
+		items = append(items, applicationModels.ImportItem{
+			Type:        applicationModels.ImportItemTypeSecret,
+			Environment: envBundle.Name,
+			Component:   secret.Component,
+			Name:        secret.Name,
+			Status:      applicationModels.ImportItemRequiresInput,
+			Message:     "Set the value of the secret",
+		})
+	}
+	return items
+}
+
+func (ah *ApplicationHandler) importEnvVarOverride(ctx context.Context, kubeUtil *kube.Kube, appName, envName string, override applicationModels.EnvVarOverride) (applicationModels.ImportItemStatus, string) {
+	// Environment variables can only be changed when they are defined for the component in the active deployment
+	envVarsConfigMap, _, _, err := kubeUtil.GetEnvVarsConfigMapAndMetadataMap(ctx, operatorUtils.GetEnvironmentNamespace(appName, envName), override.Component)
+	if k8serrors.IsNotFound(err) {
+		return applicationModels.ImportItemPending, fmt.Sprintf("component %s is not deployed to environment %s yet", override.Component, envName)
+	}
+	if err != nil {
+		return applicationModels.ImportItemFailed, err.Error()
+	}
+	if _, ok := envVarsConfigMap.Data[override.Name]; !ok {
+		return applicationModels.ImportItemPending, fmt.Sprintf("environment variable %s is not defined for component %s in the active deployment", override.Name, override.Component)
+	}
+
+	envVarsHandler := environmentvariables.Init(environmentvariables.WithAccounts(ah.accounts))
+	return importStatus(envVarsHandler.ChangeEnvVar(ctx, appName, envName, override.Component, []envvarsModels.EnvVarParameter{{Name: override.Name, Value: override.Value}}), applicationModels.ImportItemImported, "")
+}
+
+// importStatus returns status and message when the item was created, or already existed from an earlier import
+func importStatus(err error, status applicationModels.ImportItemStatus, message string) (applicationModels.ImportItemStatus, string) {
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return applicationModels.ImportItemFailed, err.Error()
+	}
+	return status, message
+}
+
+func getAlertingImportItems(envName string, alertingBundle applicationModels.AlertingBundle, status applicationModels.ImportItemStatus, message string) []applicationModels.ImportItem {
+	items := []applicationModels.ImportItem{{Type: applicationModels.ImportItemTypeAlerting, Environment: envName, Status: status, Message: message}}
+	for _, receiverName := range slices.Sorted(maps.Keys(alertingBundle.ReceiverSecretStatus)) {
+		secretStatus := alertingBundle.ReceiverSecretStatus[receiverName]
+		if secretStatus.SlackConfig == nil || !secretStatus.SlackConfig.WebhookURLConfigured {
+			continue
+		}
+		items = append(items, applicationModels.ImportItem{
+			Type:        applicationModels.ImportItemTypeSlackWebhook,
+			Environment: envName,
+			Name:        receiverName,
+			Status:      applicationModels.ImportItemRequiresInput,
+			Message:     "Set the Slack webhook URL of the receiver",
+		})
+	}
+	return items
+}
+
+func getRadixAlertSpec(alertingBundle applicationModels.AlertingBundle) v1.RadixAlertSpec {
+	receivers := make(v1.ReceiverMap, len(alertingBundle.Receivers))
+	for receiverName, receiver := range alertingBundle.Receivers {
+		receivers[receiverName] = v1.Receiver{SlackConfig: v1.SlackConfig{Enabled: receiver.SlackConfig != nil && receiver.SlackConfig.Enabled}}
+	}
+	return v1.RadixAlertSpec{Receivers: receivers, Alerts: alertingBundle.Alerts.AsRadixAlertAlerts()}
+}
+
+func (ah *ApplicationHandler) getUserKubeUtil() (*kube.Kube, error) {
+	return kube.New(ah.getUserAccount().Client, ah.getUserAccount().RadixClient, ah.getUserAccount().KedaClient, ah.getUserAccount().SecretProviderClient)
+}
@@ -9,6 +9,8 @@ import (
 
 	applicationModels "github.com/equinor/radix-api/api/applications/models"
 	"github.com/equinor/radix-api/api/metrics"
+	"github.com/equinor/radix-api/api/utils/fieldset"
+	"github.com/equinor/radix-api/api/utils/token"
 	"github.com/equinor/radix-api/models"
 	"github.com/gorilla/mux"
 )
@@ -72,16 +74,76 @@ func (ac *applicationController) GetRoutes() models.Routes {
 				Burst: 100,
 			},
 		},
+		models.Route{
+			Path:        rootPath + "/applications/_search",
+			Method:      "POST",
+			HandlerFunc: ac.PostSearchApplications,
+			KubeApiConfig: models.KubeApiConfig{
+				QPS:   100,
+				Burst: 100,
+			},
+		},
+		models.Route{
+			Path:        rootPath + "/applications/_import",
+			Method:      "POST",
+			HandlerFunc: ac.ImportApplication,
+		},
 		models.Route{
 			Path:        appPath,
 			Method:      "GET",
 			HandlerFunc: ac.GetApplication,
+			TokenScope:  token.ScopeRead,
 		},
 		models.Route{
 			Path:        appPath,
 			Method:      "DELETE",
 			HandlerFunc: ac.DeleteApplication,
 		},
+		models.Route{
+			Path:        appPath + "/decommission",
+			Method:      "GET",
+			HandlerFunc: ac.GetApplicationDecommission,
+		},
+		models.Route{
+			Path:        appPath + "/decommission",
+			Method:      "POST",
+			HandlerFunc: ac.DecommissionApplication,
+		},
+		models.Route{
+			Path:        appPath + "/decommission",
+			Method:      "DELETE",
+			HandlerFunc: ac.CancelApplicationDecommission,
+		},
+		models.Route{
+			Path:        appPath + "/ownership-transfer",
+			Method:      "GET",
+			HandlerFunc: ac.GetOwnershipTransfer,
+		},
+		models.Route{
+			Path:        appPath + "/ownership-transfer",
+			Method:      "POST",
+			HandlerFunc: ac.ProposeOwnershipTransfer,
+		},
+		models.Route{
+			Path:        appPath + "/ownership-transfer",
+			Method:      "DELETE",
+			HandlerFunc: ac.CancelOwnershipTransfer,
+		},
+		models.Route{
+			Path:        appPath + "/ownership-transfer/accept",
+			Method:      "POST",
+			HandlerFunc: ac.AcceptOwnershipTransfer,
+		},
+		models.Route{
+			Path:        appPath + "/clone",
+			Method:      "POST",
+			HandlerFunc: ac.CloneApplication,
+		},
+		models.Route{
+			Path:        appPath + "/export",
+			Method:      "GET",
+			HandlerFunc: ac.ExportApplication,
+		},
 		models.Route{
 			Path:        appPath + "/pipelines",
 			Method:      "GET",
@@ -91,26 +153,31 @@ func (ac *applicationController) GetRoutes() models.Routes {
 			Path:        appPath + "/pipelines/build",
 			Method:      "POST",
 			HandlerFunc: ac.TriggerPipelineBuild,
+			TokenScope:  token.ScopePipelinesTrigger,
 		},
 		models.Route{
 			Path:        appPath + "/pipelines/build-deploy",
 			Method:      "POST",
 			HandlerFunc: ac.TriggerPipelineBuildDeploy,
+			TokenScope:  token.ScopePipelinesTrigger,
 		},
 		models.Route{
 			Path:        appPath + "/pipelines/promote",
 			Method:      "POST",
 			HandlerFunc: ac.TriggerPipelinePromote,
+			TokenScope:  token.ScopePipelinesTrigger,
 		},
 		models.Route{
 			Path:        appPath + "/pipelines/deploy",
 			Method:      "POST",
 			HandlerFunc: ac.TriggerPipelineDeploy,
+			TokenScope:  token.ScopePipelinesTrigger,
 		},
 		models.Route{
 			Path:        appPath + "/pipelines/apply-config",
 			Method:      "POST",
 			HandlerFunc: ac.TriggerPipelineApplyConfig,
+			TokenScope:  token.ScopePipelinesTrigger,
 		},
 		models.Route{
 			Path:        appPath + "/deploykey-valid",
@@ -276,14 +343,17 @@ func (ac *applicationController) SearchApplications(accounts models.Accounts, w
 		return
 	}
 
-	// No need to perform search if names in request is empty. Just return empty list
-	if len(appNamesRequest.Names) == 0 {
+	// No need to perform search if names and filter in request are empty. Just return empty list
+	if len(appNamesRequest.Names) == 0 && appNamesRequest.Filter == nil {
 		ac.JSONResponse(w, r, []interface{}{})
 		return
 	}
 
 	handler := ac.applicationHandlerFactory.Create(accounts)
-	matcher := applicationModels.MatchByNamesFunc(appNamesRequest.Names)
+	matcher := applicationModels.MatchAll
+	if len(appNamesRequest.Names) > 0 {
+		matcher = applicationModels.MatchByNamesFunc(appNamesRequest.Names)
+	}
 
 	appRegistrations, err := handler.GetApplications(
 		r.Context(),
@@ -292,6 +362,7 @@ func (ac *applicationController) SearchApplications(accounts models.Accounts, w
 		GetApplicationsOptions{
 			IncludeLatestJobSummary: appNamesRequest.IncludeFields.LatestJobSummary,
 			IncludeEnvironments:     appNamesRequest.IncludeFields.Environments,
+			Filter:                  appNamesRequest.Filter,
 		},
 	)
 	if err != nil {
@@ -302,6 +373,49 @@ func (ac *applicationController) SearchApplications(accounts models.Accounts, w
 	ac.JSONResponse(w, r, appRegistrations)
 }
 
+// PostSearchApplications Gets applications by list of application names and a filter
+func (ac *applicationController) PostSearchApplications(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /applications/_search platform searchApplications
+	//
+	// ---
+	// summary: Get applications by name and filter. NOTE - doesn't get applicationSummary.latestJob.Environments
+	// description: |
+	//   Conditions set in a filter must all match. Filters in "and" must all match, and at least one of the filters in "or" must match.
+	//   When both names and filter are set, applications must match both
+	// parameters:
+	// - name: searchRequest
+	//   in: body
+	//   description: Names, filter and fields to include
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/ApplicationsSearchRequest"
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//        type: "array"
+	//        items:
+	//           "$ref": "#/definitions/ApplicationSummary"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "500":
+	//     description: "Internal server error"
+
+	ac.SearchApplications(accounts, w, r)
+}
+
 // GetApplication Gets application by application name
 func (ac *applicationController) GetApplication(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /applications/{appName} application getApplication
@@ -313,6 +427,11 @@ func (ac *applicationController) GetApplication(accounts models.Accounts, w http
 	//   description: Name of application
 	//   type: string
 	//   required: true
+	// - name: fields
+	//   in: query
+	//   description: Comma separated list of fields to return, e.g. name,environments. Omitted fields are not read from the cluster. All fields are returned when not set
+	//   type: string
+	//   required: false
 	// - name: Impersonate-User
 	//   in: header
 	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
@@ -328,6 +447,8 @@ func (ac *applicationController) GetApplication(accounts models.Accounts, w http
 	//     description: Successful get application
 	//     schema:
 	//       "$ref": "#/definitions/Application"
+	//   "400":
+	//     description: "Invalid fields"
 	//   "401":
 	//     description: "Unauthorized"
 	//   "403":
@@ -340,17 +461,28 @@ func (ac *applicationController) GetApplication(accounts models.Accounts, w http
 	//     description: "Internal server error"
 
 	appName := mux.Vars(r)["appName"]
+	fields, err := fieldset.GetFields(r, applicationModels.Application{})
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
 
 	handler := ac.applicationHandlerFactory.Create(accounts)
 
-	application, err := handler.GetApplication(r.Context(), appName)
+	application, err := handler.GetApplication(r.Context(), appName, fields)
+
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
 
+	response, err := fieldset.Select(application, fields)
 	if err != nil {
 		ac.ErrorResponse(w, r, err)
 		return
 	}
 
-	ac.JSONResponse(w, r, &application)
+	ac.JSONResponse(w, r, response)
 }
 
 // IsDeployKeyValidHandler validates deploy key for radix application found for application name
@@ -712,12 +844,20 @@ func (ac *applicationController) DeleteApplication(accounts models.Accounts, w h
 	// swagger:operation DELETE /applications/{appName} application deleteApplication
 	// ---
 	// summary: Delete application
+	// description: |
+	//   Deletes the application and all its environments immediately.
+	//   With dryRun, the application is not deleted, and the resources which would be deleted are returned.
 	// parameters:
 	// - name: appName
 	//   in: path
 	//   description: name of application
 	//   type: string
 	//   required: true
+	// - name: dryRun
+	//   in: query
+	//   description: true to list the resources which would be deleted, without deleting the application
+	//   type: boolean
+	//   required: false
 	// - name: Impersonate-User
 	//   in: header
 	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
@@ -730,7 +870,9 @@ func (ac *applicationController) DeleteApplication(accounts models.Accounts, w h
 	//   required: false
 	// responses:
 	//   "200":
-	//     description: "Application deleted ok"
+	//     description: "Application deleted ok. The resources which would be deleted when dryRun is true"
+	//     schema:
+	//       "$ref": "#/definitions/ApplicationDeletionPlan"
 	//   "401":
 	//     description: "Unauthorized"
 	//   "403":
@@ -740,6 +882,16 @@ func (ac *applicationController) DeleteApplication(accounts models.Accounts, w h
 	appName := mux.Vars(r)["appName"]
 
 	handler := ac.applicationHandlerFactory.Create(accounts)
+	if dryRun, _ := strconv.ParseBool(r.FormValue("dryRun")); dryRun {
+		plan, err := handler.GetApplicationDeletionPlan(r.Context(), appName)
+		if err != nil {
+			ac.ErrorResponse(w, r, err)
+			return
+		}
+		ac.JSONResponse(w, r, plan)
+		return
+	}
+
 	err := handler.DeleteApplication(r.Context(), appName)
 
 	if err != nil {
@@ -750,6 +902,503 @@ func (ac *applicationController) DeleteApplication(accounts models.Accounts, w h
 	w.WriteHeader(http.StatusOK)
 }
 
+// GetApplicationDecommission Gets the decommission of the application
+func (ac *applicationController) GetApplicationDecommission(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/decommission application getApplicationDecommission
+	// ---
+	// summary: Get the decommission of the application
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of application
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//       "$ref": "#/definitions/ApplicationDecommission"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found, or the application is not decommissioned"
+	appName := mux.Vars(r)["appName"]
+
+	handler := ac.applicationHandlerFactory.Create(accounts)
+	decommission, err := handler.GetApplicationDecommission(r.Context(), appName)
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	ac.JSONResponse(w, r, decommission)
+}
+
+// DecommissionApplication Stops all environments of the application, and deletes it when the grace period has passed
+func (ac *applicationController) DecommissionApplication(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /applications/{appName}/decommission application decommissionApplication
+	// ---
+	// summary: Decommission the application
+	// description: |
+	//   Stops all environments of the application, and deletes the application when the grace period has passed.
+	//   The decommission can be cancelled until the application is deleted, which starts the stopped environments.
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of application
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//       "$ref": "#/definitions/ApplicationDecommission"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found"
+	//   "409":
+	//     description: "The application is already decommissioned"
+	appName := mux.Vars(r)["appName"]
+
+	handler := ac.applicationHandlerFactory.Create(accounts)
+	decommission, err := handler.DecommissionApplication(r.Context(), appName)
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	ac.JSONResponse(w, r, decommission)
+}
+
+// CancelApplicationDecommission Cancels the decommission of the application
+func (ac *applicationController) CancelApplicationDecommission(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /applications/{appName}/decommission application cancelApplicationDecommission
+	// ---
+	// summary: Cancel the decommission of the application, and start the environments stopped by it
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of application
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Decommission cancelled ok"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found, or the application is not decommissioned"
+	appName := mux.Vars(r)["appName"]
+
+	handler := ac.applicationHandlerFactory.Create(accounts)
+	if err := handler.CancelApplicationDecommission(r.Context(), appName); err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetOwnershipTransfer Gets the proposed ownership transfer of the application
+func (ac *applicationController) GetOwnershipTransfer(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/ownership-transfer application getOwnershipTransfer
+	// ---
+	// summary: Get the proposed ownership transfer of the application
+	// description: The proposed new owner can get the transfer without access to the application
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of application
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//       "$ref": "#/definitions/OwnershipTransfer"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found, or no ownership transfer is proposed"
+	appName := mux.Vars(r)["appName"]
+
+	handler := ac.applicationHandlerFactory.Create(accounts)
+	transfer, err := handler.GetOwnershipTransfer(r.Context(), appName)
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	ac.JSONResponse(w, r, transfer)
+}
+
+// ProposeOwnershipTransfer Proposes a new owner and admin groups for the application
+func (ac *applicationController) ProposeOwnershipTransfer(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /applications/{appName}/ownership-transfer application proposeOwnershipTransfer
+	// ---
+	// summary: Propose a new owner and admin groups for the application
+	// description: |
+	//   The owner and admin groups are changed when the proposed new owner accepts the transfer with POST /applications/{appName}/ownership-transfer/accept.
+	//   A new proposal replaces the existing one.
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of application
+	//   type: string
+	//   required: true
+	// - name: transferRequest
+	//   in: body
+	//   description: The proposed new owner and admin groups
+	//   required: true
+	//   schema:
+	//       "$ref": "#/definitions/OwnershipTransferRequest"
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//       "$ref": "#/definitions/OwnershipTransfer"
+	//   "400":
+	//     description: "Invalid ownership transfer, e.g. without admin groups"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+
+	var transferRequest applicationModels.OwnershipTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&transferRequest); err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	handler := ac.applicationHandlerFactory.Create(accounts)
+	transfer, err := handler.ProposeOwnershipTransfer(r.Context(), appName, transferRequest)
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	ac.JSONResponse(w, r, transfer)
+}
+
+// CancelOwnershipTransfer Cancels the proposed ownership transfer of the application
+func (ac *applicationController) CancelOwnershipTransfer(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /applications/{appName}/ownership-transfer application cancelOwnershipTransfer
+	// ---
+	// summary: Cancel the proposed ownership transfer of the application
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of application
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Ownership transfer cancelled ok"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found, or no ownership transfer is proposed"
+	appName := mux.Vars(r)["appName"]
+
+	handler := ac.applicationHandlerFactory.Create(accounts)
+	if err := handler.CancelOwnershipTransfer(r.Context(), appName); err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// AcceptOwnershipTransfer Accepts the proposed ownership transfer of the application
+func (ac *applicationController) AcceptOwnershipTransfer(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /applications/{appName}/ownership-transfer/accept application acceptOwnershipTransfer
+	// ---
+	// summary: Accept the proposed ownership transfer of the application
+	// description: |
+	//   Changes the owner and admin groups of the application to the proposed ones. Only the proposed new owner can accept the transfer,
+	//   and the new owner must be a member of one of the new admin groups.
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of application
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//       "$ref": "#/definitions/ApplicationRegistration"
+	//   "400":
+	//     description: "Invalid ownership transfer"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden, the user is not the proposed new owner or not a member of the new admin groups"
+	//   "404":
+	//     description: "Not found, or no ownership transfer is proposed"
+	//   "409":
+	//     description: "Conflict, the registration was changed while the transfer was applied"
+	appName := mux.Vars(r)["appName"]
+
+	handler := ac.applicationHandlerFactory.Create(accounts)
+	registration, err := handler.AcceptOwnershipTransfer(r.Context(), appName)
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	ac.JSONResponse(w, r, registration)
+}
+
+// CloneApplication Creates a new application from the registration of an existing application
+func (ac *applicationController) CloneApplication(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /applications/{appName}/clone application cloneApplication
+	// ---
+	// summary: Create a new application from the registration of an existing application
+	// description: |
+	//   Registers a new application with the name and repository in the request, and the access groups, owner, configuration item and config of the existing application.
+	//   Build secret names, private image hub servers and the application alerting config are copied when requested, without secret values.
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: Name of the existing application
+	//   type: string
+	//   required: true
+	// - name: cloneRequest
+	//   in: body
+	//   description: Request for the application to create
+	//   required: true
+	//   schema:
+	//       "$ref": "#/definitions/ApplicationCloneRequest"
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: Clone operation details, with the settings which were copied
+	//     schema:
+	//       "$ref": "#/definitions/ApplicationCloneResponse"
+	//   "400":
+	//     description: "Invalid clone request"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found"
+	//   "409":
+	//     description: "Conflict"
+	appName := mux.Vars(r)["appName"]
+
+	var cloneRequest applicationModels.ApplicationCloneRequest
+	if err := json.NewDecoder(r.Body).Decode(&cloneRequest); err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	handler := ac.applicationHandlerFactory.Create(accounts)
+	cloneResponse, err := handler.CloneApplication(r.Context(), appName, cloneRequest)
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	ac.JSONResponse(w, r, &cloneResponse)
+}
+
+// ExportApplication Exports the definition of an application
+func (ac *applicationController) ExportApplication(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/export application exportApplication
+	// ---
+	// summary: Export everything radix-api manages for an application, without secret values
+	// description: |
+	//   The bundle contains the registration, build secret names, private image hubs, alerting configs,
+	//   environment variable overrides, and names and statuses of secrets. It can be imported with POST /applications/_import
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: Name of application
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: Successful export
+	//     schema:
+	//       "$ref": "#/definitions/ApplicationBundle"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+
+	handler := ac.applicationHandlerFactory.Create(accounts)
+	bundle, err := handler.ExportApplication(r.Context(), appName)
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	ac.JSONResponse(w, r, bundle)
+}
+
+// ImportApplication Recreates an application from an exported definition
+func (ac *applicationController) ImportApplication(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /applications/_import platform importApplication
+	// ---
+	// summary: Recreate an application from a bundle exported with GET /applications/{appName}/export
+	// description: |
+	//   Registers the application, and imports its settings. Items needing manual secret input are reported with status RequiresInput.
+	//   Environment settings are imported when the environment exists, and are reported with status Pending otherwise.
+	//   An application registered with the same repository is not changed, so the bundle can be imported again after the first deployment.
+	// parameters:
+	// - name: importRequest
+	//   in: body
+	//   description: Request with the bundle to import
+	//   required: true
+	//   schema:
+	//       "$ref": "#/definitions/ApplicationImportRequest"
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: Import operation details, with the result of each item
+	//     schema:
+	//       "$ref": "#/definitions/ApplicationImportResponse"
+	//   "400":
+	//     description: "Invalid bundle"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "409":
+	//     description: "Conflict"
+	var importRequest applicationModels.ApplicationImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&importRequest); err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	handler := ac.applicationHandlerFactory.Create(accounts)
+	importResponse, err := handler.ImportApplication(r.Context(), importRequest)
+	if err != nil {
+		ac.ErrorResponse(w, r, err)
+		return
+	}
+
+	ac.JSONResponse(w, r, importResponse)
+}
+
 // ListPipelines Lists supported pipelines
 func (ac *applicationController) ListPipelines(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /applications/{appName}/pipelines application listPipelines
@@ -792,6 +1441,11 @@ func (ac *applicationController) TriggerPipelineBuild(accounts models.Accounts,
 	//   required: true
 	//   schema:
 	//     "$ref": "#/definitions/PipelineParametersBuild"
+	// - name: Idempotency-Key
+	//   in: header
+	//   description: Unique key for the request. Replaying the request with the same key and payload returns the existing job instead of starting a new one
+	//   type: string
+	//   required: false
 	// - name: Impersonate-User
 	//   in: header
 	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
@@ -811,6 +1465,8 @@ func (ac *applicationController) TriggerPipelineBuild(accounts models.Accounts,
 	//     description: "Forbidden"
 	//   "404":
 	//     description: "Not found"
+	//   "409":
+	//     description: "Conflict, the Idempotency-Key was used with a different payload"
 	appName := mux.Vars(r)["appName"]
 	handler := ac.applicationHandlerFactory.Create(accounts)
 	jobSummary, err := handler.TriggerPipelineBuild(r.Context(), appName, r)
@@ -840,6 +1496,11 @@ func (ac *applicationController) TriggerPipelineBuildDeploy(accounts models.Acco
 	//   required: true
 	//   schema:
 	//     "$ref": "#/definitions/PipelineParametersBuild"
+	// - name: Idempotency-Key
+	//   in: header
+	//   description: Unique key for the request. Replaying the request with the same key and payload returns the existing job instead of starting a new one
+	//   type: string
+	//   required: false
 	// - name: Impersonate-User
 	//   in: header
 	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
@@ -859,6 +1520,8 @@ func (ac *applicationController) TriggerPipelineBuildDeploy(accounts models.Acco
 	//     description: "Forbidden"
 	//   "404":
 	//     description: "Not found"
+	//   "409":
+	//     description: "Conflict, the Idempotency-Key was used with a different payload"
 	appName := mux.Vars(r)["appName"]
 
 	handler := ac.applicationHandlerFactory.Create(accounts)
@@ -889,6 +1552,11 @@ func (ac *applicationController) TriggerPipelineDeploy(accounts models.Accounts,
 	//   required: true
 	//   schema:
 	//     "$ref": "#/definitions/PipelineParametersDeploy"
+	// - name: Idempotency-Key
+	//   in: header
+	//   description: Unique key for the request. Replaying the request with the same key and payload returns the existing job instead of starting a new one
+	//   type: string
+	//   required: false
 	// - name: Impersonate-User
 	//   in: header
 	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
@@ -908,6 +1576,8 @@ func (ac *applicationController) TriggerPipelineDeploy(accounts models.Accounts,
 	//     description: "Forbidden"
 	//   "404":
 	//     description: "Not found"
+	//   "409":
+	//     description: "Conflict, the Idempotency-Key was used with a different payload"
 	appName := mux.Vars(r)["appName"]
 
 	handler := ac.applicationHandlerFactory.Create(accounts)
@@ -938,6 +1608,11 @@ func (ac *applicationController) TriggerPipelineApplyConfig(accounts models.Acco
 	//   required: true
 	//   schema:
 	//     "$ref": "#/definitions/PipelineParametersApplyConfig"
+	// - name: Idempotency-Key
+	//   in: header
+	//   description: Unique key for the request. Replaying the request with the same key and payload returns the existing job instead of starting a new one
+	//   type: string
+	//   required: false
 	// - name: Impersonate-User
 	//   in: header
 	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
@@ -957,6 +1632,8 @@ func (ac *applicationController) TriggerPipelineApplyConfig(accounts models.Acco
 	//     description: "Forbidden"
 	//   "404":
 	//     description: "Not found"
+	//   "409":
+	//     description: "Conflict, the Idempotency-Key was used with a different payload"
 	appName := mux.Vars(r)["appName"]
 
 	handler := ac.applicationHandlerFactory.Create(accounts)
@@ -987,6 +1664,11 @@ func (ac *applicationController) TriggerPipelinePromote(accounts models.Accounts
 	//   required: true
 	//   schema:
 	//     "$ref": "#/definitions/PipelineParametersPromote"
+	// - name: Idempotency-Key
+	//   in: header
+	//   description: Unique key for the request. Replaying the request with the same key and payload returns the existing job instead of starting a new one
+	//   type: string
+	//   required: false
 	// - name: Impersonate-User
 	//   in: header
 	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
@@ -1004,6 +1686,8 @@ func (ac *applicationController) TriggerPipelinePromote(accounts models.Accounts
 	//       "$ref": "#/definitions/JobSummary"
 	//   "404":
 	//     description: "Not found"
+	//   "409":
+	//     description: "Conflict, the Idempotency-Key was used with a different payload"
 	appName := mux.Vars(r)["appName"]
 
 	handler := ac.applicationHandlerFactory.Create(accounts)
@@ -5,22 +5,31 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	certfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	alertModels "github.com/equinor/radix-api/api/alerting/models"
 	applicationModels "github.com/equinor/radix-api/api/applications/models"
 	environmentModels "github.com/equinor/radix-api/api/environments/models"
 	jobModels "github.com/equinor/radix-api/api/jobs/models"
+	"github.com/equinor/radix-api/api/kubequery"
 	"github.com/equinor/radix-api/api/metrics"
 	mock2 "github.com/equinor/radix-api/api/metrics/mock"
 	"github.com/equinor/radix-api/api/metrics/prometheus"
 	"github.com/equinor/radix-api/api/metrics/prometheus/mock"
+	secretModels "github.com/equinor/radix-api/api/secrets/models"
 	controllertest "github.com/equinor/radix-api/api/test"
 	"github.com/equinor/radix-api/api/utils"
+	"github.com/equinor/radix-api/api/utils/access"
+	"github.com/equinor/radix-api/api/utils/problem"
 	authnmock "github.com/equinor/radix-api/api/utils/token/mock"
 	"github.com/equinor/radix-api/internal/config"
 	"github.com/equinor/radix-api/models"
@@ -43,9 +52,13 @@ import (
 	"github.com/stretchr/testify/require"
 	tektonclientfake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"go.uber.org/mock/gomock"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
 	kubefake "k8s.io/client-go/kubernetes/fake"
+	testing2 "k8s.io/client-go/testing"
 	dynamicclient "sigs.k8s.io/controller-runtime/pkg/client"
 	secretproviderfake "sigs.k8s.io/secrets-store-csi-driver/pkg/client/clientset/versioned/fake"
 )
@@ -194,6 +207,47 @@ func TestGetApplications_HasAccessToSomeRR(t *testing.T) {
 	})
 }
 
+func TestGetApplications_CachesAccessDecisions(t *testing.T) {
+	commonTestUtils, _, kubeclient, radixclient, kedaClient, _, secretproviderclient, certClient, _ := setupTest(t)
+	_, err := commonTestUtils.ApplyRegistration(builders.ARadixRegistration().
+		WithCloneURL("git@github.com:Equinor/my-app.git"))
+	require.NoError(t, err)
+	_, err = commonTestUtils.ApplyRegistration(builders.ARadixRegistration().
+		WithCloneURL("git@github.com:Equinor/my-second-app.git").WithAdGroups([]string{"2"}).WithName("my-second-app"))
+	require.NoError(t, err)
+
+	var accessReviews atomic.Int32
+	mockValidator := authnmock.NewMockValidatorInterface(gomock.NewController(t))
+	mockValidator.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).AnyTimes().Return(controllertest.NewTestPrincipal(true), nil)
+	controllerTestUtils := controllertest.NewTestUtils(kubeclient, radixclient, kedaClient, secretproviderclient, certClient, nil, mockValidator, NewApplicationController(
+		func(_ context.Context, _ kubernetes.Interface, rr v1.RadixRegistration) (bool, error) {
+			accessReviews.Add(1)
+			return rr.GetName() == "my-second-app", nil
+		}, newTestApplicationHandlerFactory(config.Config{},
+			func(ctx context.Context, kubeClient kubernetes.Interface, namespace string, configMapName string) (bool, error) {
+				return true, nil
+			}, WithAccessDecisionCache(access.NewDecisionCache(time.Minute, 100))), createPrometheusHandlerMock(t, nil)))
+	getApplications := func() []applicationModels.ApplicationSummary {
+		response := <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications")
+		applications := make([]applicationModels.ApplicationSummary, 0)
+		require.NoError(t, controllertest.GetResponseBody(response, &applications))
+		return applications
+	}
+
+	assert.Len(t, getApplications(), 1)
+	assert.Len(t, getApplications(), 1)
+	assert.Equal(t, int32(2), accessReviews.Load(), "second request should use the cached decisions")
+
+	rr, err := radixclient.RadixV1().RadixRegistrations().Get(context.Background(), "my-second-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	rr.Spec.AdGroups = []string{"3"}
+	_, err = radixclient.RadixV1().RadixRegistrations().Update(context.Background(), rr, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	assert.Len(t, getApplications(), 1)
+	assert.Equal(t, int32(3), accessReviews.Load(), "changed AdGroups should invalidate the cached decision")
+}
+
 func TestGetApplications_WithFilterOnSSHRepo_Filter(t *testing.T) {
 	// Setup
 	commonTestUtils, controllerTestUtils, _, _, _, _, _, _, _ := setupTest(t)
@@ -405,6 +459,64 @@ func TestSearchApplicationsGet_WithJobs_ShouldOnlyHaveLatest(t *testing.T) {
 	}
 }
 
+func TestSearchApplicationsPost_WithFilter(t *testing.T) {
+	// Setup
+	commonTestUtils, controllerTestUtils, kubeclient, _, _, _, _, _, _ := setupTest(t)
+	registrations := []builders.RegistrationBuilder{
+		builders.ARadixRegistration().WithName("app-1").WithOwner("owner-a@equinor.com").WithCloneURL("git@github.com:Equinor/radix-app-1.git"),
+		builders.ARadixRegistration().WithName("app-2").WithOwner("owner-b@equinor.com").WithCloneURL("git@github.com:Equinor/radix-app-2.git").WithAdGroups([]string{"group-2"}),
+		builders.ARadixRegistration().WithName("app-3").WithOwner("owner-a@equinor.com").WithCloneURL("git@github.com:Other/app-3.git"),
+	}
+	for _, rr := range registrations {
+		_, err := commonTestUtils.ApplyRegistration(rr)
+		require.NoError(t, err)
+	}
+	commontest.CreateAppNamespace(kubeclient, "app-1")
+	_, err := commonTestUtils.ApplyJob(builders.ARadixBuildDeployJob().
+		WithAppName("app-1").
+		WithJobName("app-1-job-1").
+		WithStatus(builders.NewJobStatusBuilder().WithCondition(v1.JobFailed).WithStarted(time.Now().UTC())))
+	require.NoError(t, err)
+
+	search := func(t *testing.T, searchRequest applicationModels.ApplicationsSearchRequest) []string {
+		response := <-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications/_search", searchRequest)
+		require.Equal(t, http.StatusOK, response.Code)
+		var applications []applicationModels.ApplicationSummary
+		require.NoError(t, controllertest.GetResponseBody(response, &applications))
+		return slice.Map(applications, func(app applicationModels.ApplicationSummary) string {
+			assert.Nil(t, app.LatestJob, "latest job is only included when requested")
+			return app.Name
+		})
+	}
+
+	t.Run("owner is case-insensitive", func(t *testing.T) {
+		actual := search(t, applicationModels.ApplicationsSearchRequest{Filter: &applicationModels.ApplicationSearchFilter{Owner: "Owner-A@equinor.com"}})
+		assert.Equal(t, []string{"app-1", "app-3"}, actual)
+	})
+
+	t.Run("repository and AD group", func(t *testing.T) {
+		actual := search(t, applicationModels.ApplicationsSearchRequest{Filter: &applicationModels.ApplicationSearchFilter{Repository: "equinor/radix", AdGroup: "group-2"}})
+		assert.Equal(t, []string{"app-2"}, actual)
+	})
+
+	t.Run("owner or failing job", func(t *testing.T) {
+		actual := search(t, applicationModels.ApplicationsSearchRequest{Filter: &applicationModels.ApplicationSearchFilter{Or: []applicationModels.ApplicationSearchFilter{
+			{Owner: "owner-b@equinor.com"},
+			{HasFailingJob: pointers.Ptr(true)},
+		}}})
+		assert.Equal(t, []string{"app-1", "app-2"}, actual)
+	})
+
+	t.Run("names and filter", func(t *testing.T) {
+		actual := search(t, applicationModels.ApplicationsSearchRequest{Names: []string{"app-1", "app-2"}, Filter: &applicationModels.ApplicationSearchFilter{Owner: "owner-a@equinor.com"}})
+		assert.Equal(t, []string{"app-1"}, actual)
+	})
+
+	t.Run("no names or filter", func(t *testing.T) {
+		assert.Empty(t, search(t, applicationModels.ApplicationsSearchRequest{}))
+	})
+}
+
 // Test warning, require acks
 func TestCreateApplication_Warnings_ShouldWarn(t *testing.T) {
 	// Setup
@@ -492,6 +604,33 @@ func TestGetApplication_AllFieldsAreSet(t *testing.T) {
 	assert.Equal(t, "ci", application.Registration.ConfigurationItem)
 }
 
+func TestGetApplication_ETag(t *testing.T) {
+	// Setup
+	commonTestUtils, controllerTestUtils, kubeclient, _, _, _, _, _, _ := setupTest(t)
+	_, err := commonTestUtils.ApplyRegistration(builders.ARadixRegistration().
+		WithName("any-name"))
+	require.NoError(t, err)
+	commontest.CreateAppNamespace(kubeclient, "any-name")
+
+	// Test
+	responseChannel := controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications/any-name")
+	response := <-responseChannel
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	responseChannel = controllerTestUtils.ExecuteRequestWithHeaders("GET", "/api/v1/applications/any-name", nil, http.Header{"If-None-Match": {etag}})
+	response = <-responseChannel
+	assert.Equal(t, http.StatusNotModified, response.Code)
+	assert.Empty(t, response.Body.Bytes())
+	assert.Equal(t, etag, response.Header().Get("ETag"))
+
+	responseChannel = controllerTestUtils.ExecuteRequestWithHeaders("GET", "/api/v1/applications/any-name", nil, http.Header{"If-None-Match": {`"outdated"`}})
+	response = <-responseChannel
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.NotEmpty(t, response.Body.Bytes())
+}
+
 func TestGetApplication_WithJobs(t *testing.T) {
 	// Setup
 	commonTestUtils, controllerTestUtils, kubeclient, _, _, _, _, _, _ := setupTest(t)
@@ -521,6 +660,36 @@ func TestGetApplication_WithJobs(t *testing.T) {
 	assert.Equal(t, 3, len(application.Jobs))
 }
 
+func TestGetApplication_Fields(t *testing.T) {
+	// Setup
+	commonTestUtils, controllerTestUtils, kubeclient, _, _, _, _, _, _ := setupTest(t)
+	_, err := commonTestUtils.ApplyRegistration(builders.ARadixRegistration().
+		WithName("any-name"))
+	require.NoError(t, err)
+	commontest.CreateAppNamespace(kubeclient, "any-name")
+	jobStarted, _ := radixutils.ParseTimestamp("2018-11-12T11:45:26Z")
+	err = createRadixJob(commonTestUtils, "any-name", "any-name-job-1", jobStarted)
+	require.NoError(t, err)
+
+	// Test
+	responseChannel := controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications/any-name?fields=name,jobs")
+	response := <-responseChannel
+	require.Equal(t, http.StatusOK, response.Code)
+	var body map[string]json.RawMessage
+	err = json.Unmarshal(response.Body.Bytes(), &body)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"name", "jobs"}, slices.Collect(maps.Keys(body)))
+	application := applicationModels.Application{}
+	err = controllertest.GetResponseBody(response, &application)
+	require.NoError(t, err)
+	assert.Equal(t, "any-name", application.Name)
+	assert.Len(t, application.Jobs, 1)
+
+	responseChannel = controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications/any-name?fields=name,unknown")
+	response = <-responseChannel
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
 func TestGetApplication_BuildKitOptions(t *testing.T) {
 	scenarios := map[string]struct {
 		useBuildKit           *bool
@@ -977,9 +1146,12 @@ func TestHandleTriggerPipeline_ForNonMappedAndMappedAndMagicBranchEnvironment_Jo
 	response := <-responseChannel
 
 	assert.Equal(t, http.StatusBadRequest, response.Code)
-	errorResponse, _ := controllertest.GetErrorResponse(response)
+	errorResponse, _ := controllertest.GetProblemResponse(response)
 	expectedError := applicationModels.UnmatchedBranchToEnvironment(unmappedBranch)
-	assert.Equal(t, (expectedError.(*radixhttp.Error)).Message, errorResponse.Message)
+	var expectedHttpErr *radixhttp.Error
+	require.ErrorAs(t, expectedError, &expectedHttpErr)
+	assert.Equal(t, expectedHttpErr.Message, errorResponse.Message)
+	assert.Equal(t, problem.GetCode(expectedError), errorResponse.Code)
 
 	// Mapped branch should start job
 	parameters = applicationModels.PipelineParametersBuild{Branch: "dev"}
@@ -1018,7 +1190,7 @@ func TestHandleTriggerPipeline_ExistingAndNonExistingApplication_JobIsCreatedFor
 	response := <-responseChannel
 
 	assert.Equal(t, http.StatusNotFound, response.Code)
-	errorResponse, _ := controllertest.GetErrorResponse(response)
+	errorResponse, _ := controllertest.GetProblemResponse(response)
 	assert.Equal(t, controllertest.AppNotFoundErrorMsg("another-app"), errorResponse.Message)
 
 	parameters = applicationModels.PipelineParametersBuild{Branch: "", CommitID: pushCommitID}
@@ -1026,9 +1198,12 @@ func TestHandleTriggerPipeline_ExistingAndNonExistingApplication_JobIsCreatedFor
 	response = <-responseChannel
 
 	assert.Equal(t, http.StatusBadRequest, response.Code)
-	errorResponse, _ = controllertest.GetErrorResponse(response)
+	errorResponse, _ = controllertest.GetProblemResponse(response)
 	expectedError := applicationModels.AppNameAndBranchAreRequiredForStartingPipeline()
-	assert.Equal(t, (expectedError.(*radixhttp.Error)).Message, errorResponse.Message)
+	var expectedHttpErr *radixhttp.Error
+	require.ErrorAs(t, expectedError, &expectedHttpErr)
+	assert.Equal(t, expectedHttpErr.Message, errorResponse.Message)
+	assert.Equal(t, problem.GetCode(expectedError), errorResponse.Code)
 
 	parameters = applicationModels.PipelineParametersBuild{Branch: "maincfg", CommitID: pushCommitID}
 	responseChannel = controllerTestUtils.ExecuteRequestWithParameters("POST", fmt.Sprintf("/api/v1/applications/%s/pipelines/%s", "any-app", v1.BuildDeploy), parameters)
@@ -1085,6 +1260,92 @@ func TestHandleTriggerPipeline_Deploy_JobHasCorrectParameters(t *testing.T) {
 	}
 }
 
+func TestHandleTriggerPipeline_IdempotencyKey(t *testing.T) {
+	appName := "an-app"
+	appNamespace := builders.GetAppNamespace(appName)
+	_, controllerTestUtils, _, radixclient, _, _, _, _, _ := setupTest(t)
+	radixclient.PrependReactor("create", "radixjobs", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		if job, ok := action.(testing2.CreateAction).GetObject().(*v1.RadixJob); ok {
+			job.CreationTimestamp = metav1.Now()
+		}
+		return false, nil, nil
+	})
+	registerAppParam := buildApplicationRegistrationRequest(anApplicationRegistration().WithName(appName).Build(), false)
+	<-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications", registerAppParam)
+	endpoint := fmt.Sprintf("/api/v1/applications/%s/pipelines/%s", appName, v1.Deploy)
+	params := applicationModels.PipelineParametersDeploy{ToEnvironment: "target"}
+	headers := http.Header{"Idempotency-Key": []string{"any-key"}}
+
+	response := <-controllerTestUtils.ExecuteRequestWithHeaders("POST", endpoint, params, headers)
+	require.Equal(t, http.StatusOK, response.Code)
+	var first jobModels.JobSummary
+	require.NoError(t, controllertest.GetResponseBody(response, &first))
+
+	response = <-controllerTestUtils.ExecuteRequestWithHeaders("POST", endpoint, params, headers)
+	require.Equal(t, http.StatusOK, response.Code)
+	var replayed jobModels.JobSummary
+	require.NoError(t, controllertest.GetResponseBody(response, &replayed))
+	assert.Equal(t, first.Name, replayed.Name, "replay should return the existing job")
+	jobs, err := getJobsInNamespace(radixclient, appNamespace)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+
+	response = <-controllerTestUtils.ExecuteRequestWithHeaders("POST", endpoint, applicationModels.PipelineParametersDeploy{ToEnvironment: "other"}, headers)
+	assert.Equal(t, http.StatusConflict, response.Code, "replay with a different payload should conflict")
+
+	response = <-controllerTestUtils.ExecuteRequestWithHeaders("POST", endpoint, params, http.Header{"Idempotency-Key": []string{"other-key"}})
+	require.Equal(t, http.StatusOK, response.Code)
+	response = <-controllerTestUtils.ExecuteRequestWithParameters("POST", endpoint, params)
+	require.Equal(t, http.StatusOK, response.Code)
+	jobs, err = getJobsInNamespace(radixclient, appNamespace)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 3, "other keys, or no key, should start new jobs")
+
+	job, err := radixclient.RadixV1().RadixJobs(appNamespace).Get(context.Background(), first.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	job.CreationTimestamp = metav1.NewTime(time.Now().Add(-25 * time.Hour))
+	_, err = radixclient.RadixV1().RadixJobs(appNamespace).Update(context.Background(), job, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	response = <-controllerTestUtils.ExecuteRequestWithHeaders("POST", endpoint, applicationModels.PipelineParametersDeploy{ToEnvironment: "other"}, headers)
+	require.Equal(t, http.StatusOK, response.Code, "key should be reusable after the time window")
+	require.NoError(t, controllertest.GetResponseBody(response, &replayed))
+	assert.NotEqual(t, first.Name, replayed.Name)
+}
+
+func TestHandleTriggerPipeline_IdempotencyKey_ConcurrentRequests(t *testing.T) {
+	appName := "an-app"
+	appNamespace := builders.GetAppNamespace(appName)
+	_, controllerTestUtils, _, radixclient, _, _, _, _, _ := setupTest(t)
+	registerAppParam := buildApplicationRegistrationRequest(anApplicationRegistration().WithName(appName).Build(), false)
+	<-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications", registerAppParam)
+	// Neither request sees the job of the other when looking for an earlier job with the key
+	var hideJobs atomic.Bool
+	hideJobs.Store(true)
+	radixclient.PrependReactor("list", "radixjobs", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		return hideJobs.Load(), &v1.RadixJobList{}, nil
+	})
+	endpoint := fmt.Sprintf("/api/v1/applications/%s/pipelines/%s", appName, v1.Deploy)
+	params := applicationModels.PipelineParametersDeploy{ToEnvironment: "target"}
+	headers := http.Header{"Idempotency-Key": []string{"any-key"}}
+
+	firstResponse := controllerTestUtils.ExecuteRequestWithHeaders("POST", endpoint, params, headers)
+	secondResponse := controllerTestUtils.ExecuteRequestWithHeaders("POST", endpoint, params, headers)
+	var jobNames []string
+	for _, responses := range []<-chan *httptest.ResponseRecorder{firstResponse, secondResponse} {
+		response := <-responses
+		require.Equal(t, http.StatusOK, response.Code)
+		var job jobModels.JobSummary
+		require.NoError(t, controllertest.GetResponseBody(response, &job))
+		jobNames = append(jobNames, job.Name)
+	}
+	assert.Equal(t, jobNames[0], jobNames[1], "both requests should return the same job")
+
+	hideJobs.Store(false)
+	jobs, err := getJobsInNamespace(radixclient, appNamespace)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+}
+
 func TestHandleTriggerPipeline_Promote_JobHasCorrectParameters(t *testing.T) {
 
 	const (
@@ -1207,6 +1468,351 @@ func TestDeleteApplication_ApplicationIsDeleted(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, response.Code)
 }
 
+func TestDeleteApplication_DryRun(t *testing.T) {
+	// Setup
+	commonTestUtils, controllerTestUtils, kubeclient, radixclient, _, _, _, _, _ := setupTest(t)
+	_, err := commonTestUtils.ApplyRegistration(builders.ARadixRegistration().WithName("my-app"))
+	require.NoError(t, err)
+	rd, err := commonTestUtils.ApplyDeployment(context.Background(), builders.
+		NewDeploymentBuilder().
+		WithAppName("my-app").
+		WithEnvironment("dev").
+		WithImageTag("anytag"))
+	require.NoError(t, err)
+	for _, secret := range []corev1.Secret{
+		{ObjectMeta: metav1.ObjectMeta{Name: "app-secret", Namespace: "my-app-app"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "dev-secret", Namespace: "my-app-dev"}},
+	} {
+		_, err = kubeclient.CoreV1().Secrets(secret.Namespace).Create(context.Background(), &secret, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	// Test
+	response := <-controllerTestUtils.ExecuteRequest("DELETE", "/api/v1/applications/my-app?dryRun=true")
+	require.Equal(t, http.StatusOK, response.Code)
+	plan := applicationModels.ApplicationDeletionPlan{}
+	require.NoError(t, controllertest.GetResponseBody(response, &plan))
+
+	assert.Equal(t, []string{"my-app-app", "my-app-dev"}, plan.Namespaces)
+	assert.Equal(t, []applicationModels.NamespacedResource{{Namespace: "my-app-dev", Name: rd.Name}}, plan.Deployments)
+	assert.Equal(t, []applicationModels.NamespacedResource{{Namespace: "my-app-app", Name: "app-secret"}, {Namespace: "my-app-dev", Name: "dev-secret"}}, plan.Secrets)
+	_, err = radixclient.RadixV1().RadixRegistrations().Get(context.Background(), "my-app", metav1.GetOptions{})
+	assert.NoError(t, err, "application should not be deleted by a dry run")
+}
+
+func TestDecommissionApplication(t *testing.T) {
+	// Setup
+	commonTestUtils, controllerTestUtils, _, radixclient, _, _, _, _, _ := setupTest(t)
+	_, err := commonTestUtils.ApplyRegistration(builders.ARadixRegistration().WithName("my-app"))
+	require.NoError(t, err)
+	_, err = commonTestUtils.ApplyDeployment(context.Background(), builders.
+		NewDeploymentBuilder().
+		WithAppName("my-app").
+		WithEnvironment("dev").
+		WithImageTag("anytag").
+		WithComponent(builders.NewDeployComponentBuilder().WithName("frontend")))
+	require.NoError(t, err)
+	_, err = commonTestUtils.ApplyDeployment(context.Background(), builders.
+		NewDeploymentBuilder().
+		WithAppName("my-app").
+		WithEnvironment("prod").
+		WithImageTag("anytag").
+		WithComponent(builders.NewDeployComponentBuilder().WithName("frontend").WithReplicasOverride(pointers.Ptr(0))))
+	require.NoError(t, err)
+
+	// Test
+	response := <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications/my-app/decommission")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	response = <-controllerTestUtils.ExecuteRequest("POST", "/api/v1/applications/my-app/decommission")
+	require.Equal(t, http.StatusOK, response.Code)
+	decommission := applicationModels.ApplicationDecommission{}
+	require.NoError(t, controllertest.GetResponseBody(response, &decommission))
+	assert.Equal(t, []string{"dev"}, decommission.StoppedEnvironments, "only environments with running components should be recorded as stopped")
+	assert.False(t, decommission.Requested.IsZero())
+	assert.False(t, decommission.DeleteAfter.Before(decommission.Requested))
+	rd, err := kubequery.GetLatestRadixDeployment(context.Background(), radixclient, "my-app", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, pointers.Ptr(0), rd.Spec.Components[0].ReplicasOverride)
+
+	response = <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications/my-app/decommission")
+	require.Equal(t, http.StatusOK, response.Code)
+	actual := applicationModels.ApplicationDecommission{}
+	require.NoError(t, controllertest.GetResponseBody(response, &actual))
+	assert.Equal(t, decommission, actual)
+
+	response = <-controllerTestUtils.ExecuteRequest("POST", "/api/v1/applications/my-app/decommission")
+	assert.Equal(t, http.StatusConflict, response.Code)
+
+	response = <-controllerTestUtils.ExecuteRequest("DELETE", "/api/v1/applications/my-app/decommission")
+	require.Equal(t, http.StatusOK, response.Code)
+	rd, err = kubequery.GetLatestRadixDeployment(context.Background(), radixclient, "my-app", "dev")
+	require.NoError(t, err)
+	assert.Nil(t, rd.Spec.Components[0].ReplicasOverride, "stopped environments should be started when the decommission is cancelled")
+	rd, err = kubequery.GetLatestRadixDeployment(context.Background(), radixclient, "my-app", "prod")
+	require.NoError(t, err)
+	assert.Equal(t, pointers.Ptr(0), rd.Spec.Components[0].ReplicasOverride, "environments stopped before the decommission should not be started when it is cancelled")
+
+	response = <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications/my-app/decommission")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	response = <-controllerTestUtils.ExecuteRequest("DELETE", "/api/v1/applications/my-app/decommission")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestOwnershipTransfer(t *testing.T) {
+	// Setup
+	commonTestUtils, controllerTestUtils, _, radixclient, _, _, _, _, _ := setupTest(t)
+	_, err := commonTestUtils.ApplyRegistration(builders.ARadixRegistration().WithName("my-app").WithOwner("previous-owner@equinor.com").WithAdGroups([]string{"previous-admins"}))
+	require.NoError(t, err)
+
+	// Test
+	response := <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications/my-app/ownership-transfer")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+
+	response = <-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications/my-app/ownership-transfer", applicationModels.OwnershipTransferRequest{Owner: "test-principal"})
+	assert.Equal(t, http.StatusBadRequest, response.Code, "a transfer leaving the application without admin groups should be rejected")
+
+	response = <-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications/my-app/ownership-transfer", applicationModels.OwnershipTransferRequest{Owner: "another-user@equinor.com", AdGroups: []string{"new-admins"}})
+	require.Equal(t, http.StatusOK, response.Code)
+	response = <-controllerTestUtils.ExecuteRequest("POST", "/api/v1/applications/my-app/ownership-transfer/accept")
+	assert.Equal(t, http.StatusForbidden, response.Code, "only the proposed new owner should be allowed to accept the transfer")
+
+	response = <-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications/my-app/ownership-transfer", applicationModels.OwnershipTransferRequest{Owner: "test-principal", AdGroups: []string{"new-admins"}})
+	require.Equal(t, http.StatusOK, response.Code)
+	transfer := applicationModels.OwnershipTransfer{}
+	require.NoError(t, controllertest.GetResponseBody(response, &transfer))
+	assert.Equal(t, "test-principal", transfer.Owner)
+	assert.Equal(t, []string{"new-admins"}, transfer.AdGroups)
+	assert.False(t, transfer.Proposed.IsZero())
+
+	response = <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications/my-app/ownership-transfer")
+	require.Equal(t, http.StatusOK, response.Code)
+	actual := applicationModels.OwnershipTransfer{}
+	require.NoError(t, controllertest.GetResponseBody(response, &actual))
+	assert.Equal(t, transfer, actual)
+
+	impersonation := http.Header{"Impersonate-User": {"test-principal"}, "Impersonate-Group": {"new-admins"}}
+	response = <-controllerTestUtils.ExecuteRequestWithHeaders("POST", "/api/v1/applications/my-app/ownership-transfer/accept", nil, impersonation)
+	assert.Equal(t, http.StatusForbidden, response.Code, "impersonated requests should not be allowed to accept the transfer")
+
+	response = <-controllerTestUtils.ExecuteRequest("POST", "/api/v1/applications/my-app/ownership-transfer/accept")
+	require.Equal(t, http.StatusOK, response.Code)
+	registration := applicationModels.ApplicationRegistration{}
+	require.NoError(t, controllertest.GetResponseBody(response, &registration))
+	assert.Equal(t, "test-principal", registration.Owner)
+	assert.Equal(t, []string{"new-admins"}, registration.AdGroups)
+	rr, err := radixclient.RadixV1().RadixRegistrations().Get(context.Background(), "my-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "test-principal", rr.Spec.Owner)
+	assert.Equal(t, []string{"new-admins"}, rr.Spec.AdGroups)
+	assert.NotContains(t, rr.Annotations, "radix.equinor.com/ownership-transfer")
+
+	response = <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications/my-app/ownership-transfer")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	response = <-controllerTestUtils.ExecuteRequest("POST", "/api/v1/applications/my-app/ownership-transfer/accept")
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func TestCloneApplication_CopiesRegistrationAndSettings(t *testing.T) {
+	// Setup
+	commonTestUtils, controllerTestUtils, client, radixclient, _, _, _, _, _ := setupTest(t)
+	_, err := commonTestUtils.ApplyApplication(builders.
+		ARadixApplication().
+		WithAppName("my-app").
+		WithBuildSecrets("secret2", "secret1").
+		WithPrivateImageRegistry("myregistry.azurecr.io", "any-user", "any-user@equinor.com"))
+	require.NoError(t, err)
+	sourceAlert := v1.RadixAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "alerting", Labels: map[string]string{kube.RadixAppLabel: "my-app"}},
+		Spec: v1.RadixAlertSpec{
+			Receivers: v1.ReceiverMap{"slack": v1.Receiver{SlackConfig: v1.SlackConfig{Enabled: true}}},
+			Alerts:    []v1.Alert{{Alert: "RadixAppComponentCrashLooping", Receiver: "slack"}},
+		},
+	}
+	_, err = radixclient.RadixV1().RadixAlerts("my-app-app").Create(context.Background(), &sourceAlert, metav1.CreateOptions{})
+	require.NoError(t, err)
+	// The namespace of the new application is created by the operator
+	_, err = client.CoreV1().Namespaces().Create(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "new-app-app"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// Test
+	cloneRequest := applicationModels.ApplicationCloneRequest{
+		Name:                 "new-app",
+		Repository:           "https://github.com/Equinor/new-app",
+		CopyBuildSecrets:     true,
+		CopyPrivateImageHubs: true,
+		CopyAlerting:         true,
+	}
+	response := <-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications/my-app/clone", cloneRequest)
+	require.Equal(t, http.StatusOK, response.Code)
+	cloneResponse := applicationModels.ApplicationCloneResponse{}
+	require.NoError(t, controllertest.GetResponseBody(response, &cloneResponse))
+
+	sourceRR, err := radixclient.RadixV1().RadixRegistrations().Get(context.Background(), "my-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	newRR, err := radixclient.RadixV1().RadixRegistrations().Get(context.Background(), "new-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "git@github.com:Equinor/new-app.git", newRR.Spec.CloneURL)
+	assert.Equal(t, sourceRR.Spec.AdGroups, newRR.Spec.AdGroups)
+	assert.Equal(t, sourceRR.Spec.ConfigBranch, newRR.Spec.ConfigBranch)
+	assert.NotEqual(t, sourceRR.Spec.AppID, newRR.Spec.AppID)
+	assert.NotEqual(t, sourceRR.Spec.SharedSecret, newRR.Spec.SharedSecret)
+	require.NotNil(t, cloneResponse.ApplicationRegistration)
+	assert.Equal(t, "new-app", cloneResponse.ApplicationRegistration.Name)
+	assert.Empty(t, cloneResponse.Failures)
+	assert.Equal(t, &applicationModels.ClonedSettings{
+		BuildSecrets:     []string{"secret1", "secret2"},
+		PrivateImageHubs: []string{"myregistry.azurecr.io"},
+		Alerting:         true,
+	}, cloneResponse.Copied)
+
+	buildSecrets, err := client.CoreV1().Secrets("new-app-app").Get(context.Background(), defaults.BuildSecretsName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"secret1": []byte(defaults.BuildSecretDefaultData), "secret2": []byte(defaults.BuildSecretDefaultData)}, buildSecrets.Data)
+	imageHubs, err := client.CoreV1().Secrets("new-app-app").Get(context.Background(), defaults.PrivateImageHubSecretName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"auths":{"myregistry.azurecr.io":{"username":"any-user","email":"any-user@equinor.com"}}}`, string(imageHubs.Data[corev1.DockerConfigJsonKey]))
+	newAlert, err := radixclient.RadixV1().RadixAlerts("new-app-app").Get(context.Background(), "alerting", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "new-app", newAlert.Labels[kube.RadixAppLabel])
+	assert.Equal(t, sourceAlert.Spec, newAlert.Spec)
+}
+
+func TestCloneApplication_InvalidRequest(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		appName        string
+		cloneRequest   applicationModels.ApplicationCloneRequest
+		expectedStatus int
+	}{
+		{name: "missing name", appName: "my-app", cloneRequest: applicationModels.ApplicationCloneRequest{Repository: "https://github.com/Equinor/new-app"}, expectedStatus: http.StatusBadRequest},
+		{name: "same name", appName: "my-app", cloneRequest: applicationModels.ApplicationCloneRequest{Name: "my-app", Repository: "https://github.com/Equinor/new-app"}, expectedStatus: http.StatusBadRequest},
+		{name: "missing repository", appName: "my-app", cloneRequest: applicationModels.ApplicationCloneRequest{Name: "new-app"}, expectedStatus: http.StatusBadRequest},
+		{name: "application does not exist", appName: "any-non-existing", cloneRequest: applicationModels.ApplicationCloneRequest{Name: "new-app", Repository: "https://github.com/Equinor/new-app"}, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, ts := range scenarios {
+		t.Run(ts.name, func(t *testing.T) {
+			commonTestUtils, controllerTestUtils, _, radixclient, _, _, _, _, _ := setupTest(t)
+			_, err := commonTestUtils.ApplyRegistration(builders.ARadixRegistration().WithName("my-app"))
+			require.NoError(t, err)
+
+			response := <-controllerTestUtils.ExecuteRequestWithParameters("POST", fmt.Sprintf("/api/v1/applications/%s/clone", ts.appName), ts.cloneRequest)
+			assert.Equal(t, ts.expectedStatus, response.Code)
+			_, err = radixclient.RadixV1().RadixRegistrations().Get(context.Background(), "new-app", metav1.GetOptions{})
+			assert.True(t, k8serrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestExportApplication(t *testing.T) {
+	// Setup
+	commonTestUtils, controllerTestUtils, _, radixclient, _, _, _, _, _ := setupTest(t)
+	_, err := commonTestUtils.ApplyApplication(builders.
+		ARadixApplication().
+		WithAppName("my-app").
+		WithEnvironment("dev", "master").
+		WithBuildSecrets("secret2", "secret1").
+		WithPrivateImageRegistry("myregistry.azurecr.io", "any-user", "any-user@equinor.com"))
+	require.NoError(t, err)
+	_, err = radixclient.RadixV1().RadixAlerts("my-app-app").Create(context.Background(), &v1.RadixAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "alerting", Labels: map[string]string{kube.RadixAppLabel: "my-app"}},
+		Spec: v1.RadixAlertSpec{
+			Receivers: v1.ReceiverMap{"slack": v1.Receiver{SlackConfig: v1.SlackConfig{Enabled: true}}},
+			Alerts:    []v1.Alert{{Alert: "RadixAppComponentCrashLooping", Receiver: "slack"}},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// Test
+	response := <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/applications/my-app/export")
+	require.Equal(t, http.StatusOK, response.Code)
+	bundle := applicationModels.ApplicationBundle{}
+	require.NoError(t, controllertest.GetResponseBody(response, &bundle))
+
+	assert.Equal(t, applicationModels.ApplicationBundleVersion, bundle.Version)
+	assert.Equal(t, "my-app", bundle.Registration.Name)
+	assert.Empty(t, bundle.Registration.SharedSecret)
+	assert.Equal(t, []string{"secret1", "secret2"}, bundle.BuildSecrets)
+	assert.Equal(t, []applicationModels.PrivateImageHub{{Server: "myregistry.azurecr.io", Username: "any-user", Email: "any-user@equinor.com"}}, bundle.PrivateImageHubs)
+	require.NotNil(t, bundle.Alerting)
+	assert.Equal(t, []alertModels.AlertConfig{{Alert: "RadixAppComponentCrashLooping", Receiver: "slack"}}, []alertModels.AlertConfig(bundle.Alerting.Alerts))
+	assert.Equal(t, []applicationModels.EnvironmentBundle{{Name: "dev"}}, bundle.Environments)
+}
+
+func TestImportApplication(t *testing.T) {
+	bundle := applicationModels.ApplicationBundle{
+		Version:          applicationModels.ApplicationBundleVersion,
+		Registration:     anApplicationRegistration().WithName("my-app").WithSharedSecret("").Build(),
+		BuildSecrets:     []string{"secret1"},
+		PrivateImageHubs: []applicationModels.PrivateImageHub{{Server: "myregistry.azurecr.io", Username: "any-user"}},
+		Alerting: &applicationModels.AlertingBundle{
+			Receivers:            alertModels.ReceiverConfigMap{"slack": alertModels.ReceiverConfig{SlackConfig: &alertModels.SlackConfig{Enabled: true}}},
+			ReceiverSecretStatus: alertModels.ReceiverConfigSecretStatusMap{"slack": alertModels.ReceiverConfigSecretStatus{SlackConfig: &alertModels.SlackConfigSecretStatus{WebhookURLConfigured: true}}},
+			Alerts:               alertModels.AlertConfigList{{Alert: "RadixAppComponentCrashLooping", Receiver: "slack"}},
+		},
+		Environments: []applicationModels.EnvironmentBundle{{
+			Name:            "dev",
+			EnvVarOverrides: []applicationModels.EnvVarOverride{{Component: "api", Name: "VAR1", Value: "value1"}},
+			Secrets:         []secretModels.Secret{{Name: "SECRET1", Component: "api", Status: "Consistent"}, {Name: "SECRET2", Component: "api", Status: "Pending"}},
+		}},
+	}
+
+	// Setup
+	_, controllerTestUtils, client, radixclient, _, _, _, _, _ := setupTest(t)
+	// The namespace of the application is created by the operator
+	_, err := client.CoreV1().Namespaces().Create(context.Background(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-app-app"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// Test
+	response := <-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications/_import", applicationModels.ApplicationImportRequest{Bundle: &bundle})
+	require.Equal(t, http.StatusOK, response.Code)
+	importResponse := applicationModels.ApplicationImportResponse{}
+	require.NoError(t, controllertest.GetResponseBody(response, &importResponse))
+
+	require.NotNil(t, importResponse.ApplicationRegistration)
+	rr, err := radixclient.RadixV1().RadixRegistrations().Get(context.Background(), "my-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, rr.Spec.SharedSecret)
+	pendingMessage := "environment dev does not exist yet. Import the bundle again after the first deployment"
+	expectedItems := []applicationModels.ImportItem{
+		{Type: applicationModels.ImportItemTypeSharedSecret, Status: applicationModels.ImportItemRequiresInput, Message: "A new webhook shared secret was generated. Update the webhook of the GitHub repository"},
+		{Type: applicationModels.ImportItemTypeBuildSecret, Name: "secret1", Status: applicationModels.ImportItemRequiresInput, Message: "Set the value of the build secret"},
+		{Type: applicationModels.ImportItemTypePrivateImageHub, Name: "myregistry.azurecr.io", Status: applicationModels.ImportItemRequiresInput, Message: "Set the password of the private image hub"},
+		{Type: applicationModels.ImportItemTypeAlerting, Status: applicationModels.ImportItemImported},
+		{Type: applicationModels.ImportItemTypeSlackWebhook, Name: "slack", Status: applicationModels.ImportItemRequiresInput, Message: "Set the Slack webhook URL of the receiver"},
+		{Type: applicationModels.ImportItemTypeEnvVar, Environment: "dev", Component: "api", Name: "VAR1", Status: applicationModels.ImportItemPending, Message: pendingMessage},
+		{Type: applicationModels.ImportItemTypeSecret, Environment: "dev", Component: "api", Name: "SECRET1", Status: applicationModels.ImportItemRequiresInput, Message: "Set the value of the secret"},
+	}
+	assert.Equal(t, expectedItems, importResponse.Items)
+	_, err = client.CoreV1().Secrets("my-app-app").Get(context.Background(), defaults.BuildSecretsName, metav1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = radixclient.RadixV1().RadixAlerts("my-app-app").Get(context.Background(), "alerting", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	// Importing again does not change the registration, and reports the items created by the first import
+	response = <-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications/_import", applicationModels.ApplicationImportRequest{Bundle: &bundle})
+	require.Equal(t, http.StatusOK, response.Code)
+	importResponse = applicationModels.ApplicationImportResponse{}
+	require.NoError(t, controllertest.GetResponseBody(response, &importResponse))
+	assert.Equal(t, expectedItems[1:], importResponse.Items)
+	actualRR, err := radixclient.RadixV1().RadixRegistrations().Get(context.Background(), "my-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, rr.Spec.SharedSecret, actualRR.Spec.SharedSecret)
+
+	// The repository of a registered application cannot be changed by import
+	bundle.Registration.Repository = "https://github.com/Equinor/any-other-repo"
+	response = <-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications/_import", applicationModels.ApplicationImportRequest{Bundle: &bundle})
+	assert.Equal(t, http.StatusConflict, response.Code)
+}
+
+func TestImportApplication_InvalidBundle(t *testing.T) {
+	_, controllerTestUtils, _, _, _, _, _, _, _ := setupTest(t)
+
+	response := <-controllerTestUtils.ExecuteRequestWithParameters("POST", "/api/v1/applications/_import", applicationModels.ApplicationImportRequest{Bundle: &applicationModels.ApplicationBundle{Version: 2}})
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
+
 func TestGetApplication_WithAppAlias_ContainsAppAlias(t *testing.T) {
 	// Setup
 	commonTestUtils, controllerTestUtils, client, radixclient, kedaClient, dynamicClient, secretproviderclient, certClient, _ := setupTest(t)
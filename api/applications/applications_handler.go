@@ -16,6 +16,8 @@ import (
 	"github.com/equinor/radix-api/api/kubequery"
 	"github.com/equinor/radix-api/api/middleware/auth"
 	apimodels "github.com/equinor/radix-api/api/models"
+	"github.com/equinor/radix-api/api/utils/access"
+	"github.com/equinor/radix-api/api/utils/fieldset"
 	"github.com/equinor/radix-api/api/utils/warningcollector"
 	"github.com/equinor/radix-api/internal/config"
 	"github.com/equinor/radix-api/models"
@@ -29,6 +31,7 @@ import (
 	jobPipeline "github.com/equinor/radix-operator/pkg/apis/pipeline"
 	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
 	operatorUtils "github.com/equinor/radix-operator/pkg/apis/utils"
+	radixclient "github.com/equinor/radix-operator/pkg/client/clientset/versioned"
 	"github.com/google/uuid"
 	"github.com/oklog/ulid/v2"
 	"github.com/rs/zerolog/log"
@@ -53,6 +56,23 @@ type ApplicationHandler struct {
 	config                          config.Config
 	hasAccessToGetConfigMap         hasAccessToGetConfigMapFunc
 	getWarningCollectionFromContext CollectContextWarningsFunc
+	cache                           *kubequery.Cache
+	accessDecisions                 *access.DecisionCache
+}
+
+// WithCache configures the cache used by ApplicationHandler, and its EnvironmentHandler, for reads with the service account
+func WithCache(cache *kubequery.Cache) ApplicationHandlerOption {
+	return func(ah *ApplicationHandler) {
+		ah.cache = cache
+		environments.WithCache(cache)(&ah.environmentHandler)
+	}
+}
+
+// WithAccessDecisionCache configures the cache of access decisions used when listing applications
+func WithAccessDecisionCache(accessDecisions *access.DecisionCache) ApplicationHandlerOption {
+	return func(ah *ApplicationHandler) {
+		ah.accessDecisions = accessDecisions
+	}
 }
 
 // NewApplicationHandler Constructor
@@ -81,35 +101,50 @@ func (ah *ApplicationHandler) getServiceAccount() models.Account {
 }
 
 // GetApplication handler for GetApplication
-func (ah *ApplicationHandler) GetApplication(ctx context.Context, appName string) (*applicationModels.Application, error) {
+func (ah *ApplicationHandler) GetApplication(ctx context.Context, appName string, fields fieldset.Fields) (*applicationModels.Application, error) {
 	rr, err := kubequery.GetRadixRegistration(ctx, ah.accounts.UserAccount.RadixClient, appName)
 	if err != nil {
 		return nil, err
 	}
-	ra, err := kubequery.GetRadixApplication(ctx, ah.accounts.UserAccount.RadixClient, appName)
-	if err != nil && !k8serrors.IsNotFound(err) {
-		return nil, err
+	var ra *v1.RadixApplication
+	if fields.Has("environments", "appAlias", "dnsAliases", "useBuildKit", "useBuildCache") {
+		ra, err = kubequery.GetRadixApplication(ctx, ah.accounts.UserAccount.RadixClient, appName)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
 	}
-	reList, err := kubequery.GetRadixEnvironments(ctx, ah.accounts.ServiceAccount.RadixClient, appName)
-	if err != nil {
-		return nil, err
+	var reList []v1.RadixEnvironment
+	var rdList []v1.RadixDeployment
+	if fields.Has("environments", "dnsExternalAliases") {
+		reList, err = ah.cache.GetRadixEnvironments(ctx, ah.accounts.ServiceAccount.RadixClient, appName)
+		if err != nil {
+			return nil, err
+		}
+		envNames := slice.Map(reList, func(re v1.RadixEnvironment) string { return re.Spec.EnvName })
+		rdList, err = ah.cache.GetRadixDeploymentsForEnvironments(ctx, ah.accounts.ServiceAccount.RadixClient, appName, envNames)
+		if err != nil {
+			return nil, err
+		}
 	}
-	rjList, err := kubequery.GetRadixJobs(ctx, ah.getUserAccount().RadixClient, appName)
-	if err != nil {
-		return nil, err
+	var rjList []v1.RadixJob
+	if fields.Has("environments", "jobs") {
+		rjList, err = kubequery.GetRadixJobs(ctx, ah.getUserAccount().RadixClient, appName)
+		if err != nil {
+			return nil, err
+		}
 	}
-	envNames := slice.Map(reList, func(re v1.RadixEnvironment) string { return re.Spec.EnvName })
-	rdList, err := kubequery.GetRadixDeploymentsForEnvironments(ctx, ah.accounts.UserAccount.RadixClient, appName, envNames, 10)
-	if err != nil {
-		return nil, err
+	var userIsAdmin bool
+	if fields.Has("userIsAdmin") {
+		userIsAdmin, err = ah.userIsAppAdmin(ctx, appName)
+		if err != nil {
+			return nil, err
+		}
 	}
-
-	userIsAdmin, err := ah.userIsAppAdmin(ctx, appName)
-	if err != nil {
-		return nil, err
+	var dnsAliases []v1.RadixDNSAlias
+	if fields.Has("dnsAliases") {
+		dnsAliases = kubequery.GetDNSAliases(ctx, ah.accounts.UserAccount.RadixClient, ra)
 	}
 
-	dnsAliases := kubequery.GetDNSAliases(ctx, ah.accounts.UserAccount.RadixClient, ra)
 	application := apimodels.BuildApplication(rr, ra, reList, rdList, rjList, userIsAdmin, dnsAliases, ah.config.DNSZone)
 	return application, nil
 }
@@ -392,7 +427,7 @@ func (ah *ApplicationHandler) TriggerPipelinePromote(ctx context.Context, appNam
 
 	jobParameters := pipelineParameters.MapPipelineParametersPromoteToJobParameter()
 	jobParameters.CommitID = radixDeployment.GetLabels()[kube.RadixCommitLabel]
-	jobSummary, err := HandleStartPipelineJob(ctx, ah.accounts.UserAccount.RadixClient, appName, pipeline, jobParameters)
+	jobSummary, err := ah.startPipelineJob(ctx, appName, pipeline, jobParameters, r)
 	if err != nil {
 		return nil, err
 	}
@@ -426,22 +461,19 @@ func (ah *ApplicationHandler) TriggerPipelineDeploy(ctx context.Context, appName
 		return nil, err
 	}
 
-	toEnvironment := pipelineParameters.ToEnvironment
-
-	if strings.TrimSpace(toEnvironment) == "" {
-		return nil, radixhttp.ValidationError("Radix Application Pipeline", "To environment is required for \"deploy\" pipeline")
+	jobParameters := pipelineParameters.MapPipelineParametersDeployToJobParameter()
+	if err := ValidatePipelineDeployJobParameters(jobParameters); err != nil {
+		return nil, err
 	}
 
-	log.Ctx(ctx).Info().Msgf("Creating deploy pipeline jobController for %s into environment %s", appName, toEnvironment)
+	log.Ctx(ctx).Info().Msgf("Creating deploy pipeline jobController for %s into environment %s", appName, jobParameters.ToEnvironment)
 
 	pipeline, err := jobPipeline.GetPipelineFromName("deploy")
 	if err != nil {
 		return nil, err
 	}
 
-	jobParameters := pipelineParameters.MapPipelineParametersDeployToJobParameter()
-
-	jobSummary, err := HandleStartPipelineJob(ctx, ah.accounts.UserAccount.RadixClient, appName, pipeline, jobParameters)
+	jobSummary, err := ah.startPipelineJob(ctx, appName, pipeline, jobParameters, r)
 	if err != nil {
 		return nil, err
 	}
@@ -465,7 +497,7 @@ func (ah *ApplicationHandler) TriggerPipelineApplyConfig(ctx context.Context, ap
 
 	jobParameters := pipelineParameters.MapPipelineParametersApplyConfigToJobParameter()
 
-	jobSummary, err := HandleStartPipelineJob(ctx, ah.accounts.UserAccount.RadixClient, appName, pipeline, jobParameters)
+	jobSummary, err := ah.startPipelineJob(ctx, appName, pipeline, jobParameters, r)
 	if err != nil {
 		return nil, err
 	}
@@ -473,10 +505,16 @@ func (ah *ApplicationHandler) TriggerPipelineApplyConfig(ctx context.Context, ap
 	return jobSummary, nil
 }
 
+func (ah *ApplicationHandler) startPipelineJob(ctx context.Context, appName string, pipeline *jobPipeline.Definition, jobParameters *jobModels.JobParameters, r *http.Request) (*jobModels.JobSummary, error) {
+	idempotency, err := getIdempotency(ctx, r, ah.config.IdempotencyKeyTimeWindow, pipeline, jobParameters)
+	if err != nil {
+		return nil, err
+	}
+	return handleStartIdempotentPipelineJob(ctx, ah.accounts.UserAccount.RadixClient, appName, pipeline, jobParameters, idempotency)
+}
+
 func (ah *ApplicationHandler) triggerPipelineBuildOrBuildDeploy(ctx context.Context, appName, pipelineName string, r *http.Request) (*jobModels.JobSummary, error) {
 	var pipelineParameters applicationModels.PipelineParametersBuild
-	userAccount := ah.getUserAccount()
-
 	if err := json.NewDecoder(r.Body).Decode(&pipelineParameters); err != nil {
 		return nil, err
 	}
@@ -484,32 +522,11 @@ func (ah *ApplicationHandler) triggerPipelineBuildOrBuildDeploy(ctx context.Cont
 	envName := pipelineParameters.ToEnvironment
 	commitID := pipelineParameters.CommitID
 
-	if strings.TrimSpace(appName) == "" || strings.TrimSpace(jobParameters.GitRef) == "" {
-		return nil, applicationModels.AppNameAndBranchAreRequiredForStartingPipeline()
-	}
-
 	log.Ctx(ctx).Info().Msgf("Creating build pipeline jobController for %s on %s %s for commit %s", appName, jobParameters.GitRefType, jobParameters.GitRef, commitID)
-	radixRegistration, err := ah.getUserAccount().RadixClient.RadixV1().RadixRegistrations().Get(ctx, appName, metav1.GetOptions{})
-	if err != nil {
+	if err := ValidatePipelineBuildJobParameters(ctx, ah.getUserAccount().RadixClient, appName, jobParameters); err != nil {
 		return nil, err
 	}
 
-	// Check if branch is mapped
-	if !applicationconfig.IsConfigBranch(jobParameters.GitRef, radixRegistration) {
-		ra, err := userAccount.RadixClient.RadixV1().RadixApplications(operatorUtils.GetAppNamespace(appName)).Get(ctx, appName, metav1.GetOptions{})
-		if err != nil {
-			return nil, err
-		}
-		targetEnvironments := applicationconfig.GetAllTargetEnvironments(jobParameters.GitRef, jobParameters.GitRefType, ra)
-		if len(targetEnvironments) == 0 {
-			return nil, applicationModels.UnmatchedBranchToEnvironment(jobParameters.GitRef)
-		}
-
-		if len(envName) > 0 && !slice.Any(targetEnvironments, func(targetEnvName string) bool { return targetEnvName == envName }) {
-			return nil, applicationModels.EnvironmentNotMappedToBranch(envName, jobParameters.GitRef)
-		}
-	}
-
 	pipeline, err := jobPipeline.GetPipelineFromName(pipelineName)
 	if err != nil {
 		return nil, err
@@ -518,7 +535,7 @@ func (ah *ApplicationHandler) triggerPipelineBuildOrBuildDeploy(ctx context.Cont
 	log.Ctx(ctx).Info().Msgf("Creating build pipeline job for %s on %s %s for commit %s%s", appName, jobParameters.GitRefType, jobParameters.GitRef, commitID,
 		radixutils.TernaryString(len(envName) > 0, fmt.Sprintf(", for environment %s", envName), ""))
 
-	jobSummary, err := HandleStartPipelineJob(ctx, ah.accounts.UserAccount.RadixClient, appName, pipeline, jobParameters)
+	jobSummary, err := ah.startPipelineJob(ctx, appName, pipeline, jobParameters, r)
 	if err != nil {
 		return nil, err
 	}
@@ -526,6 +543,46 @@ func (ah *ApplicationHandler) triggerPipelineBuildOrBuildDeploy(ctx context.Cont
 	return jobSummary, nil
 }
 
+// ValidatePipelineBuildJobParameters returns an error when the git ref of a build or build-deploy pipeline job is missing,
+// is not mapped to any environment of the application, or is not mapped to the environment to deploy to
+func ValidatePipelineBuildJobParameters(ctx context.Context, radixClient radixclient.Interface, appName string, jobParameters *jobModels.JobParameters) error {
+	if strings.TrimSpace(appName) == "" || strings.TrimSpace(jobParameters.GitRef) == "" {
+		return applicationModels.AppNameAndBranchAreRequiredForStartingPipeline()
+	}
+
+	radixRegistration, err := radixClient.RadixV1().RadixRegistrations().Get(ctx, appName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	// Check if branch is mapped
+	if applicationconfig.IsConfigBranch(jobParameters.GitRef, radixRegistration) {
+		return nil
+	}
+	ra, err := radixClient.RadixV1().RadixApplications(operatorUtils.GetAppNamespace(appName)).Get(ctx, appName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	targetEnvironments := applicationconfig.GetAllTargetEnvironments(jobParameters.GitRef, jobParameters.GitRefType, ra)
+	if len(targetEnvironments) == 0 {
+		return applicationModels.UnmatchedBranchToEnvironment(jobParameters.GitRef)
+	}
+
+	envName := jobParameters.ToEnvironment
+	if len(envName) > 0 && !slice.Any(targetEnvironments, func(targetEnvName string) bool { return targetEnvName == envName }) {
+		return applicationModels.EnvironmentNotMappedToBranch(envName, jobParameters.GitRef)
+	}
+	return nil
+}
+
+// ValidatePipelineDeployJobParameters returns an error when the environment of a deploy pipeline job is missing
+func ValidatePipelineDeployJobParameters(jobParameters *jobModels.JobParameters) error {
+	if strings.TrimSpace(jobParameters.ToEnvironment) == "" {
+		return radixhttp.ValidationError("Radix Application Pipeline", "To environment is required for \"deploy\" pipeline")
+	}
+	return nil
+}
+
 // RegenerateDeployKey Regenerates deploy key and secret and returns the new key
 func (ah *ApplicationHandler) RegenerateDeployKey(ctx context.Context, appName string, regenerateDeployKeyAndSecretData applicationModels.RegenerateDeployKeyData) error {
 	if regenerateDeployKeyAndSecretData.PrivateKey == "" {
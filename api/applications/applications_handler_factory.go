@@ -16,19 +16,21 @@ type ApplicationHandlerFactory interface {
 }
 
 type applicationHandlerFactory struct {
-	config config.Config
+	config  config.Config
+	options []ApplicationHandlerOption
 }
 
 // NewApplicationHandlerFactory creates a new ApplicationHandlerFactory
-func NewApplicationHandlerFactory(config config.Config) ApplicationHandlerFactory {
+func NewApplicationHandlerFactory(config config.Config, options ...ApplicationHandlerOption) ApplicationHandlerFactory {
 	return &applicationHandlerFactory{
-		config: config,
+		config:  config,
+		options: options,
 	}
 }
 
 // Create creates a new ApplicationHandler
 func (f *applicationHandlerFactory) Create(accounts models.Accounts) ApplicationHandler {
-	return NewApplicationHandler(accounts, f.config, hasAccessToGetConfigMap)
+	return NewApplicationHandler(accounts, f.config, hasAccessToGetConfigMap, f.options...)
 }
 
 func hasAccessToGetConfigMap(ctx context.Context, kubeClient kubernetes.Interface, namespace, configMapName string) (bool, error) {
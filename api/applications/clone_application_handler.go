@@ -0,0 +1,260 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	applicationModels "github.com/equinor/radix-api/api/applications/models"
+	"github.com/equinor/radix-api/api/kubequery"
+	"github.com/equinor/radix-api/api/utils/labelselector"
+	"github.com/equinor/radix-common/utils/slice"
+	"github.com/equinor/radix-operator/pkg/apis/defaults"
+	"github.com/equinor/radix-operator/pkg/apis/kube"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	operatorUtils "github.com/equinor/radix-operator/pkg/apis/utils"
+	"github.com/rs/zerolog/log"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	appNamespacePollInterval = time.Second
+	appNamespacePollTimeout  = 30 * time.Second
+)
+
+// cloneSource the settings of the existing application to copy
+type cloneSource struct {
+	registration *v1.RadixRegistration
+	application  *v1.RadixApplication
+	alert        *v1.RadixAlert
+}
+
+// dockerConfigJson the format of the private image hub secret, without passwords
+type dockerConfigJson struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// CloneApplication registers a new application with the registration of an existing application, and copies the requested settings to it.
+// Settings are copied when the operator has created the namespace of the new application. Settings which cannot be copied are reported as failures
+func (ah *ApplicationHandler) CloneApplication(ctx context.Context, appName string, cloneRequest applicationModels.ApplicationCloneRequest) (*applicationModels.ApplicationCloneResponse, error) {
+	if err := validateCloneRequest(appName, cloneRequest); err != nil {
+		return nil, err
+	}
+
+	source, err := ah.getCloneSource(ctx, appName, cloneRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	registration := applicationModels.NewApplicationRegistrationBuilder().WithRadixRegistration(source.registration).Build()
+	registration.Name = cloneRequest.Name
+	registration.Repository = cloneRequest.Repository
+	registration.SharedSecret = ""
+	if configBranch := strings.TrimSpace(cloneRequest.ConfigBranch); configBranch != "" {
+		registration.ConfigBranch = configBranch
+	}
+
+	upsertResponse, err := ah.RegisterApplication(ctx, applicationModels.ApplicationRegistrationRequest{
+		ApplicationRegistration: &registration,
+		AcknowledgeWarnings:     cloneRequest.AcknowledgeWarnings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response := &applicationModels.ApplicationCloneResponse{
+		ApplicationRegistration: upsertResponse.ApplicationRegistration,
+		Warnings:                upsertResponse.Warnings,
+	}
+	if upsertResponse.ApplicationRegistration == nil || !(cloneRequest.CopyBuildSecrets || cloneRequest.CopyPrivateImageHubs || cloneRequest.CopyAlerting) {
+		return response, nil
+	}
+
+	response.Copied = &applicationModels.ClonedSettings{}
+	namespace := operatorUtils.GetAppNamespace(cloneRequest.Name)
+	addFailure := func(setting string, err error) {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to copy %s from application %s to %s", setting, appName, cloneRequest.Name)
+		response.Failures = append(response.Failures, fmt.Sprintf("%s: %v", setting, err))
+	}
+
+	if err := ah.waitForNamespace(ctx, namespace); err != nil {
+		for setting, requested := range map[string]bool{"buildSecrets": cloneRequest.CopyBuildSecrets, "privateImageHubs": cloneRequest.CopyPrivateImageHubs, "alerting": cloneRequest.CopyAlerting} {
+			if requested {
+				addFailure(setting, err)
+			}
+		}
+		slices.Sort(response.Failures)
+		return response, nil
+	}
+
+	if buildSecrets := getBuildSecretNames(source.application); cloneRequest.CopyBuildSecrets && len(buildSecrets) > 0 {
+		if err := ah.createBuildSecrets(ctx, cloneRequest.Name, buildSecrets); err != nil {
+			addFailure("buildSecrets", err)
+		} else {
+			response.Copied.BuildSecrets = buildSecrets
+		}
+	}
+	if imageHubs := getPrivateImageHubs(source.application); cloneRequest.CopyPrivateImageHubs && len(imageHubs) > 0 {
+		if err := ah.createPrivateImageHubs(ctx, cloneRequest.Name, imageHubs); err != nil {
+			addFailure("privateImageHubs", err)
+		} else {
+			response.Copied.PrivateImageHubs = slice.Map(imageHubs, func(imageHub applicationModels.PrivateImageHub) string { return imageHub.Server })
+		}
+	}
+	if cloneRequest.CopyAlerting && source.alert != nil {
+		if err := ah.createRadixAlert(ctx, cloneRequest.Name, namespace, source.alert.Name, source.alert.Spec); err != nil {
+			addFailure("alerting", err)
+		} else {
+			response.Copied.Alerting = true
+		}
+	}
+	return response, nil
+}
+
+func validateCloneRequest(appName string, cloneRequest applicationModels.ApplicationCloneRequest) error {
+	switch {
+	case strings.TrimSpace(cloneRequest.Name) == "":
+		return invalidCloneRequestError("name is required")
+	case cloneRequest.Name == appName:
+		return invalidCloneRequestError("name must be different from the name of the existing application")
+	case strings.TrimSpace(cloneRequest.Repository) == "":
+		return invalidCloneRequestError("repository is required")
+	}
+	return nil
+}
+
+// getCloneSource reads all settings to copy before the new application is registered, to fail early when the user cannot read them
+func (ah *ApplicationHandler) getCloneSource(ctx context.Context, appName string, cloneRequest applicationModels.ApplicationCloneRequest) (*cloneSource, error) {
+	rr, err := kubequery.GetRadixRegistration(ctx, ah.getUserAccount().RadixClient, appName)
+	if err != nil {
+		return nil, err
+	}
+	source := cloneSource{registration: rr}
+
+	if cloneRequest.CopyBuildSecrets || cloneRequest.CopyPrivateImageHubs {
+		// An application without a RadixApplication has no build secrets or private image hubs to copy
+		ra, err := kubequery.GetRadixApplication(ctx, ah.getUserAccount().RadixClient, appName)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		source.application = ra
+	}
+
+	if cloneRequest.CopyAlerting {
+		alerts, err := ah.getUserAccount().RadixClient.RadixV1().RadixAlerts(operatorUtils.GetAppNamespace(appName)).List(ctx, metav1.ListOptions{LabelSelector: labelselector.ForApplication(appName).String()})
+		if err != nil {
+			return nil, err
+		}
+		if len(alerts.Items) > 1 {
+			return nil, invalidCloneRequestError(fmt.Sprintf("application %s has multiple alerting configurations", appName))
+		}
+		if len(alerts.Items) == 1 {
+			source.alert = &alerts.Items[0]
+		}
+	}
+	return &source, nil
+}
+
+func (ah *ApplicationHandler) waitForNamespace(ctx context.Context, namespace string) error {
+	err := wait.PollUntilContextTimeout(ctx, appNamespacePollInterval, appNamespacePollTimeout, true, func(ctx context.Context) (done bool, err error) {
+		_, err = ah.getServiceAccount().Client.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+		if err == nil {
+			return true, nil
+		}
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	})
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for namespace %s to be created", namespace)
+	}
+	return err
+}
+
+// getBuildSecretNames returns the sorted names of the build secrets of the application
+func getBuildSecretNames(ra *v1.RadixApplication) []string {
+	if ra == nil || ra.Spec.Build == nil {
+		return nil
+	}
+	return slices.Sorted(slices.Values(ra.Spec.Build.Secrets))
+}
+
+// getPrivateImageHubs returns the private image hubs of the application, sorted by server
+func getPrivateImageHubs(ra *v1.RadixApplication) []applicationModels.PrivateImageHub {
+	if ra == nil {
+		return nil
+	}
+	var imageHubs []applicationModels.PrivateImageHub
+	for _, server := range slices.Sorted(maps.Keys(ra.Spec.PrivateImageHubs)) {
+		imageHubs = append(imageHubs, applicationModels.PrivateImageHub{
+			Server:   server,
+			Username: ra.Spec.PrivateImageHubs[server].Username,
+			Email:    ra.Spec.PrivateImageHubs[server].Email,
+		})
+	}
+	return imageHubs
+}
+
+// createBuildSecrets creates the build secrets of the application with the names, and the default value
+func (ah *ApplicationHandler) createBuildSecrets(ctx context.Context, appName string, names []string) error {
+	data := make(map[string][]byte, len(names))
+	for _, name := range names {
+		data[name] = []byte(defaults.BuildSecretDefaultData)
+	}
+	return ah.createSecretInAppNamespace(ctx, appName, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: defaults.BuildSecretsName, Labels: labelselector.ForApplication(appName)},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       data,
+	})
+}
+
+// createPrivateImageHubs creates the private image hub secret of the application with the servers, usernames and emails
+func (ah *ApplicationHandler) createPrivateImageHubs(ctx context.Context, appName string, imageHubs []applicationModels.PrivateImageHub) error {
+	config := dockerConfigJson{Auths: make(map[string]dockerConfigEntry, len(imageHubs))}
+	for _, imageHub := range imageHubs {
+		config.Auths[imageHub.Server] = dockerConfigEntry{Username: imageHub.Username, Email: imageHub.Email}
+	}
+	configData, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return ah.createSecretInAppNamespace(ctx, appName, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: defaults.PrivateImageHubSecretName, Labels: labelselector.ForApplication(appName)},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: configData},
+	})
+}
+
+// createRadixAlert creates an alerting config of the application in the namespace of the application or one of its environments
+func (ah *ApplicationHandler) createRadixAlert(ctx context.Context, appName, namespace, name string, spec v1.RadixAlertSpec) error {
+	radixAlert := &v1.RadixAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{kube.RadixAppLabel: appName}},
+		Spec:       *spec.DeepCopy(),
+	}
+	return retry.OnError(retry.DefaultBackoff, k8serrors.IsForbidden, func() error {
+		_, err := ah.getUserAccount().RadixClient.RadixV1().RadixAlerts(namespace).Create(ctx, radixAlert, metav1.CreateOptions{})
+		return err
+	})
+}
+
+// createSecretInAppNamespace retries while the operator grants the user access to the new namespace
+func (ah *ApplicationHandler) createSecretInAppNamespace(ctx context.Context, appName string, secret *corev1.Secret) error {
+	return retry.OnError(retry.DefaultBackoff, k8serrors.IsForbidden, func() error {
+		_, err := ah.getUserAccount().Client.CoreV1().Secrets(operatorUtils.GetAppNamespace(appName)).Create(ctx, secret, metav1.CreateOptions{})
+		return err
+	})
+}
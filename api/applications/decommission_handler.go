@@ -0,0 +1,239 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	applicationModels "github.com/equinor/radix-api/api/applications/models"
+	"github.com/equinor/radix-api/api/kubequery"
+	"github.com/equinor/radix-api/api/middleware/auth"
+	"github.com/equinor/radix-api/api/utils/predicate"
+	"github.com/equinor/radix-common/utils/slice"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	operatorUtils "github.com/equinor/radix-operator/pkg/apis/utils"
+	"github.com/rs/zerolog/log"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	decommissionRequestedByAnnotation         = "radix.equinor.com/decommission-requested-by"
+	decommissionRequestedAnnotation           = "radix.equinor.com/decommission-requested"
+	decommissionDeleteAfterAnnotation         = "radix.equinor.com/decommission-delete-after"
+	decommissionStoppedEnvironmentsAnnotation = "radix.equinor.com/decommission-stopped-environments"
+)
+
+// GetApplicationDeletionPlan lists the namespaces, deployments, DNS aliases, external DNS certificates and secrets deleted with the application
+func (ah *ApplicationHandler) GetApplicationDeletionPlan(ctx context.Context, appName string) (*applicationModels.ApplicationDeletionPlan, error) {
+	if _, err := kubequery.GetRadixRegistration(ctx, ah.getUserAccount().RadixClient, appName); err != nil {
+		return nil, err
+	}
+	envNames, err := ah.getEnvironmentNames(ctx, appName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	appNamespace := operatorUtils.GetAppNamespace(appName)
+	plan := applicationModels.ApplicationDeletionPlan{Namespaces: []string{appNamespace}}
+	if plan.Secrets, err = ah.getSecretsInNamespace(ctx, appNamespace); err != nil {
+		return nil, err
+	}
+
+	for _, envName := range envNames {
+		envNamespace := operatorUtils.GetEnvironmentNamespace(appName, envName)
+		plan.Namespaces = append(plan.Namespaces, envNamespace)
+
+		rdList, err := kubequery.GetRadixDeploymentsForEnvironment(ctx, ah.getUserAccount().RadixClient, appName, envName)
+		if err != nil {
+			return nil, err
+		}
+		for _, rd := range rdList {
+			plan.Deployments = append(plan.Deployments, applicationModels.NamespacedResource{Namespace: envNamespace, Name: rd.Name})
+		}
+
+		certificates, err := kubequery.GetCertificatesForEnvironment(ctx, ah.getServiceAccount().CertManagerClient, appName, envName)
+		if err != nil {
+			return nil, err
+		}
+		for _, certificate := range certificates {
+			plan.ExternalDNSCertificates = append(plan.ExternalDNSCertificates, applicationModels.NamespacedResource{Namespace: envNamespace, Name: certificate.Name})
+		}
+
+		secrets, err := ah.getSecretsInNamespace(ctx, envNamespace)
+		if err != nil {
+			return nil, err
+		}
+		plan.Secrets = append(plan.Secrets, secrets...)
+	}
+
+	// DNS aliases are defined in the RadixApplication, which does not exist before the first pipeline job
+	ra, err := kubequery.GetRadixApplication(ctx, ah.getUserAccount().RadixClient, appName)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+	if ra != nil {
+		plan.DNSAliases = slice.Map(kubequery.GetDNSAliases(ctx, ah.getUserAccount().RadixClient, ra), func(dnsAlias v1.RadixDNSAlias) string { return dnsAlias.Name })
+	}
+	return &plan, nil
+}
+
+// DecommissionApplication stops all environments of the application, and schedules deletion of the application when the decommission grace period has passed.
+// Only environments with running components are recorded as stopped, so environments which were already stopped are not started if the decommission is cancelled
+func (ah *ApplicationHandler) DecommissionApplication(ctx context.Context, appName string) (*applicationModels.ApplicationDecommission, error) {
+	rr, err := kubequery.GetRadixRegistration(ctx, ah.getUserAccount().RadixClient, appName)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := getApplicationDecommission(rr); ok {
+		return nil, applicationAlreadyDecommissionedError(appName)
+	}
+
+	envNames, err := ah.getEnvironmentNames(ctx, appName, true)
+	if err != nil {
+		return nil, err
+	}
+	var runningEnvNames []string
+	for _, envName := range envNames {
+		// Environments without deployments have nothing to stop
+		rd, err := kubequery.GetLatestRadixDeployment(ctx, ah.getUserAccount().RadixClient, appName, envName)
+		if err != nil {
+			return nil, err
+		}
+		if rd != nil && hasRunningComponents(rd) {
+			runningEnvNames = append(runningEnvNames, envName)
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	decommission := applicationModels.ApplicationDecommission{
+		RequestedBy:         auth.GetOriginator(ctx),
+		Requested:           now,
+		DeleteAfter:         now.Add(ah.config.DecommissionGracePeriod),
+		StoppedEnvironments: runningEnvNames,
+	}
+	// The decommission is recorded before the environments are stopped, so a cancel starts the environments stopped before a failure
+	err = ah.updateRegistrationAnnotations(ctx, appName, func(annotations map[string]string) {
+		annotations[decommissionRequestedByAnnotation] = decommission.RequestedBy
+		annotations[decommissionRequestedAnnotation] = decommission.Requested.Format(time.RFC3339)
+		annotations[decommissionDeleteAfterAnnotation] = decommission.DeleteAfter.Format(time.RFC3339)
+		annotations[decommissionStoppedEnvironmentsAnnotation] = strings.Join(decommission.StoppedEnvironments, ",")
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Ctx(ctx).Info().Msgf("Decommissioning application %s, it will be deleted after %s", appName, decommission.DeleteAfter.Format(time.RFC3339))
+
+	for _, envName := range runningEnvNames {
+		if err := ah.environmentHandler.StopEnvironment(ctx, appName, envName); err != nil {
+			return nil, err
+		}
+	}
+	return &decommission, nil
+}
+
+// GetApplicationDecommission gets the decommission of the application
+func (ah *ApplicationHandler) GetApplicationDecommission(ctx context.Context, appName string) (*applicationModels.ApplicationDecommission, error) {
+	rr, err := kubequery.GetRadixRegistration(ctx, ah.getUserAccount().RadixClient, appName)
+	if err != nil {
+		return nil, err
+	}
+	decommission, ok := getApplicationDecommission(rr)
+	if !ok {
+		return nil, applicationNotDecommissionedError(appName)
+	}
+	return decommission, nil
+}
+
+// CancelApplicationDecommission cancels the deletion of the application, and starts the environments stopped by the decommission
+func (ah *ApplicationHandler) CancelApplicationDecommission(ctx context.Context, appName string) error {
+	decommission, err := ah.GetApplicationDecommission(ctx, appName)
+	if err != nil {
+		return err
+	}
+	err = ah.updateRegistrationAnnotations(ctx, appName, func(annotations map[string]string) {
+		delete(annotations, decommissionRequestedByAnnotation)
+		delete(annotations, decommissionRequestedAnnotation)
+		delete(annotations, decommissionDeleteAfterAnnotation)
+		delete(annotations, decommissionStoppedEnvironmentsAnnotation)
+	})
+	if err != nil {
+		return err
+	}
+	log.Ctx(ctx).Info().Msgf("Cancelled decommission of application %s", appName)
+
+	var errs []error
+	for _, envName := range decommission.StoppedEnvironments {
+		if err := ah.environmentHandler.ResetManuallyStoppedComponentsInEnvironment(ctx, appName, envName); err != nil && !k8serrors.IsNotFound(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (ah *ApplicationHandler) getEnvironmentNames(ctx context.Context, appName string, excludeOrphaned bool) ([]string, error) {
+	reList, err := kubequery.GetRadixEnvironments(ctx, ah.getServiceAccount().RadixClient, appName)
+	if err != nil {
+		return nil, err
+	}
+	if excludeOrphaned {
+		reList = slice.FindAll(reList, predicate.IsNotOrphanEnvironment)
+	}
+	return slices.Sorted(slices.Values(slice.Map(reList, func(re v1.RadixEnvironment) string { return re.Spec.EnvName }))), nil
+}
+
+func (ah *ApplicationHandler) getSecretsInNamespace(ctx context.Context, namespace string) ([]applicationModels.NamespacedResource, error) {
+	secretList, err := ah.getServiceAccount().Client.CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]applicationModels.NamespacedResource, 0, len(secretList.Items))
+	for _, secret := range secretList.Items {
+		secrets = append(secrets, applicationModels.NamespacedResource{Namespace: namespace, Name: secret.Name})
+	}
+	slices.SortFunc(secrets, func(a, b applicationModels.NamespacedResource) int { return strings.Compare(a.Name, b.Name) })
+	return secrets, nil
+}
+
+func (ah *ApplicationHandler) updateRegistrationAnnotations(ctx context.Context, appName string, update func(annotations map[string]string)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		rr, err := ah.getUserAccount().RadixClient.RadixV1().RadixRegistrations().Get(ctx, appName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if rr.Annotations == nil {
+			rr.Annotations = make(map[string]string)
+		}
+		update(rr.Annotations)
+		_, err = ah.getUserAccount().RadixClient.RadixV1().RadixRegistrations().Update(ctx, rr, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// hasRunningComponents returns true when a component of the deployment is not manually stopped
+func hasRunningComponents(rd *v1.RadixDeployment) bool {
+	return slices.ContainsFunc(rd.Spec.Components, func(component v1.RadixDeployComponent) bool {
+		replicasOverride := component.GetReplicasOverride()
+		return replicasOverride == nil || *replicasOverride > 0
+	})
+}
+
+// getApplicationDecommission returns the decommission of the application, and false when it is not decommissioned
+func getApplicationDecommission(rr *v1.RadixRegistration) (*applicationModels.ApplicationDecommission, bool) {
+	deleteAfter, err := time.Parse(time.RFC3339, rr.Annotations[decommissionDeleteAfterAnnotation])
+	if err != nil {
+		return nil, false
+	}
+	decommission := applicationModels.ApplicationDecommission{
+		RequestedBy: rr.Annotations[decommissionRequestedByAnnotation],
+		DeleteAfter: deleteAfter,
+	}
+	decommission.Requested, _ = time.Parse(time.RFC3339, rr.Annotations[decommissionRequestedAnnotation])
+	if stoppedEnvNames := rr.Annotations[decommissionStoppedEnvironmentsAnnotation]; stoppedEnvNames != "" {
+		decommission.StoppedEnvironments = strings.Split(stoppedEnvNames, ",")
+	}
+	return &decommission, true
+}
@@ -0,0 +1,83 @@
+package applications
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/equinor/radix-api/api/middleware/maintenance"
+	"github.com/equinor/radix-api/api/utils/leader"
+	radixclient "github.com/equinor/radix-operator/pkg/client/clientset/versioned"
+	"github.com/rs/zerolog/log"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const decommissionerLeaseName = "radix-api-decommissioner"
+
+// Decommissioner deletes decommissioned applications when their grace period has passed. No applications are deleted in maintenance mode
+type Decommissioner struct {
+	radixClient     radixclient.Interface
+	maintenanceMode *maintenance.Mode
+	now             func() time.Time
+}
+
+// NewDecommissioner Constructor. The Radix client must be allowed to list and delete RadixRegistrations
+func NewDecommissioner(radixClient radixclient.Interface, maintenanceMode *maintenance.Mode) *Decommissioner {
+	return &Decommissioner{radixClient: radixClient, maintenanceMode: maintenanceMode, now: time.Now}
+}
+
+// RunWithLeaderElection runs the decommissioner in the replica holding the lease in the namespace, so each application is deleted by only one replica.
+// The replicas compete for the lease until the context is cancelled
+func (d *Decommissioner) RunWithLeaderElection(ctx context.Context, kubeClient kubernetes.Interface, namespace, identity string, interval time.Duration) {
+	leader.Run(ctx, kubeClient, namespace, decommissionerLeaseName, identity, func(ctx context.Context) { d.Run(ctx, interval) })
+}
+
+// Run deletes expired applications every interval until the context is cancelled
+func (d *Decommissioner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := d.DeleteExpired(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to delete decommissioned applications")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeleteExpired deletes the applications where the grace period of the decommission has passed.
+// Nothing is deleted while maintenance mode is enabled, and the applications are deleted after it is disabled
+func (d *Decommissioner) DeleteExpired(ctx context.Context) error {
+	if d.maintenanceMode.Status().Enabled {
+		log.Ctx(ctx).Info().Msg("Skipping deletion of decommissioned applications in maintenance mode")
+		return nil
+	}
+
+	rrList, err := d.radixClient.RadixV1().RadixRegistrations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, rr := range rrList.Items {
+		decommission, ok := getApplicationDecommission(&rr)
+		if !ok || d.now().Before(decommission.DeleteAfter) {
+			continue
+		}
+
+		// The resource version precondition prevents deleting an application where the decommission was cancelled after it was listed
+		log.Ctx(ctx).Info().Msgf("Deleting application %s, decommissioned by %s", rr.Name, decommission.RequestedBy)
+		err := d.radixClient.RadixV1().RadixRegistrations().Delete(ctx, rr.Name, metav1.DeleteOptions{
+			Preconditions: &metav1.Preconditions{UID: &rr.UID, ResourceVersion: &rr.ResourceVersion},
+		})
+		if err != nil && !k8serrors.IsNotFound(err) && !k8serrors.IsConflict(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,47 @@
+package applications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/equinor/radix-api/api/middleware/maintenance"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	radixfake "github.com/equinor/radix-operator/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Decommissioner_DeleteExpired(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	decommissioned := func(name string, deleteAfter time.Time) *v1.RadixRegistration {
+		return &v1.RadixRegistration{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{
+			decommissionRequestedByAnnotation: "a_user@equinor.com",
+			decommissionDeleteAfterAnnotation: deleteAfter.Format(time.RFC3339),
+		}}}
+	}
+	radixClient := radixfake.NewSimpleClientset( //nolint:staticcheck
+		decommissioned("expired-app", now.Add(-time.Minute)),
+		decommissioned("decommissioned-app", now.Add(time.Minute)),
+		&v1.RadixRegistration{ObjectMeta: metav1.ObjectMeta{Name: "any-app"}},
+	)
+	maintenanceMode := maintenance.NewMode(true, "")
+	decommissioner := NewDecommissioner(radixClient, maintenanceMode)
+	decommissioner.now = func() time.Time { return now }
+
+	require.NoError(t, decommissioner.DeleteExpired(context.Background()))
+	_, err := radixClient.RadixV1().RadixRegistrations().Get(context.Background(), "expired-app", metav1.GetOptions{})
+	require.NoError(t, err, "applications should not be deleted in maintenance mode")
+
+	maintenanceMode.Set(false, "")
+	require.NoError(t, decommissioner.DeleteExpired(context.Background()))
+
+	rrList, err := radixClient.RadixV1().RadixRegistrations().List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	var actual []string
+	for _, rr := range rrList.Items {
+		actual = append(actual, rr.Name)
+	}
+	assert.ElementsMatch(t, []string{"decommissioned-app", "any-app"}, actual)
+}
@@ -1,7 +1,68 @@
 package applications
 
-import radixhttp "github.com/equinor/radix-common/net/http"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/equinor/radix-api/api/utils/problem"
+	radixhttp "github.com/equinor/radix-common/net/http"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Error codes of application errors
+const (
+	CodeAdminGroupMembershipRequired problem.Code = "admin-group-membership-required"
+	CodeIdempotencyKeyReused         problem.Code = "idempotency-key-reused"
+	CodeInvalidCloneRequest          problem.Code = "invalid-clone-request"
+	CodeInvalidApplicationBundle     problem.Code = "invalid-application-bundle"
+	CodeApplicationRepositoryChanged problem.Code = "application-repository-changed"
+	CodeApplicationDecommissioned    problem.Code = "application-decommissioned"
+	CodeApplicationNotDecommissioned problem.Code = "application-not-decommissioned"
+	CodeInvalidOwnershipTransfer     problem.Code = "invalid-ownership-transfer"
+	CodeNoOwnershipTransfer          problem.Code = "no-ownership-transfer"
+	CodeOwnershipTransferRecipient   problem.Code = "ownership-transfer-recipient-required"
+)
 
 func userShouldBeMemberOfAdminAdGroupError() error {
-	return radixhttp.ValidationError("Radix Registration", "User should be a member of at least one admin AD group or their sub-members")
+	return problem.WithCode(CodeAdminGroupMembershipRequired, radixhttp.ValidationError("Radix Registration", "User should be a member of at least one admin AD group or their sub-members"))
+}
+
+func idempotencyKeyReusedError(jobName string) error {
+	return problem.WithCode(CodeIdempotencyKeyReused, k8serrors.NewConflict(v1.SchemeGroupVersion.WithResource("radixjobs").GroupResource(), jobName,
+		errors.New("the Idempotency-Key was used for a pipeline job with a different payload")))
+}
+
+func invalidCloneRequestError(message string) error {
+	return problem.WithCode(CodeInvalidCloneRequest, radixhttp.ValidationError("Clone application", message))
+}
+
+func invalidApplicationBundleError(message string) error {
+	return problem.WithCode(CodeInvalidApplicationBundle, radixhttp.ValidationError("Application bundle", message))
+}
+
+func applicationRepositoryChangedError(appName, repository string) error {
+	return problem.WithCode(CodeApplicationRepositoryChanged, k8serrors.NewConflict(v1.SchemeGroupVersion.WithResource("radixregistrations").GroupResource(), appName,
+		fmt.Errorf("the application is registered with another repository than %s", repository)))
+}
+
+func applicationAlreadyDecommissionedError(appName string) error {
+	return problem.WithCode(CodeApplicationDecommissioned, k8serrors.NewConflict(v1.SchemeGroupVersion.WithResource("radixregistrations").GroupResource(), appName,
+		errors.New("the application is already decommissioned")))
+}
+
+func applicationNotDecommissionedError(appName string) error {
+	return problem.WithCode(CodeApplicationNotDecommissioned, radixhttp.NotFoundError(fmt.Sprintf("application %s is not decommissioned", appName)))
+}
+
+func invalidOwnershipTransferError(message string) error {
+	return problem.WithCode(CodeInvalidOwnershipTransfer, radixhttp.ValidationError("Ownership transfer", message))
+}
+
+func noOwnershipTransferError(appName string) error {
+	return problem.WithCode(CodeNoOwnershipTransfer, radixhttp.NotFoundError(fmt.Sprintf("no ownership transfer is proposed for application %s", appName)))
+}
+
+func ownershipTransferRecipientRequiredError() error {
+	return problem.WithCode(CodeOwnershipTransferRecipient, radixhttp.ForbiddenError("only the proposed new owner can accept the ownership transfer"))
 }
@@ -2,6 +2,8 @@ package applications
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"slices"
 	"sort"
 	"strings"
@@ -12,14 +14,15 @@ import (
 	environmentModels "github.com/equinor/radix-api/api/environments/models"
 	jobModels "github.com/equinor/radix-api/api/jobs/models"
 	"github.com/equinor/radix-api/api/kubequery"
+	"github.com/equinor/radix-api/api/middleware/auth"
 	"github.com/equinor/radix-api/api/utils/access"
+	"github.com/equinor/radix-api/api/utils/fieldset"
 	"github.com/equinor/radix-common/utils/slice"
 	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
 	"github.com/equinor/radix-operator/pkg/client/clientset/versioned"
 	"golang.org/x/sync/errgroup"
 
 	authorizationapi "k8s.io/api/authorization/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -28,17 +31,25 @@ type hasAccessToRR func(ctx context.Context, client kubernetes.Interface, rr v1.
 type GetApplicationsOptions struct {
 	IncludeLatestJobSummary bool
 	IncludeEnvironments     bool
+	// Filter applications by registration, and by latest job and environments when the filter requires them
+	Filter *applicationModels.ApplicationSearchFilter
 }
 
 // GetApplications handler for ShowApplications - NOTE: does not get latestJob.Environments
 func (ah *ApplicationHandler) GetApplications(ctx context.Context, matcher applicationModels.ApplicationMatch, hasAccess hasAccessToRR, options GetApplicationsOptions) ([]*applicationModels.ApplicationSummary, error) {
-	radixRegistationList, err := ah.getServiceAccount().RadixClient.RadixV1().RadixRegistrations().List(ctx, metav1.ListOptions{})
+	radixRegistations, err := ah.cache.GetRadixRegistrations(ctx, ah.getServiceAccount().RadixClient)
 	if err != nil {
 		return nil, err
 	}
 
-	filteredRegistrations := make([]v1.RadixRegistration, 0, len(radixRegistationList.Items))
-	for _, rr := range radixRegistationList.Items {
+	// Filters on the latest job or environments are matched when they are read for the applications the user has access to
+	filterRequiresStatus := options.Filter != nil && (options.Filter.RequiresLatestJob() || options.Filter.RequiresEnvironments())
+	if options.Filter != nil && !filterRequiresStatus {
+		matcher = matchAllFunc(matcher, applicationModels.MatchByFilterFunc(*options.Filter))
+	}
+
+	filteredRegistrations := make([]v1.RadixRegistration, 0, len(radixRegistations))
+	for _, rr := range radixRegistations {
 		if matcher(&rr) {
 			filteredRegistrations = append(filteredRegistrations, rr)
 		}
@@ -50,14 +61,14 @@ func (ah *ApplicationHandler) GetApplications(ctx context.Context, matcher appli
 	}
 
 	var latestApplicationJobs map[string]*jobModels.JobSummary
-	if options.IncludeLatestJobSummary {
+	if options.IncludeLatestJobSummary || (filterRequiresStatus && options.Filter.RequiresLatestJob()) {
 		if latestApplicationJobs, err = getLatestJobPerApplication(ctx, ah.accounts.UserAccount.RadixClient, radixRegistrations); err != nil {
 			return nil, err
 		}
 	}
 
 	var appEnvironmentsMap map[string][]environmentModels.Environment
-	if options.IncludeEnvironments {
+	if options.IncludeEnvironments || (filterRequiresStatus && options.Filter.RequiresEnvironments()) {
 		if appEnvironmentsMap, err = ah.getEnvironmentsForApplications(ctx, radixRegistrations); err != nil {
 			return nil, err
 		}
@@ -66,18 +77,36 @@ func (ah *ApplicationHandler) GetApplications(ctx context.Context, matcher appli
 	applications := make([]*applicationModels.ApplicationSummary, 0)
 	for _, rr := range radixRegistrations {
 		appName := rr.GetName()
-		applications = append(
-			applications,
-			&applicationModels.ApplicationSummary{
-				Name:         appName,
-				LatestJob:    latestApplicationJobs[appName],
-				Environments: appEnvironmentsMap[appName],
-			},
-		)
+		summary := &applicationModels.ApplicationSummary{
+			Name:         appName,
+			LatestJob:    latestApplicationJobs[appName],
+			Environments: appEnvironmentsMap[appName],
+		}
+		if filterRequiresStatus && !options.Filter.Match(&rr, summary) {
+			continue
+		}
+		if !options.IncludeLatestJobSummary {
+			summary.LatestJob = nil
+		}
+		if !options.IncludeEnvironments {
+			summary.Environments = nil
+		}
+		applications = append(applications, summary)
 	}
 	return applications, nil
 }
 
+func matchAllFunc(matchers ...applicationModels.ApplicationMatch) applicationModels.ApplicationMatch {
+	return func(rr *v1.RadixRegistration) bool {
+		for _, matcher := range matchers {
+			if !matcher(rr) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
 func (ah *ApplicationHandler) getEnvironmentsForApplications(ctx context.Context, radixRegistrations []v1.RadixRegistration) (map[string][]environmentModels.Environment, error) {
 	type ChannelData struct {
 		key          string
@@ -91,7 +120,7 @@ func (ah *ApplicationHandler) getEnvironmentsForApplications(ctx context.Context
 	for _, rr := range radixRegistrations {
 		appName := rr.GetName()
 		g.Go(func() error {
-			reList, err := kubequery.GetRadixEnvironments(ctx, ah.accounts.ServiceAccount.RadixClient, appName)
+			reList, err := ah.cache.GetRadixEnvironments(ctx, ah.accounts.ServiceAccount.RadixClient, appName)
 			if err != nil {
 				return err
 			}
@@ -127,7 +156,7 @@ func getEnvironmentsForApplication(ctx context.Context, handler environments.Env
 	for _, envName := range envNames {
 
 		g.Go(func() error {
-			environmentModel, err := handler.GetEnvironment(ctx, appName, envName)
+			environmentModel, err := handler.GetEnvironment(ctx, appName, envName, fieldset.All)
 			if err == nil {
 				chanData <- *environmentModel
 			}
@@ -158,6 +187,7 @@ func (ah *ApplicationHandler) filterRadixRegByAccess(ctx context.Context, radixr
 	limit := 25
 	rrChan := make(chan v1.RadixRegistration, len(radixregs))
 	kubeClient := ah.getUserAccount().Client
+	principal := accessDecisionPrincipal(ctx)
 	var g errgroup.Group
 	g.SetLimit(limit)
 
@@ -169,11 +199,22 @@ func (ah *ApplicationHandler) filterRadixRegByAccess(ctx context.Context, radixr
 			if rr.Status.Reconciled.IsZero() {
 				return nil
 			}
+			version := accessDecisionVersion(rr)
+			if allowed, found := ah.accessDecisions.Get(principal, rr.GetName(), version); found {
+				if allowed {
+					rrChan <- rr
+				}
+				return nil
+			}
 			ok, err := hasAccess(ctx, kubeClient, rr)
+			if err != nil {
+				return err
+			}
+			ah.accessDecisions.Set(principal, rr.GetName(), version, ok)
 			if ok {
 				rrChan <- rr
 			}
-			return err
+			return nil
 		}
 	}
 
@@ -197,6 +238,28 @@ func (ah *ApplicationHandler) filterRadixRegByAccess(ctx context.Context, radixr
 	return result, nil
 }
 
+// accessDecisionPrincipal identifies the principal, and the impersonated user and groups, in the access decision cache
+func accessDecisionPrincipal(ctx context.Context) string {
+	impersonation := auth.CtxImpersonation(ctx)
+	groups := slices.Clone(impersonation.Groups)
+	slices.Sort(groups)
+	return strings.Join([]string{auth.CtxTokenPrincipal(ctx).Id(), impersonation.User, strings.Join(groups, ",")}, "|")
+}
+
+// accessDecisionVersion identifies the users and groups with access to the application, so cached access decisions
+// are not used after they are changed, or the application is recreated
+func accessDecisionVersion(rr v1.RadixRegistration) string {
+	hash := sha256.New()
+	for _, values := range [][]string{{string(rr.GetUID())}, rr.Spec.AdGroups, rr.Spec.AdUsers, rr.Spec.ReaderAdGroups, rr.Spec.ReaderAdUsers} {
+		for _, value := range values {
+			hash.Write([]byte(value))
+			hash.Write([]byte{0})
+		}
+		hash.Write([]byte{1})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 // cannot run as test - does not return correct values
 func hasAccess(ctx context.Context, client kubernetes.Interface, rr v1.RadixRegistration) (bool, error) {
 	return access.HasAccess(ctx, client, &authorizationapi.ResourceAttributes{
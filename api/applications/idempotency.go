@@ -0,0 +1,132 @@
+package applications
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	jobController "github.com/equinor/radix-api/api/jobs"
+	jobModels "github.com/equinor/radix-api/api/jobs/models"
+	radixhttp "github.com/equinor/radix-common/net/http"
+	"github.com/equinor/radix-operator/pkg/apis/kube"
+	pipelineJob "github.com/equinor/radix-operator/pkg/apis/pipeline"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	operatorUtils "github.com/equinor/radix-operator/pkg/apis/utils"
+	"github.com/equinor/radix-operator/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	idempotencyKeyHeader            = "Idempotency-Key"
+	idempotencyKeyLabel             = "radix-idempotency-key"
+	idempotencyGenerationLabel      = "radix-idempotency-generation"
+	idempotencyPayloadAnnotation    = "radix-idempotency-payload"
+	idempotencyNameHashLength       = 20
+	maxIdempotencyKeyLength         = 255
+	defaultIdempotencyKeyTimeWindow = 24 * time.Hour
+)
+
+// idempotency identifies a request to start a pipeline job by the Idempotency-Key header and a hash of its payload
+type idempotency struct {
+	keyHash     string
+	payloadHash string
+	window      time.Duration
+}
+
+// getIdempotency returns the idempotency of a request to start a pipeline job, or nil when the Idempotency-Key header is not set
+func getIdempotency(ctx context.Context, r *http.Request, window time.Duration, pipeline *pipelineJob.Definition, jobParameters *jobModels.JobParameters) (*idempotency, error) {
+	key := r.Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		return nil, nil
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, radixhttp.ValidationError(idempotencyKeyHeader, fmt.Sprintf("the key cannot be longer than %d characters", maxIdempotencyKeyLength))
+	}
+	if window <= 0 {
+		window = defaultIdempotencyKeyTimeWindow
+	}
+
+	payload, err := json.Marshal(struct {
+		Pipeline    v1.RadixPipelineType
+		TriggeredBy string
+		Parameters  *jobModels.JobParameters
+	}{pipeline.Type, getTriggeredBy(ctx, jobParameters.TriggeredBy), jobParameters})
+	if err != nil {
+		return nil, err
+	}
+
+	// The label value is limited to 63 characters, so the key is stored as a SHA-224 hash
+	keyHash := sha256.Sum224([]byte(key))
+	payloadHash := sha256.Sum256(payload)
+	return &idempotency{
+		keyHash:     hex.EncodeToString(keyHash[:]),
+		payloadHash: hex.EncodeToString(payloadHash[:]),
+		window:      window,
+	}, nil
+}
+
+// getLatestJob returns the pipeline job created with the same key and the highest generation, or nil if there is none
+func (i *idempotency) getLatestJob(ctx context.Context, radixClient versioned.Interface, appName string) (*v1.RadixJob, error) {
+	selector := labels.SelectorFromSet(labels.Set{kube.RadixAppLabel: appName, idempotencyKeyLabel: i.keyHash})
+	jobs, err := radixClient.RadixV1().RadixJobs(operatorUtils.GetAppNamespace(appName)).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	var latestJob *v1.RadixJob
+	for idx := range jobs.Items {
+		job := &jobs.Items[idx]
+		if latestJob == nil || getIdempotencyGeneration(job) > getIdempotencyGeneration(latestJob) {
+			latestJob = job
+		}
+	}
+	return latestJob, nil
+}
+
+// isReplayOf returns true when the job was created with the same key within the time window.
+// A conflict error is returned when the job was created with a different payload
+func (i *idempotency) isReplayOf(job *v1.RadixJob) (bool, error) {
+	if job.CreationTimestamp.Time.Before(time.Now().Add(-i.window)) {
+		return false, nil
+	}
+	if job.Annotations[idempotencyPayloadAnnotation] != i.payloadHash {
+		return false, idempotencyKeyReusedError(job.Name)
+	}
+	return true, nil
+}
+
+// apply names the pipeline job from the application name, the key and the generation following the latest job with the key,
+// so concurrent requests with the same key create a job with the same name, and only the first is created.
+// The key and payload hash are stored on the pipeline job
+func (i *idempotency) apply(job *v1.RadixJob, latestJob *v1.RadixJob) {
+	generation := 0
+	if latestJob != nil {
+		generation = getIdempotencyGeneration(latestJob) + 1
+	}
+	nameHash := sha256.Sum256([]byte(job.Spec.AppName + "/" + i.keyHash))
+	job.Name = fmt.Sprintf("%s-%s-%d", jobController.WorkerImage, hex.EncodeToString(nameHash[:])[:idempotencyNameHashLength], generation)
+
+	if job.Labels == nil {
+		job.Labels = map[string]string{}
+	}
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	job.Labels[idempotencyKeyLabel] = i.keyHash
+	job.Labels[idempotencyGenerationLabel] = strconv.Itoa(generation)
+	job.Annotations[idempotencyPayloadAnnotation] = i.payloadHash
+}
+
+func getIdempotencyGeneration(job *v1.RadixJob) int {
+	generation, err := strconv.Atoi(job.Labels[idempotencyGenerationLabel])
+	if err != nil {
+		return -1
+	}
+	return generation
+}
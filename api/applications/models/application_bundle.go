@@ -0,0 +1,147 @@
+package models
+
+import (
+	"time"
+
+	alertModels "github.com/equinor/radix-api/api/alerting/models"
+	secretModels "github.com/equinor/radix-api/api/secrets/models"
+)
+
+// ApplicationBundleVersion the version of the ApplicationBundle format
+const ApplicationBundleVersion = 1
+
+// ApplicationBundle everything radix-api manages for an application, without secret values
+// swagger:model ApplicationBundle
+type ApplicationBundle struct {
+	// Version of the bundle format
+	//
+	// required: true
+	// example: 1
+	Version int `json:"version"`
+
+	// Exported timestamp of the export
+	//
+	// required: false
+	// swagger:strfmt date-time
+	Exported time.Time `json:"exported"`
+
+	// Registration of the application. The shared secret is not exported
+	//
+	// required: true
+	Registration ApplicationRegistration `json:"registration"`
+
+	// BuildSecrets names of the build secrets
+	//
+	// required: false
+	// example: ["NPM_TOKEN"]
+	BuildSecrets []string `json:"buildSecrets,omitempty"`
+
+	// PrivateImageHubs private image hubs, without passwords
+	//
+	// required: false
+	PrivateImageHubs []PrivateImageHub `json:"privateImageHubs,omitempty"`
+
+	// Alerting the application alerting config. Not set when alerting is disabled
+	//
+	// required: false
+	Alerting *AlertingBundle `json:"alerting,omitempty"`
+
+	// Environments the settings of each environment
+	//
+	// required: false
+	Environments []EnvironmentBundle `json:"environments,omitempty"`
+}
+
+// PrivateImageHub a private image hub, without the password
+// swagger:model PrivateImageHub
+type PrivateImageHub struct {
+	// Server name of the image hub
+	//
+	// required: true
+	// example: myprivaterepo.azurecr.io
+	Server string `json:"server"`
+
+	// Username for connecting to the private image hub
+	//
+	// required: true
+	// example: my-user-name
+	Username string `json:"username"`
+
+	// Email provided in radixconfig.yaml
+	//
+	// required: false
+	// example: radix@equinor.com
+	Email string `json:"email,omitempty"`
+}
+
+// AlertingBundle an alerting config, without Slack webhook URLs
+// swagger:model AlertingBundle
+type AlertingBundle struct {
+	// Receivers map of receivers to be mapped to alerts
+	//
+	// required: false
+	Receivers alertModels.ReceiverConfigMap `json:"receivers,omitempty"`
+
+	// ReceiverSecretStatus tells which receivers had secrets configured
+	//
+	// required: false
+	ReceiverSecretStatus alertModels.ReceiverConfigSecretStatusMap `json:"receiverSecretStatus,omitempty"`
+
+	// Alerts the list of configured alerts
+	//
+	// required: false
+	Alerts alertModels.AlertConfigList `json:"alerts,omitempty"`
+}
+
+// EnvironmentBundle the settings of an environment
+// swagger:model EnvironmentBundle
+type EnvironmentBundle struct {
+	// Name of the environment
+	//
+	// required: true
+	// example: prod
+	Name string `json:"name"`
+
+	// Alerting the environment alerting config. Not set when alerting is disabled
+	//
+	// required: false
+	Alerting *AlertingBundle `json:"alerting,omitempty"`
+
+	// EnvVarOverrides environment variables changed from the value in radixconfig.yaml
+	//
+	// required: false
+	EnvVarOverrides []EnvVarOverride `json:"envVarOverrides,omitempty"`
+
+	// Secrets names and statuses of the secrets of the active deployment
+	//
+	// required: false
+	Secrets []secretModels.Secret `json:"secrets,omitempty"`
+}
+
+// EnvVarOverride an environment variable of a component, changed from the value in radixconfig.yaml
+// swagger:model EnvVarOverride
+type EnvVarOverride struct {
+	// Component name of the component
+	//
+	// required: true
+	// example: api
+	Component string `json:"component"`
+
+	// Name of the environment variable
+	//
+	// required: true
+	// example: VAR1
+	Name string `json:"name"`
+
+	// Value of the environment variable
+	//
+	// required: true
+	// example: value1
+	Value string `json:"value"`
+
+	// RadixConfigValue the value of the environment variable in radixconfig.yaml
+	//
+	// required: false
+	// example: value0
+	RadixConfigValue string `json:"radixConfigValue,omitempty"`
+}
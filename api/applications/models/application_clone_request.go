@@ -0,0 +1,43 @@
+package models
+
+// ApplicationCloneRequest describe a request to create a new application from the registration of an existing one
+// swagger:model ApplicationCloneRequest
+type ApplicationCloneRequest struct {
+	// Name the unique name of the new Radix application
+	//
+	// required: true
+	// example: radix-canary-golang-v2
+	Name string `json:"name"`
+
+	// Repository the github repository of the new application
+	//
+	// required: true
+	// example: https://github.com/equinor/radix-canary-golang-v2
+	Repository string `json:"repository"`
+
+	// ConfigBranch information. Defaults to the config branch of the existing application
+	//
+	// required: false
+	// example: main
+	ConfigBranch string `json:"configBranch,omitempty"`
+
+	// CopyBuildSecrets copy the names of the build secrets of the existing application. Values are not copied
+	//
+	// required: false
+	CopyBuildSecrets bool `json:"copyBuildSecrets,omitempty"`
+
+	// CopyPrivateImageHubs copy the servers, usernames and emails of the private image hubs of the existing application. Passwords are not copied
+	//
+	// required: false
+	CopyPrivateImageHubs bool `json:"copyPrivateImageHubs,omitempty"`
+
+	// CopyAlerting copy the application alerting config of the existing application. Slack webhook URLs are not copied
+	//
+	// required: false
+	CopyAlerting bool `json:"copyAlerting,omitempty"`
+
+	// AcknowledgeWarnings acknowledge all warnings
+	//
+	// required: false
+	AcknowledgeWarnings bool `json:"acknowledgeWarnings,omitempty"`
+}
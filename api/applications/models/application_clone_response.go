@@ -0,0 +1,48 @@
+package models
+
+// ApplicationCloneResponse describe the result of creating an application from an existing one
+// swagger:model ApplicationCloneResponse
+type ApplicationCloneResponse struct {
+	// ApplicationRegistration of the new application. Not set when there are warnings which are not acknowledged
+	//
+	// required: false
+	ApplicationRegistration *ApplicationRegistration `json:"applicationRegistration,omitempty"`
+
+	// Warnings of the registration of the new application
+	//
+	// required: false
+	// example: ["Repository is used in other application(s)"]
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Copied settings of the existing application
+	//
+	// required: false
+	Copied *ClonedSettings `json:"copied,omitempty"`
+
+	// Failures settings which were requested, but could not be copied
+	//
+	// required: false
+	// example: ["alerting: timed out waiting for the namespace of the application"]
+	Failures []string `json:"failures,omitempty"`
+}
+
+// ClonedSettings describe the settings copied to a new application
+// swagger:model ClonedSettings
+type ClonedSettings struct {
+	// BuildSecrets names of the copied build secrets, which must be given values
+	//
+	// required: false
+	// example: ["NPM_TOKEN"]
+	BuildSecrets []string `json:"buildSecrets,omitempty"`
+
+	// PrivateImageHubs servers of the copied private image hubs, which must be given passwords
+	//
+	// required: false
+	// example: ["myregistry.azurecr.io"]
+	PrivateImageHubs []string `json:"privateImageHubs,omitempty"`
+
+	// Alerting is true when the alerting config was copied
+	//
+	// required: false
+	Alerting bool `json:"alerting,omitempty"`
+}
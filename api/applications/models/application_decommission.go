@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// ApplicationDecommission describe a staged decommission of an application.
+// All environments are stopped when the decommission is started, and the application is deleted when the grace period has passed
+// swagger:model ApplicationDecommission
+type ApplicationDecommission struct {
+	// RequestedBy the user who started the decommission
+	//
+	// required: true
+	// example: a_user@equinor.com
+	RequestedBy string `json:"requestedBy"`
+
+	// Requested timestamp when the decommission was started
+	//
+	// required: true
+	// swagger:strfmt date-time
+	Requested time.Time `json:"requested"`
+
+	// DeleteAfter timestamp when the application is deleted, unless the decommission is cancelled
+	//
+	// required: true
+	// swagger:strfmt date-time
+	DeleteAfter time.Time `json:"deleteAfter"`
+
+	// StoppedEnvironments environments stopped by the decommission, which are started when it is cancelled
+	//
+	// required: false
+	// example: ["dev","prod"]
+	StoppedEnvironments []string `json:"stoppedEnvironments,omitempty"`
+}
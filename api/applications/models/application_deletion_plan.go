@@ -0,0 +1,48 @@
+package models
+
+// ApplicationDeletionPlan describe the resources deleted with an application
+// swagger:model ApplicationDeletionPlan
+type ApplicationDeletionPlan struct {
+	// Namespaces of the application and its environments
+	//
+	// required: true
+	// example: ["radix-canary-golang-app","radix-canary-golang-prod"]
+	Namespaces []string `json:"namespaces"`
+
+	// Deployments of the application in all environments
+	//
+	// required: false
+	Deployments []NamespacedResource `json:"deployments,omitempty"`
+
+	// DNSAliases of the application
+	//
+	// required: false
+	// example: ["my-app"]
+	DNSAliases []string `json:"dnsAliases,omitempty"`
+
+	// ExternalDNSCertificates certificates of external DNS aliases of the application
+	//
+	// required: false
+	ExternalDNSCertificates []NamespacedResource `json:"externalDnsCertificates,omitempty"`
+
+	// Secrets in the namespaces of the application and its environments
+	//
+	// required: false
+	Secrets []NamespacedResource `json:"secrets,omitempty"`
+}
+
+// NamespacedResource describe a resource in a namespace
+// swagger:model NamespacedResource
+type NamespacedResource struct {
+	// Namespace of the resource
+	//
+	// required: true
+	// example: radix-canary-golang-prod
+	Namespace string `json:"namespace"`
+
+	// Name of the resource
+	//
+	// required: true
+	// example: prod-2m4yd-z0xsyfgn
+	Name string `json:"name"`
+}
@@ -3,30 +3,40 @@ package models
 import (
 	"fmt"
 
+	"github.com/equinor/radix-api/api/utils/problem"
 	radixhttp "github.com/equinor/radix-common/net/http"
 )
 
+// Error codes of application errors
+const (
+	CodeGitRefRequired               problem.Code = "git-ref-required"
+	CodeBranchNotMapped              problem.Code = "branch-not-mapped"
+	CodeEnvironmentNotMappedToBranch problem.Code = "environment-not-mapped-to-branch"
+	CodePipelineNotAllowed           problem.Code = "pipeline-not-allowed"
+	CodeIncompleteDeployKey          problem.Code = "incomplete-deploy-key"
+)
+
 // AppNameAndBranchAreRequiredForStartingPipeline Cannot start pipeline when appname and branch are missing
 func AppNameAndBranchAreRequiredForStartingPipeline() error {
-	return radixhttp.ValidationError("Radix Application Pipeline", "App name and branch are required")
+	return problem.WithCode(CodeGitRefRequired, radixhttp.ValidationError("Radix Application Pipeline", "App name and branch are required"))
 }
 
 // UnmatchedBranchToEnvironment Triggering a pipeline on an un-mapped branch is not allowed
 func UnmatchedBranchToEnvironment(branch string) error {
-	return radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("Failed to match environment to branch: %s", branch))
+	return problem.WithCode(CodeBranchNotMapped, radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("Failed to match environment to branch: %s", branch)))
 }
 
 // EnvironmentNotMappedToBranch Triggering a pipeline on an environment, not matched to a branch
 func EnvironmentNotMappedToBranch(envName, branch string) error {
-	return radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("Failed to match environment %s to branch: %s", envName, branch))
+	return problem.WithCode(CodeEnvironmentNotMappedToBranch, radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("Failed to match environment %s to branch: %s", envName, branch)))
 }
 
 // UserNotAllowedToTriggerPipelineError Triggering a pipeline is not allowed for this user and app
 func UserNotAllowedToTriggerPipelineError(appName string) error {
-	return radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("user is not allowed to trigger pipeline for app %s", appName))
+	return problem.WithCode(CodePipelineNotAllowed, radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("user is not allowed to trigger pipeline for app %s", appName)))
 }
 
 // OnePartOfDeployKeyIsNotAllowed Error message
 func OnePartOfDeployKeyIsNotAllowed() error {
-	return radixhttp.ValidationError("Radix Registration", "Setting public key, but no private key is not valid")
+	return problem.WithCode(CodeIncompleteDeployKey, radixhttp.ValidationError("Radix Registration", "Setting public key, but no private key is not valid"))
 }
@@ -0,0 +1,15 @@
+package models
+
+// ApplicationImportRequest describe a request to recreate an application from an ApplicationBundle
+// swagger:model ApplicationImportRequest
+type ApplicationImportRequest struct {
+	// Bundle exported from the application
+	//
+	// required: true
+	Bundle *ApplicationBundle `json:"bundle"`
+
+	// AcknowledgeWarnings acknowledge all warnings
+	//
+	// required: false
+	AcknowledgeWarnings bool `json:"acknowledgeWarnings,omitempty"`
+}
@@ -0,0 +1,88 @@
+package models
+
+// ImportItemStatus the result of importing an item of an ApplicationBundle
+type ImportItemStatus string
+
+const (
+	// ImportItemImported the item was imported
+	ImportItemImported ImportItemStatus = "Imported"
+	// ImportItemRequiresInput the item was imported, but a secret value must be set manually
+	ImportItemRequiresInput ImportItemStatus = "RequiresInput"
+	// ImportItemPending the item could not be imported yet, because the environment or component does not exist.
+	// Import the bundle again after the first deployment of the application
+	ImportItemPending ImportItemStatus = "Pending"
+	// ImportItemFailed the item could not be imported
+	ImportItemFailed ImportItemStatus = "Failed"
+)
+
+// Types of items of an ApplicationBundle
+const (
+	ImportItemTypeSharedSecret    = "sharedSecret"
+	ImportItemTypeBuildSecret     = "buildSecret"
+	ImportItemTypePrivateImageHub = "privateImageHub"
+	ImportItemTypeAlerting        = "alerting"
+	ImportItemTypeSlackWebhook    = "slackWebhookUrl"
+	ImportItemTypeEnvVar          = "envVar"
+	ImportItemTypeSecret          = "secret"
+)
+
+// ApplicationImportResponse describe the result of importing an ApplicationBundle
+// swagger:model ApplicationImportResponse
+type ApplicationImportResponse struct {
+	// ApplicationRegistration of the imported application. Not set when there are warnings which are not acknowledged
+	//
+	// required: false
+	ApplicationRegistration *ApplicationRegistration `json:"applicationRegistration,omitempty"`
+
+	// Warnings of the registration of the application
+	//
+	// required: false
+	// example: ["Repository is used in other application(s)"]
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Items the result of importing each item of the bundle
+	//
+	// required: false
+	Items []ImportItem `json:"items,omitempty"`
+}
+
+// ImportItem the result of importing an item of an ApplicationBundle
+// swagger:model ImportItem
+type ImportItem struct {
+	// Type of the item
+	//
+	// required: true
+	// enum: sharedSecret,buildSecret,privateImageHub,alerting,slackWebhookUrl,envVar,secret
+	// example: buildSecret
+	Type string `json:"type"`
+
+	// Environment of the item. Not set for application items
+	//
+	// required: false
+	// example: prod
+	Environment string `json:"environment,omitempty"`
+
+	// Component of the item
+	//
+	// required: false
+	// example: api
+	Component string `json:"component,omitempty"`
+
+	// Name of the item
+	//
+	// required: false
+	// example: NPM_TOKEN
+	Name string `json:"name,omitempty"`
+
+	// Status of the item
+	//
+	// required: true
+	// enum: Imported,RequiresInput,Pending,Failed
+	// example: RequiresInput
+	Status ImportItemStatus `json:"status"`
+
+	// Message describing what to do with the item
+	//
+	// required: false
+	Message string `json:"message,omitempty"`
+}
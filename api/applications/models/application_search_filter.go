@@ -0,0 +1,134 @@
+package models
+
+import (
+	"slices"
+	"strings"
+
+	deploymentModels "github.com/equinor/radix-api/api/deployments/models"
+	jobModels "github.com/equinor/radix-api/api/jobs/models"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+)
+
+// ApplicationSearchFilter selects applications by their registration and status.
+// All conditions set in a filter must match. Filters in And must all match, and at least one of the filters in Or must match
+// swagger:model ApplicationSearchFilter
+type ApplicationSearchFilter struct {
+	// Owner email of the owner, case-insensitive
+	//
+	// required: false
+	// example: a_user@equinor.com
+	Owner string `json:"owner,omitempty"`
+
+	// Creator email of the creator, case-insensitive
+	//
+	// required: false
+	// example: a_user@equinor.com
+	Creator string `json:"creator,omitempty"`
+
+	// ConfigurationItem ID of the configuration item
+	//
+	// required: false
+	ConfigurationItem string `json:"configurationItem,omitempty"`
+
+	// AdGroup ID of an administrator or reader AD group
+	//
+	// required: false
+	AdGroup string `json:"adGroup,omitempty"`
+
+	// Repository part of the repository clone URL, case-insensitive
+	//
+	// required: false
+	// example: equinor/radix-canary
+	Repository string `json:"repository,omitempty"`
+
+	// HasFailingJob true to match applications where the latest job failed, false to match where it did not
+	//
+	// required: false
+	HasFailingJob *bool `json:"hasFailingJob,omitempty"`
+
+	// HasFailingEnvironment true to match applications with a failing replica in an environment, false to match without
+	//
+	// required: false
+	HasFailingEnvironment *bool `json:"hasFailingEnvironment,omitempty"`
+
+	// And filters which must all match
+	//
+	// required: false
+	And []ApplicationSearchFilter `json:"and,omitempty"`
+
+	// Or filters where at least one must match
+	//
+	// required: false
+	Or []ApplicationSearchFilter `json:"or,omitempty"`
+}
+
+// RequiresLatestJob returns true when the filter, or one of its sub filters, matches on the latest job of the application
+func (f ApplicationSearchFilter) RequiresLatestJob() bool {
+	return f.HasFailingJob != nil || slices.ContainsFunc(f.And, ApplicationSearchFilter.RequiresLatestJob) || slices.ContainsFunc(f.Or, ApplicationSearchFilter.RequiresLatestJob)
+}
+
+// RequiresEnvironments returns true when the filter, or one of its sub filters, matches on the environments of the application
+func (f ApplicationSearchFilter) RequiresEnvironments() bool {
+	return f.HasFailingEnvironment != nil || slices.ContainsFunc(f.And, ApplicationSearchFilter.RequiresEnvironments) || slices.ContainsFunc(f.Or, ApplicationSearchFilter.RequiresEnvironments)
+}
+
+// Match returns true when the RadixRegistration, and the latest job and environments in the summary, matches the filter.
+// The summary is only used when the filter requires the latest job or environments
+func (f ApplicationSearchFilter) Match(rr *v1.RadixRegistration, summary *ApplicationSummary) bool {
+	if rr == nil {
+		return false
+	}
+	switch {
+	case f.Owner != "" && !strings.EqualFold(rr.Spec.Owner, f.Owner),
+		f.Creator != "" && !strings.EqualFold(rr.Spec.Creator, f.Creator),
+		f.ConfigurationItem != "" && rr.Spec.ConfigurationItem != f.ConfigurationItem,
+		f.AdGroup != "" && !slices.Contains(rr.Spec.AdGroups, f.AdGroup) && !slices.Contains(rr.Spec.ReaderAdGroups, f.AdGroup),
+		f.Repository != "" && !strings.Contains(strings.ToLower(rr.Spec.CloneURL), strings.ToLower(f.Repository)),
+		f.HasFailingJob != nil && *f.HasFailingJob != hasFailingJob(summary),
+		f.HasFailingEnvironment != nil && *f.HasFailingEnvironment != hasFailingEnvironment(summary):
+		return false
+	}
+	for _, filter := range f.And {
+		if !filter.Match(rr, summary) {
+			return false
+		}
+	}
+	if len(f.Or) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(f.Or, func(filter ApplicationSearchFilter) bool { return filter.Match(rr, summary) })
+}
+
+// MatchByFilterFunc returns a ApplicationMatch that checks if a RadixRegistration matches the filter.
+// Conditions on the latest job and environments are matched as if the application has no jobs or environments
+func MatchByFilterFunc(filter ApplicationSearchFilter) ApplicationMatch {
+	return func(rr *v1.RadixRegistration) bool {
+		return filter.Match(rr, nil)
+	}
+}
+
+func hasFailingJob(summary *ApplicationSummary) bool {
+	return summary != nil && summary.LatestJob != nil && summary.LatestJob.Status == jobModels.Failed.String()
+}
+
+func hasFailingEnvironment(summary *ApplicationSummary) bool {
+	if summary == nil {
+		return false
+	}
+	for _, env := range summary.Environments {
+		if env.ActiveDeployment == nil {
+			continue
+		}
+		for _, component := range env.ActiveDeployment.Components {
+			if component == nil {
+				continue
+			}
+			if slices.ContainsFunc(component.ReplicaList, func(replica deploymentModels.ReplicaSummary) bool {
+				return replica.Status.Status == deploymentModels.Failing || replica.Status.Status == deploymentModels.Failed
+			}) {
+				return true
+			}
+		}
+	}
+	return false
+}
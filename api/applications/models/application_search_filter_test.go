@@ -0,0 +1,77 @@
+package models
+
+import (
+	"testing"
+
+	deploymentModels "github.com/equinor/radix-api/api/deployments/models"
+	environmentModels "github.com/equinor/radix-api/api/environments/models"
+	jobModels "github.com/equinor/radix-api/api/jobs/models"
+	"github.com/equinor/radix-common/utils/pointers"
+	radixv1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_ApplicationSearchFilter_MatchRegistration(t *testing.T) {
+	rr := radixv1.RadixRegistration{
+		ObjectMeta: v1.ObjectMeta{Name: "app1"},
+		Spec: radixv1.RadixRegistrationSpec{
+			CloneURL:          "git@github.com:Equinor/my-app.git",
+			Owner:             "owner@equinor.com",
+			Creator:           "creator@equinor.com",
+			ConfigurationItem: "ci-1",
+			AdGroups:          []string{"admin-group"},
+			ReaderAdGroups:    []string{"reader-group"},
+		},
+	}
+
+	assert.True(t, ApplicationSearchFilter{}.Match(&rr, nil))
+	assert.True(t, ApplicationSearchFilter{Owner: "OWNER@equinor.com", Creator: "creator@equinor.com"}.Match(&rr, nil))
+	assert.False(t, ApplicationSearchFilter{Owner: "owner@equinor.com", Creator: "other@equinor.com"}.Match(&rr, nil))
+	assert.True(t, ApplicationSearchFilter{ConfigurationItem: "ci-1"}.Match(&rr, nil))
+	assert.False(t, ApplicationSearchFilter{ConfigurationItem: "ci-2"}.Match(&rr, nil))
+	assert.True(t, ApplicationSearchFilter{AdGroup: "admin-group"}.Match(&rr, nil))
+	assert.True(t, ApplicationSearchFilter{AdGroup: "reader-group"}.Match(&rr, nil))
+	assert.False(t, ApplicationSearchFilter{AdGroup: "other-group"}.Match(&rr, nil))
+	assert.True(t, ApplicationSearchFilter{Repository: "equinor/my-app"}.Match(&rr, nil))
+	assert.False(t, ApplicationSearchFilter{Repository: "equinor/other-app"}.Match(&rr, nil))
+	assert.False(t, ApplicationSearchFilter{Owner: "owner@equinor.com"}.Match(nil, nil))
+}
+
+func Test_ApplicationSearchFilter_MatchAndOr(t *testing.T) {
+	rr := radixv1.RadixRegistration{Spec: radixv1.RadixRegistrationSpec{Owner: "owner@equinor.com", ConfigurationItem: "ci-1"}}
+	owner := ApplicationSearchFilter{Owner: "owner@equinor.com"}
+	otherOwner := ApplicationSearchFilter{Owner: "other@equinor.com"}
+	configurationItem := ApplicationSearchFilter{ConfigurationItem: "ci-1"}
+
+	assert.True(t, ApplicationSearchFilter{And: []ApplicationSearchFilter{owner, configurationItem}}.Match(&rr, nil))
+	assert.False(t, ApplicationSearchFilter{And: []ApplicationSearchFilter{otherOwner, configurationItem}}.Match(&rr, nil))
+	assert.True(t, ApplicationSearchFilter{Or: []ApplicationSearchFilter{otherOwner, owner}}.Match(&rr, nil))
+	assert.False(t, ApplicationSearchFilter{Or: []ApplicationSearchFilter{otherOwner}}.Match(&rr, nil))
+	assert.False(t, ApplicationSearchFilter{ConfigurationItem: "ci-2", Or: []ApplicationSearchFilter{owner}}.Match(&rr, nil))
+	assert.True(t, ApplicationSearchFilter{And: []ApplicationSearchFilter{configurationItem, {Or: []ApplicationSearchFilter{otherOwner, owner}}}}.Match(&rr, nil))
+}
+
+func Test_ApplicationSearchFilter_MatchStatus(t *testing.T) {
+	rr := radixv1.RadixRegistration{ObjectMeta: v1.ObjectMeta{Name: "app1"}}
+	failingEnvironment := environmentModels.Environment{ActiveDeployment: &deploymentModels.Deployment{Components: []*deploymentModels.Component{
+		{ReplicaList: []deploymentModels.ReplicaSummary{{Status: deploymentModels.ReplicaStatus{Status: deploymentModels.Running}}}},
+		{ReplicaList: []deploymentModels.ReplicaSummary{{Status: deploymentModels.ReplicaStatus{Status: deploymentModels.Failing}}}},
+	}}}
+	failing := ApplicationSummary{LatestJob: &jobModels.JobSummary{Status: jobModels.Failed.String()}, Environments: []environmentModels.Environment{{}, failingEnvironment}}
+	succeeded := ApplicationSummary{LatestJob: &jobModels.JobSummary{Status: jobModels.Succeeded.String()}, Environments: []environmentModels.Environment{{}}}
+
+	assert.True(t, ApplicationSearchFilter{HasFailingJob: pointers.Ptr(true)}.Match(&rr, &failing))
+	assert.False(t, ApplicationSearchFilter{HasFailingJob: pointers.Ptr(true)}.Match(&rr, &succeeded))
+	assert.True(t, ApplicationSearchFilter{HasFailingJob: pointers.Ptr(false)}.Match(&rr, &succeeded))
+	assert.True(t, ApplicationSearchFilter{HasFailingEnvironment: pointers.Ptr(true)}.Match(&rr, &failing))
+	assert.False(t, ApplicationSearchFilter{HasFailingEnvironment: pointers.Ptr(true)}.Match(&rr, &succeeded))
+	assert.False(t, ApplicationSearchFilter{HasFailingJob: pointers.Ptr(true)}.Match(&rr, nil))
+}
+
+func Test_ApplicationSearchFilter_Requires(t *testing.T) {
+	assert.False(t, ApplicationSearchFilter{Owner: "owner@equinor.com"}.RequiresLatestJob())
+	assert.True(t, ApplicationSearchFilter{Or: []ApplicationSearchFilter{{And: []ApplicationSearchFilter{{HasFailingJob: pointers.Ptr(false)}}}}}.RequiresLatestJob())
+	assert.False(t, ApplicationSearchFilter{HasFailingJob: pointers.Ptr(true)}.RequiresEnvironments())
+	assert.True(t, ApplicationSearchFilter{And: []ApplicationSearchFilter{{HasFailingEnvironment: pointers.Ptr(true)}}}.RequiresEnvironments())
+}
@@ -1,12 +1,35 @@
 package models
 
+// ApplicationsSearchRequest selects the applications to search for, and what to include in the summaries
+// swagger:model ApplicationsSearchRequest
 type ApplicationsSearchRequest struct {
-	Names []string
+	// Names of the applications to search for
+	//
+	// required: false
+	// example: ["radix-canary-golang","radix-api"]
+	Names []string `json:"names,omitempty"`
 
-	IncludeFields ApplicationSearchIncludeFields
+	// Filter selects applications by registration and status. Names are not required when the filter is set
+	//
+	// required: false
+	Filter *ApplicationSearchFilter `json:"filter,omitempty"`
+
+	// IncludeFields fields to include in the summaries
+	//
+	// required: false
+	IncludeFields ApplicationSearchIncludeFields `json:"includeFields"`
 }
 
+// ApplicationSearchIncludeFields fields to include in the application summaries
+// swagger:model ApplicationSearchIncludeFields
 type ApplicationSearchIncludeFields struct {
-	LatestJobSummary bool
-	Environments     bool
+	// LatestJobSummary true to include the latest job
+	//
+	// required: false
+	LatestJobSummary bool `json:"latestJobSummary"`
+
+	// Environments true to include the environments
+	//
+	// required: false
+	Environments bool `json:"environments"`
 }
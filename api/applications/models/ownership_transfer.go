@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// OwnershipTransferRequest describe a proposal to transfer the ownership of an application
+// swagger:model OwnershipTransferRequest
+type OwnershipTransferRequest struct {
+	// Owner email of the proposed new owner, who must accept the transfer
+	//
+	// required: true
+	// example: a_user@equinor.com
+	Owner string `json:"owner"`
+
+	// AdGroups the admin AD groups of the application after the transfer
+	//
+	// required: true
+	// example: ["5dcd2d7c-e1d3-48de-b2a8-4ba5c96da5a4"]
+	AdGroups []string `json:"adGroups"`
+}
+
+// OwnershipTransfer describe a proposed transfer of the ownership of an application, waiting to be accepted by the new owner
+// swagger:model OwnershipTransfer
+type OwnershipTransfer struct {
+	// Owner email of the proposed new owner
+	//
+	// required: true
+	// example: a_user@equinor.com
+	Owner string `json:"owner"`
+
+	// AdGroups the admin AD groups of the application after the transfer
+	//
+	// required: true
+	// example: ["5dcd2d7c-e1d3-48de-b2a8-4ba5c96da5a4"]
+	AdGroups []string `json:"adGroups"`
+
+	// ProposedBy the user who proposed the transfer
+	//
+	// required: true
+	// example: another_user@equinor.com
+	ProposedBy string `json:"proposedBy"`
+
+	// Proposed timestamp when the transfer was proposed
+	//
+	// required: true
+	// swagger:strfmt date-time
+	Proposed time.Time `json:"proposed"`
+}
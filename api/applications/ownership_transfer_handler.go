@@ -0,0 +1,151 @@
+package applications
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	applicationModels "github.com/equinor/radix-api/api/applications/models"
+	"github.com/equinor/radix-api/api/audit"
+	"github.com/equinor/radix-api/api/kubequery"
+	"github.com/equinor/radix-api/api/middleware/auth"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	"github.com/rs/zerolog/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const ownershipTransferAnnotation = "radix.equinor.com/ownership-transfer"
+
+// ProposeOwnershipTransfer proposes a new owner and admin groups for the application. The transfer is applied when the new owner accepts it
+func (ah *ApplicationHandler) ProposeOwnershipTransfer(ctx context.Context, appName string, transferRequest applicationModels.OwnershipTransferRequest) (*applicationModels.OwnershipTransfer, error) {
+	transfer := applicationModels.OwnershipTransfer{
+		Owner:      strings.TrimSpace(transferRequest.Owner),
+		AdGroups:   slices.DeleteFunc(slices.Clone(transferRequest.AdGroups), func(adGroup string) bool { return strings.TrimSpace(adGroup) == "" }),
+		ProposedBy: auth.GetOriginator(ctx),
+		Proposed:   time.Now().UTC().Truncate(time.Second),
+	}
+	if err := validateOwnershipTransfer(transfer); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only users allowed to change the registration can propose a transfer
+	err = ah.updateRegistrationAnnotations(ctx, appName, func(annotations map[string]string) {
+		annotations[ownershipTransferAnnotation] = string(data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	audit.SetDetail(ctx, "owner", transfer.Owner)
+	audit.SetDetail(ctx, "adGroups", strings.Join(transfer.AdGroups, ","))
+	return &transfer, nil
+}
+
+// GetOwnershipTransfer gets the proposed ownership transfer of the application. The proposed new owner can get it without access to the application
+func (ah *ApplicationHandler) GetOwnershipTransfer(ctx context.Context, appName string) (*applicationModels.OwnershipTransfer, error) {
+	rr, err := kubequery.GetRadixRegistration(ctx, ah.getServiceAccount().RadixClient, appName)
+	if err != nil {
+		return nil, err
+	}
+	transfer, ok := getOwnershipTransfer(rr)
+	if !ok || !isOwnershipTransferRecipient(ctx, transfer) {
+		if _, err := kubequery.GetRadixRegistration(ctx, ah.getUserAccount().RadixClient, appName); err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		return nil, noOwnershipTransferError(appName)
+	}
+	return transfer, nil
+}
+
+// CancelOwnershipTransfer removes the proposed ownership transfer of the application
+func (ah *ApplicationHandler) CancelOwnershipTransfer(ctx context.Context, appName string) error {
+	rr, err := kubequery.GetRadixRegistration(ctx, ah.getUserAccount().RadixClient, appName)
+	if err != nil {
+		return err
+	}
+	if _, ok := getOwnershipTransfer(rr); !ok {
+		return noOwnershipTransferError(appName)
+	}
+	return ah.updateRegistrationAnnotations(ctx, appName, func(annotations map[string]string) {
+		delete(annotations, ownershipTransferAnnotation)
+	})
+}
+
+// AcceptOwnershipTransfer changes the owner and admin groups of the application to the proposed ones. Only the proposed new owner can accept the transfer,
+// identified by the user principal name of their token without impersonation, and the new owner must be a member of one of the new admin groups. The registration is changed in a single update, which fails if it was changed after it was read
+func (ah *ApplicationHandler) AcceptOwnershipTransfer(ctx context.Context, appName string) (*applicationModels.ApplicationRegistration, error) {
+	// The new owner does not have access to the application before the transfer
+	rr, err := kubequery.GetRadixRegistration(ctx, ah.getServiceAccount().RadixClient, appName)
+	if err != nil {
+		return nil, err
+	}
+	transfer, ok := getOwnershipTransfer(rr)
+	if !ok {
+		return nil, noOwnershipTransferError(appName)
+	}
+	if !isOwnershipTransferRecipient(ctx, transfer) {
+		return nil, ownershipTransferRecipientRequiredError()
+	}
+	if err := validateOwnershipTransfer(*transfer); err != nil {
+		return nil, err
+	}
+	if err := ah.validateUserIsMemberOfAdGroups(ctx, appName, transfer.AdGroups); err != nil {
+		return nil, err
+	}
+
+	updatedRegistration := rr.DeepCopy()
+	updatedRegistration.Spec.Owner = transfer.Owner
+	updatedRegistration.Spec.AdGroups = transfer.AdGroups
+	delete(updatedRegistration.Annotations, ownershipTransferAnnotation)
+	updatedRegistration, err = ah.getServiceAccount().RadixClient.RadixV1().RadixRegistrations().Update(ctx, updatedRegistration, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	audit.SetDetail(ctx, "previousOwner", rr.Spec.Owner)
+	audit.SetDetail(ctx, "previousAdGroups", strings.Join(rr.Spec.AdGroups, ","))
+	audit.SetDetail(ctx, "owner", transfer.Owner)
+	audit.SetDetail(ctx, "adGroups", strings.Join(transfer.AdGroups, ","))
+	audit.SetDetail(ctx, "proposedBy", transfer.ProposedBy)
+	log.Ctx(ctx).Info().Msgf("Transferred ownership of application %s from %s to %s, proposed by %s", appName, rr.Spec.Owner, transfer.Owner, transfer.ProposedBy)
+
+	registration := applicationModels.NewApplicationRegistrationBuilder().WithRadixRegistration(updatedRegistration).Build()
+	return &registration, nil
+}
+
+func validateOwnershipTransfer(transfer applicationModels.OwnershipTransfer) error {
+	switch {
+	case transfer.Owner == "":
+		return invalidOwnershipTransferError("owner is required")
+	case len(transfer.AdGroups) == 0:
+		return invalidOwnershipTransferError("the application must have at least one admin AD group after the transfer")
+	}
+	return nil
+}
+
+// isOwnershipTransferRecipient returns true when the request is authenticated with a token issued to the proposed new owner.
+// Impersonated requests and tokens not issued to users are never the recipient, since their name can be chosen by the caller
+func isOwnershipTransferRecipient(ctx context.Context, transfer *applicationModels.OwnershipTransfer) bool {
+	userPrincipalName, ok := auth.CtxUserPrincipalName(ctx)
+	return ok && strings.EqualFold(userPrincipalName, transfer.Owner)
+}
+
+// getOwnershipTransfer returns the proposed ownership transfer of the application, and false when no transfer is proposed
+func getOwnershipTransfer(rr *v1.RadixRegistration) (*applicationModels.OwnershipTransfer, bool) {
+	data, ok := rr.Annotations[ownershipTransferAnnotation]
+	if !ok {
+		return nil, false
+	}
+	var transfer applicationModels.OwnershipTransfer
+	if err := json.Unmarshal([]byte(data), &transfer); err != nil {
+		return nil, false
+	}
+	return &transfer, true
+}
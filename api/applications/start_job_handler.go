@@ -13,6 +13,7 @@ import (
 	k8sObjectUtils "github.com/equinor/radix-operator/pkg/apis/utils"
 	"github.com/equinor/radix-operator/pkg/client/clientset/versioned"
 	"github.com/rs/zerolog/log"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -20,15 +21,57 @@ const radixGitHubWebhookUserNameRegEx = `^system:serviceaccount:radix-github-web
 
 // HandleStartPipelineJob Handles the creation of a pipeline jobController for an application
 func HandleStartPipelineJob(ctx context.Context, radixClient versioned.Interface, appName string, pipeline *pipelineJob.Definition, jobParameters *jobModels.JobParameters) (*jobModels.JobSummary, error) {
+	return handleStartIdempotentPipelineJob(ctx, radixClient, appName, pipeline, jobParameters, nil)
+}
+
+// handleStartIdempotentPipelineJob Handles the creation of a pipeline jobController for an application.
+// The existing job is returned when the request is a replay of an earlier request with the same idempotency key
+func handleStartIdempotentPipelineJob(ctx context.Context, radixClient versioned.Interface, appName string, pipeline *pipelineJob.Definition, jobParameters *jobModels.JobParameters, idempotency *idempotency) (*jobModels.JobSummary, error) {
 	if _, err := radixClient.RadixV1().RadixRegistrations().Get(ctx, appName, metav1.GetOptions{}); err != nil {
 		return nil, err
 	}
 
+	var latestJob *v1.RadixJob
+	if idempotency != nil {
+		var err error
+		if latestJob, err = idempotency.getLatestJob(ctx, radixClient, appName); err != nil {
+			return nil, err
+		}
+		if latestJob != nil {
+			replay, err := idempotency.isReplayOf(latestJob)
+			if err != nil {
+				return nil, err
+			}
+			if replay {
+				log.Ctx(ctx).Info().Msgf("Pipeline job %s was already started with the same idempotency key", latestJob.GetName())
+				return jobModels.GetSummaryFromRadixJob(latestJob), nil
+			}
+		}
+	}
+
 	job, err := buildPipelineJob(ctx, appName, pipeline, jobParameters)
 	if err != nil {
 		return nil, err
 	}
-	return createPipelineJob(ctx, radixClient, appName, job)
+	if idempotency == nil {
+		return createPipelineJob(ctx, radixClient, appName, job)
+	}
+
+	idempotency.apply(job, latestJob)
+	jobSummary, err := createPipelineJob(ctx, radixClient, appName, job)
+	if !k8serrors.IsAlreadyExists(err) {
+		return jobSummary, err
+	}
+	// A concurrent request with the same key created the job first
+	existingJob, err := radixClient.RadixV1().RadixJobs(k8sObjectUtils.GetAppNamespace(appName)).Get(ctx, job.GetName(), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if existingJob.Annotations[idempotencyPayloadAnnotation] != idempotency.payloadHash {
+		return nil, idempotencyKeyReusedError(existingJob.GetName())
+	}
+	log.Ctx(ctx).Info().Msgf("Pipeline job %s was already started with the same idempotency key", existingJob.GetName())
+	return jobModels.GetSummaryFromRadixJob(existingJob), nil
 }
 
 func createPipelineJob(ctx context.Context, radixClient versioned.Interface, appName string, job *v1.RadixJob) (*jobModels.JobSummary, error) {
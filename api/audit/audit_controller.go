@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"net/http"
+
+	auditModels "github.com/equinor/radix-api/api/audit/models"
+	"github.com/equinor/radix-api/api/utils/pagination"
+	"github.com/equinor/radix-api/models"
+	"github.com/gorilla/mux"
+)
+
+const rootPath = "/applications/{appName}"
+
+type auditController struct {
+	*models.DefaultController
+	handler Handler
+}
+
+// NewAuditController Constructor. Only register it when the audit sink is a Querier
+func NewAuditController(handler Handler) models.Controller {
+	return &auditController{handler: handler}
+}
+
+// GetRoutes List the supported routes of this handler
+func (c *auditController) GetRoutes() models.Routes {
+	routes := models.Routes{
+		models.Route{
+			Path:        rootPath + "/auditlog",
+			Method:      "GET",
+			HandlerFunc: c.GetAuditLog,
+		},
+	}
+
+	return routes
+}
+
+// GetAuditLog Get the audit log of mutating operations for the application
+func (c *auditController) GetAuditLog(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/auditlog application getAuditLog
+	// ---
+	// summary: Gets the audit log of mutating operations for the application, newest first and one page at a time
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: limit
+	//   in: query
+	//   description: Maximum number of entries in the page. Defaults to 500
+	//   type: integer
+	//   required: false
+	// - name: continue
+	//   in: query
+	//   description: The next value from the previous page, to get the following page of entries
+	//   type: string
+	//   required: false
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//        "$ref": "#/definitions/AuditEntryPage"
+	//   "400":
+	//     description: "Invalid limit or continue"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	pageParams, err := pagination.GetParams(r)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	entries, err := c.handler.GetAuditLog(r.Context(), appName)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	response, err := pagination.Paginate(entries, pageParams, func(entry auditModels.AuditEntry) string { return entry.ID })
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, response)
+}
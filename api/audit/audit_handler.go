@@ -0,0 +1,29 @@
+package audit
+
+import (
+	"context"
+
+	auditModels "github.com/equinor/radix-api/api/audit/models"
+)
+
+// Handler Reads the audit log
+type Handler struct {
+	querier Querier
+}
+
+// NewHandler Constructor
+func NewHandler(querier Querier) Handler {
+	return Handler{querier: querier}
+}
+
+// GetAuditLog returns the audit entries for the application, newest first
+func (h Handler) GetAuditLog(ctx context.Context, appName string) ([]auditModels.AuditEntry, error) {
+	entries, err := h.querier.Query(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []auditModels.AuditEntry{}
+	}
+	return entries, nil
+}
@@ -0,0 +1,211 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	auditModels "github.com/equinor/radix-api/api/audit/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	configMapNamePrefix = "radix-api-auditlog"
+	configMapEntriesKey = "entries.json"
+
+	configMapQueueSize    = 1000
+	configMapWriteTimeout = 30 * time.Second
+)
+
+var lostEntries = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "radix_api_audit_entries_lost_total",
+	Help: "The total number of audit entries that could not be written to the ConfigMap sink",
+})
+
+// ConfigMapSink Stores the latest audit entries for each application in ConfigMaps
+type ConfigMapSink struct {
+	client     kubernetes.Interface
+	namespace  string
+	maxEntries int
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan auditModels.AuditEntry
+	done   chan struct{}
+}
+
+var _ Querier = &ConfigMapSink{}
+
+// NewConfigMapSink Stores the latest maxEntries audit entries for each application in a ConfigMap in the namespace.
+// Entries without an application are stored in a shared ConfigMap. Entries are queued, and written in the background in batches,
+// with one update of each ConfigMap for the entries queued while the previous batch was written. Call Close to write the queued entries
+func NewConfigMapSink(client kubernetes.Interface, namespace string, maxEntries int) *ConfigMapSink {
+	s := &ConfigMapSink{
+		client:     client,
+		namespace:  namespace,
+		maxEntries: maxEntries,
+		queue:      make(chan auditModels.AuditEntry, configMapQueueSize),
+		done:       make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Write queues the entry. An error is returned when the queue is full or the sink is closed
+func (s *ConfigMapSink) Write(_ context.Context, entry auditModels.AuditEntry) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		lostEntries.Inc()
+		return errors.New("the audit sink is closed")
+	}
+	select {
+	case s.queue <- entry:
+		return nil
+	default:
+		lostEntries.Inc()
+		return errors.New("the audit sink queue is full")
+	}
+}
+
+// Close stops accepting entries, and waits until the queued entries are written or ctx is done
+func (s *ConfigMapSink) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *ConfigMapSink) run() {
+	defer close(s.done)
+	for entry := range s.queue {
+		batch := append(make([]auditModels.AuditEntry, 0, len(s.queue)+1), entry)
+	drain:
+		for {
+			select {
+			case entry, ok := <-s.queue:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, entry)
+			default:
+				break drain
+			}
+		}
+		s.writeBatch(batch)
+	}
+}
+
+// writeBatch appends the entries to the ConfigMap of each application, keeping the order of the entries
+func (s *ConfigMapSink) writeBatch(batch []auditModels.AuditEntry) {
+	var names []string
+	entriesByName := map[string][]auditModels.AuditEntry{}
+	for _, entry := range batch {
+		name := getConfigMapName(entry.AppName)
+		if _, ok := entriesByName[name]; !ok {
+			names = append(names, name)
+		}
+		entriesByName[name] = append(entriesByName[name], entry)
+	}
+
+	for _, name := range names {
+		ctx, cancel := context.WithTimeout(context.Background(), configMapWriteTimeout)
+		if err := s.write(ctx, name, entriesByName[name]); err != nil {
+			lostEntries.Add(float64(len(entriesByName[name])))
+			log.Error().Err(err).Msgf("failed to write %d audit entries to ConfigMap %s", len(entriesByName[name]), name)
+		}
+		cancel()
+	}
+}
+
+func (s *ConfigMapSink) write(ctx context.Context, name string, newEntries []auditModels.AuditEntry) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, name, metav1.GetOptions{})
+		exists := err == nil
+		if kubeerrors.IsNotFound(err) {
+			configMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: s.namespace}}
+		} else if err != nil {
+			return err
+		}
+
+		entries, err := getConfigMapEntries(configMap)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, newEntries...)
+		if len(entries) > s.maxEntries {
+			entries = entries[len(entries)-s.maxEntries:]
+		}
+		data, err := json.Marshal(entries)
+		if err != nil {
+			return err
+		}
+		configMap.Data = map[string]string{configMapEntriesKey: string(data)}
+
+		if !exists {
+			_, err = s.client.CoreV1().ConfigMaps(s.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+			if kubeerrors.IsAlreadyExists(err) {
+				return kubeerrors.NewConflict(corev1.Resource("configmaps"), configMap.Name, err)
+			}
+			return err
+		}
+		_, err = s.client.CoreV1().ConfigMaps(s.namespace).Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// Query returns the audit entries for the application, newest first. Entries still in the queue are not included
+func (s *ConfigMapSink) Query(ctx context.Context, appName string) ([]auditModels.AuditEntry, error) {
+	configMap, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, getConfigMapName(appName), metav1.GetOptions{})
+	if kubeerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := getConfigMapEntries(configMap)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+func getConfigMapName(appName string) string {
+	if appName == "" {
+		return configMapNamePrefix
+	}
+	return configMapNamePrefix + "-" + appName
+}
+
+func getConfigMapEntries(configMap *corev1.ConfigMap) ([]auditModels.AuditEntry, error) {
+	data, ok := configMap.Data[configMapEntriesKey]
+	if !ok {
+		return nil, nil
+	}
+	var entries []auditModels.AuditEntry
+	if err := json.Unmarshal([]byte(data), &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+type contextKey string
+
+var detailsContextKey = contextKey("auditDetails")
+
+type details struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func withDetails(ctx context.Context) (context.Context, *details) {
+	d := &details{}
+	return context.WithValue(ctx, detailsContextKey, d), d
+}
+
+func (d *details) get() map[string]string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return maps.Clone(d.values)
+}
+
+// SetDetail adds a detail of the operation to the audit entry of the request. It is ignored when the request is not audited
+func SetDetail(ctx context.Context, name, value string) {
+	d, ok := ctx.Value(detailsContextKey).(*details)
+	if !ok {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.values == nil {
+		d.values = make(map[string]string)
+	}
+	d.values[name] = value
+}
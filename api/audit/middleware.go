@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	auditModels "github.com/equinor/radix-api/api/audit/models"
+	"github.com/equinor/radix-api/api/middleware/auth"
+	"github.com/felixge/httpsnoop"
+	"github.com/gorilla/mux"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/negroni/v3"
+)
+
+const (
+	redactedValue      = "[redacted]"
+	maxSummaryBodySize = 64 * 1024
+)
+
+// sensitiveFieldName matches names of query parameters and body fields whose values are never written to the audit log
+var sensitiveFieldName = regexp.MustCompile(`(?i)secret|password|token|value|key|cert|credential|private`)
+
+// NewAuditMiddleware Writes an audit entry to the sink for each request to the route. A nil sink disables auditing
+func NewAuditMiddleware(sink Sink, method, route string) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		if sink == nil {
+			next(w, r)
+			return
+		}
+
+		request := summarizeRequest(r)
+		ctx, details := withDetails(r.Context())
+		m := httpsnoop.CaptureMetrics(next, w, r.WithContext(ctx))
+
+		entry := newAuditEntry(r, method, route, request, m.Code)
+		entry.Details = details.get()
+		if err := sink.Write(context.WithoutCancel(r.Context()), entry); err != nil {
+			log.Ctx(r.Context()).Error().Err(err).Msg("failed to write audit entry")
+		}
+	}
+}
+
+func newAuditEntry(r *http.Request, method, route string, request map[string]string, statusCode int) auditModels.AuditEntry {
+	principal := auth.CtxTokenPrincipal(r.Context())
+	impersonation := auth.CtxImpersonation(r.Context())
+	vars := mux.Vars(r)
+
+	outcome := auditModels.AuditOutcomeSuccess
+	if statusCode >= http.StatusBadRequest {
+		outcome = auditModels.AuditOutcomeFailure
+	}
+	componentName := vars["componentName"]
+	if componentName == "" {
+		componentName = vars["jobComponentName"]
+	}
+
+	return auditModels.AuditEntry{
+		ID:                xid.New().String(),
+		Time:              time.Now().UTC(),
+		User:              principal.Name(),
+		UserID:            principal.Id(),
+		ImpersonateUser:   impersonation.User,
+		ImpersonateGroups: impersonation.Groups,
+		Method:            method,
+		Route:             route,
+		Path:              r.URL.Path,
+		AppName:           vars["appName"],
+		EnvName:           vars["envName"],
+		ComponentName:     componentName,
+		Request:           request,
+		StatusCode:        statusCode,
+		Outcome:           outcome,
+	}
+}
+
+// summarizeRequest returns the query parameters and the top level fields of the body, when it is a JSON object.
+// Values of objects, arrays and fields with sensitive names are redacted. The body is restored for the next handler
+func summarizeRequest(r *http.Request) map[string]string {
+	summary := map[string]string{}
+	for name, values := range r.URL.Query() {
+		summary["query."+name] = redact(name, values[0])
+	}
+
+	if r.Body != nil && r.Body != http.NoBody {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxSummaryBodySize+1))
+		r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), r.Body), Closer: r.Body}
+		var fields map[string]json.RawMessage
+		if err == nil && len(body) <= maxSummaryBodySize && json.Unmarshal(body, &fields) == nil {
+			for name, value := range fields {
+				summary["body."+name] = summarizeJSONValue(name, value)
+			}
+		}
+	}
+
+	if len(summary) == 0 {
+		return nil
+	}
+	return summary
+}
+
+func summarizeJSONValue(name string, value json.RawMessage) string {
+	var scalar any
+	if err := json.Unmarshal(value, &scalar); err != nil {
+		return redactedValue
+	}
+	switch scalar.(type) {
+	case map[string]any, []any:
+		return redactedValue
+	case nil:
+		return "null"
+	}
+	return redact(name, fmt.Sprint(scalar))
+}
+
+func redact(name, value string) string {
+	if sensitiveFieldName.MatchString(name) {
+		return redactedValue
+	}
+	return value
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
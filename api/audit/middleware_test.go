@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	auditModels "github.com/equinor/radix-api/api/audit/models"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memorySink struct {
+	entries []auditModels.AuditEntry
+}
+
+func (s *memorySink) Write(_ context.Context, entry auditModels.AuditEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *memorySink) Query(context.Context, string) ([]auditModels.AuditEntry, error) {
+	return s.entries, nil
+}
+
+func Test_AuditMiddleware_WritesEntry(t *testing.T) {
+	sink := &memorySink{}
+	route := "/api/v1/applications/{appName}/environments/{envName}/components/{componentName}/secrets/{secretName}"
+	var handlerBody string
+	router := mux.NewRouter()
+	router.Handle(route, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		handlerBody = string(body)
+		w.WriteHeader(http.StatusForbidden)
+	})).Methods(http.MethodPut)
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			NewAuditMiddleware(sink, http.MethodPut, route)(w, r, next.ServeHTTP)
+		})
+	})
+
+	body := `{"secretValue":"very-secret","name":"db","replicas":2,"enabled":true,"items":[{"value":"x"}],"nothing":null}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/applications/app1/environments/dev/components/web/secrets/DB_PASSWORD?dryRun=true&token=abc", strings.NewReader(body))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, body, handlerBody, "body must be restored for the handler")
+	require.Len(t, sink.entries, 1)
+	entry := sink.entries[0]
+	assert.NotEmpty(t, entry.ID)
+	assert.Equal(t, "anonymous", entry.User)
+	assert.Equal(t, http.MethodPut, entry.Method)
+	assert.Equal(t, route, entry.Route)
+	assert.Equal(t, "/api/v1/applications/app1/environments/dev/components/web/secrets/DB_PASSWORD", entry.Path)
+	assert.Equal(t, "app1", entry.AppName)
+	assert.Equal(t, "dev", entry.EnvName)
+	assert.Equal(t, "web", entry.ComponentName)
+	assert.Equal(t, http.StatusForbidden, entry.StatusCode)
+	assert.Equal(t, auditModels.AuditOutcomeFailure, entry.Outcome)
+	assert.Equal(t, map[string]string{
+		"query.dryRun":     "true",
+		"query.token":      redactedValue,
+		"body.secretValue": redactedValue,
+		"body.name":        "db",
+		"body.replicas":    "2",
+		"body.enabled":     "true",
+		"body.items":       redactedValue,
+		"body.nothing":     "null",
+	}, entry.Request)
+}
+
+func Test_AuditMiddleware_NonJSONBodyIsNotSummarized(t *testing.T) {
+	sink := &memorySink{}
+	handler := NewAuditMiddleware(sink, http.MethodPost, "/api/v1/applications/{appName}/restart")
+	var handlerBody string
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/applications/app1/restart", strings.NewReader("plain text"))
+	handler(httptest.NewRecorder(), req, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		handlerBody = string(body)
+	})
+
+	assert.Equal(t, "plain text", handlerBody)
+	require.Len(t, sink.entries, 1)
+	assert.Nil(t, sink.entries[0].Request)
+	assert.Equal(t, http.StatusOK, sink.entries[0].StatusCode)
+	assert.Equal(t, auditModels.AuditOutcomeSuccess, sink.entries[0].Outcome)
+}
+
+func Test_AuditMiddleware_NilSinkDisablesAuditing(t *testing.T) {
+	called := false
+	NewAuditMiddleware(nil, http.MethodPost, "/")(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil), func(http.ResponseWriter, *http.Request) { called = true })
+	assert.True(t, called)
+}
+
+func Test_AuditMiddleware_WritesDetails(t *testing.T) {
+	sink := &memorySink{}
+	handler := NewAuditMiddleware(sink, http.MethodPost, "/api/v1/applications/{appName}/ownership-transfer/accept")
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/applications/app1/ownership-transfer/accept", nil)
+	handler(httptest.NewRecorder(), req, func(w http.ResponseWriter, r *http.Request) {
+		SetDetail(r.Context(), "owner", "a_user@equinor.com")
+	})
+
+	require.Len(t, sink.entries, 1)
+	assert.Equal(t, map[string]string{"owner": "a_user@equinor.com"}, sink.entries[0].Details)
+}
+
+func Test_SetDetail_IgnoredWhenNotAudited(t *testing.T) {
+	assert.NotPanics(t, func() { SetDetail(context.Background(), "owner", "a_user@equinor.com") })
+}
@@ -0,0 +1,122 @@
+package models
+
+import "time"
+
+// AuditOutcome of an audited operation
+type AuditOutcome string
+
+const (
+	// AuditOutcomeSuccess the operation returned a 2xx or 3xx status code
+	AuditOutcomeSuccess AuditOutcome = "Success"
+	// AuditOutcomeFailure the operation returned a 4xx or 5xx status code
+	AuditOutcomeFailure AuditOutcome = "Failure"
+)
+
+// AuditEntry describes a mutating operation sent to the API
+// swagger:model AuditEntry
+type AuditEntry struct {
+	// ID of the audit entry
+	//
+	// required: true
+	// example: cnr2p4f5f2gs73b4pq0g
+	ID string `json:"id"`
+
+	// Time when the operation completed
+	//
+	// required: true
+	// swagger:strfmt date-time
+	Time time.Time `json:"time"`
+
+	// User name of the principal that sent the request
+	//
+	// required: true
+	// example: a_user@equinor.com
+	User string `json:"user"`
+
+	// UserID id of the principal that sent the request
+	//
+	// required: true
+	UserID string `json:"userId"`
+
+	// ImpersonateUser user impersonated by the principal
+	//
+	// required: false
+	ImpersonateUser string `json:"impersonateUser,omitempty"`
+
+	// ImpersonateGroups groups impersonated by the principal
+	//
+	// required: false
+	ImpersonateGroups []string `json:"impersonateGroups,omitempty"`
+
+	// Method HTTP method of the request
+	//
+	// required: true
+	// example: POST
+	Method string `json:"method"`
+
+	// Route template of the request
+	//
+	// required: true
+	// example: /api/v1/applications/{appName}/environments/{envName}/stop
+	Route string `json:"route"`
+
+	// Path of the request
+	//
+	// required: true
+	// example: /api/v1/applications/my-app/environments/dev/stop
+	Path string `json:"path"`
+
+	// AppName name of the application
+	//
+	// required: false
+	AppName string `json:"appName,omitempty"`
+
+	// EnvName name of the environment
+	//
+	// required: false
+	EnvName string `json:"envName,omitempty"`
+
+	// ComponentName name of the component or job component
+	//
+	// required: false
+	ComponentName string `json:"componentName,omitempty"`
+
+	// Request summary of the query parameters and top level body fields. Values of fields that may contain secrets are redacted
+	//
+	// required: false
+	// example: {"body.fromEnvironment":"dev","body.toEnvironment":"prod"}
+	Request map[string]string `json:"request,omitempty"`
+
+	// Details of the operation added by the handler of the request
+	//
+	// required: false
+	// example: {"previousOwner":"a_user@equinor.com","owner":"another_user@equinor.com"}
+	Details map[string]string `json:"details,omitempty"`
+
+	// StatusCode HTTP status code of the response
+	//
+	// required: true
+	// example: 200
+	StatusCode int `json:"statusCode"`
+
+	// Outcome of the operation
+	//
+	// required: true
+	// enum: Success,Failure
+	// example: Success
+	Outcome AuditOutcome `json:"outcome"`
+}
+
+// AuditEntryPage is a page of audit entries
+// swagger:model AuditEntryPage
+type AuditEntryPage struct {
+	// Items in the page
+	//
+	// required: true
+	Items []AuditEntry `json:"items"`
+
+	// Next is the value of the continue query parameter to get the next page. Omitted when there are no more entries
+	//
+	// required: false
+	Next string `json:"next,omitempty"`
+}
@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	auditModels "github.com/equinor/radix-api/api/audit/models"
+)
+
+// Sink Stores audit entries
+type Sink interface {
+	// Write stores the audit entry
+	Write(ctx context.Context, entry auditModels.AuditEntry) error
+}
+
+// Querier Reads audit entries. Sinks that cannot read back the entries they have written, from all replicas, do not implement it
+type Querier interface {
+	// Query returns the audit entries for the application, newest first
+	Query(ctx context.Context, appName string) ([]auditModels.AuditEntry, error)
+}
+
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewStdoutSink Writes audit entries to stdout as JSON lines
+func NewStdoutSink() Sink {
+	return &writerSink{w: os.Stdout}
+}
+
+func (s *writerSink) Write(_ context.Context, entry auditModels.AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// NewFileSink Appends audit entries to the file as JSON lines, e.g. to be shipped to a central log store.
+// The entries cannot be queried, since each replica only has its own entries
+func NewFileSink(path string) (Sink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &writerSink{w: file}, nil
+}
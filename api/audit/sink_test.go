@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	auditModels "github.com/equinor/radix-api/api/audit/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_WriterSink_WritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	sink := &writerSink{w: &buf}
+	require.NoError(t, sink.Write(context.Background(), auditModels.AuditEntry{ID: "1", AppName: "app1"}))
+	require.NoError(t, sink.Write(context.Background(), auditModels.AuditEntry{ID: "2", AppName: "app1"}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"id":"1"`)
+}
+
+func Test_FileSink_AppendsJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+	for _, entry := range []auditModels.AuditEntry{{ID: "1", AppName: "app1"}, {ID: "2", AppName: "app2"}} {
+		require.NoError(t, sink.Write(context.Background(), entry))
+	}
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[1], `"id":"2"`)
+	assert.NotImplements(t, (*Querier)(nil), sink, "entries of other replicas are not in the file")
+}
+
+func Test_ConfigMapSink_KeepsLatestEntriesPerApplication(t *testing.T) {
+	client := kubefake.NewSimpleClientset() //nolint:staticcheck
+	sink := NewConfigMapSink(client, "radix-api-qa", 2)
+	for _, entry := range []auditModels.AuditEntry{{ID: "1", AppName: "app1"}, {ID: "2", AppName: "app1"}, {ID: "3", AppName: "app2"}, {ID: "4", AppName: "app1"}, {ID: "5"}} {
+		require.NoError(t, sink.Write(context.Background(), entry))
+	}
+	require.NoError(t, sink.Close(context.Background()))
+
+	entries, err := sink.Query(context.Background(), "app1")
+	require.NoError(t, err)
+	assert.Equal(t, []auditModels.AuditEntry{{ID: "4", AppName: "app1"}, {ID: "2", AppName: "app1"}}, entries)
+
+	entries, err = sink.Query(context.Background(), "app2")
+	require.NoError(t, err)
+	assert.Equal(t, []auditModels.AuditEntry{{ID: "3", AppName: "app2"}}, entries)
+
+	entries, err = sink.Query(context.Background(), "app3")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, err = client.CoreV1().ConfigMaps("radix-api-qa").Get(context.Background(), "radix-api-auditlog", metav1.GetOptions{})
+	assert.NoError(t, err, "entries without application are stored in a shared ConfigMap")
+}
+
+func Test_ConfigMapSink_WritesBatchInOneUpdate(t *testing.T) {
+	client := kubefake.NewSimpleClientset() //nolint:staticcheck
+	sink := NewConfigMapSink(client, "radix-api-qa", 10)
+	sink.writeBatch([]auditModels.AuditEntry{{ID: "1", AppName: "app1"}, {ID: "2", AppName: "app2"}, {ID: "3", AppName: "app1"}})
+	require.NoError(t, sink.Close(context.Background()))
+
+	var writes int
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "create" || action.GetVerb() == "update" {
+			writes++
+		}
+	}
+	assert.Equal(t, 2, writes, "each ConfigMap should be written once for the batch")
+	entries, err := sink.Query(context.Background(), "app1")
+	require.NoError(t, err)
+	assert.Equal(t, []auditModels.AuditEntry{{ID: "3", AppName: "app1"}, {ID: "1", AppName: "app1"}}, entries)
+}
+
+func Test_ConfigMapSink_WriteAfterCloseFails(t *testing.T) {
+	sink := NewConfigMapSink(kubefake.NewSimpleClientset(), "radix-api-qa", 10) //nolint:staticcheck
+	require.NoError(t, sink.Close(context.Background()))
+	assert.Error(t, sink.Write(context.Background(), auditModels.AuditEntry{ID: "1", AppName: "app1"}))
+}
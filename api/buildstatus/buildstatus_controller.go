@@ -6,6 +6,7 @@ import (
 	"time"
 
 	buildmodels "github.com/equinor/radix-api/api/buildstatus/models"
+	"github.com/equinor/radix-api/api/kubequery"
 	"github.com/equinor/radix-api/models"
 	radixv1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
 	"github.com/gorilla/mux"
@@ -17,11 +18,12 @@ const rootPath = "/applications/{appName}/environments/{envName}"
 type buildStatusController struct {
 	*models.DefaultController
 	buildmodels.PipelineBadge
+	cache *kubequery.Cache
 }
 
-// NewBuildStatusController Constructor
-func NewBuildStatusController(status buildmodels.PipelineBadge) models.Controller {
-	return &buildStatusController{PipelineBadge: status}
+// NewBuildStatusController Constructor. cache is optional, and is used to read RadixJobs when set
+func NewBuildStatusController(status buildmodels.PipelineBadge, cache *kubequery.Cache) models.Controller {
+	return &buildStatusController{PipelineBadge: status, cache: cache}
 }
 
 // GetRoutes List the supported routes of this handler
@@ -32,6 +34,11 @@ func (bsc *buildStatusController) GetRoutes() models.Routes {
 			Method:                    "GET",
 			HandlerFunc:               bsc.GetBuildStatus,
 			AllowUnauthenticatedUsers: true,
+			// Badges are requested anonymously, often through image proxies sharing the same IP address
+			RateLimit: models.RateLimitConfig{
+				RequestsPerSecond: 50,
+				Burst:             200,
+			},
 		},
 	}
 
@@ -74,7 +81,7 @@ func (bsc *buildStatusController) GetBuildStatus(accounts models.Accounts, w htt
 		pipeline = html.EscapeString(queryPipeline)
 	}
 
-	buildStatusHandler := Init(accounts, bsc.PipelineBadge)
+	buildStatusHandler := Init(accounts, bsc.PipelineBadge, bsc.cache)
 	buildStatus, err := buildStatusHandler.GetBuildStatusForApplication(r.Context(), appName, env, pipeline)
 
 	if err != nil {
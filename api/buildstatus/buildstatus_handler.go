@@ -6,37 +6,33 @@ import (
 	"strings"
 
 	build_models "github.com/equinor/radix-api/api/buildstatus/models"
+	"github.com/equinor/radix-api/api/kubequery"
 	"github.com/equinor/radix-api/models"
 	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
-	operatorUtils "github.com/equinor/radix-operator/pkg/apis/utils"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type BuildStatusHandler struct {
 	accounts      models.Accounts
 	pipelineBadge build_models.PipelineBadge
+	cache         *kubequery.Cache
 }
 
-func Init(accounts models.Accounts, pipelineBadge build_models.PipelineBadge) BuildStatusHandler {
-	return BuildStatusHandler{accounts: accounts, pipelineBadge: pipelineBadge}
+func Init(accounts models.Accounts, pipelineBadge build_models.PipelineBadge, cache *kubequery.Cache) BuildStatusHandler {
+	return BuildStatusHandler{accounts: accounts, pipelineBadge: pipelineBadge, cache: cache}
 }
 
 // GetBuildStatusForApplication Gets a list of build status for environments
 func (handler BuildStatusHandler) GetBuildStatusForApplication(ctx context.Context, appName, env, pipeline string) ([]byte, error) {
 	var output []byte
 
-	// Get latest RJ
-	serviceAccount := handler.accounts.ServiceAccount
-	namespace := operatorUtils.GetAppNamespace(appName)
-
-	// Get list of Jobs in the namespace
-	radixJobs, err := serviceAccount.RadixClient.RadixV1().RadixJobs(namespace).List(ctx, metav1.ListOptions{})
+	// Get list of Jobs in the app namespace
+	radixJobs, err := handler.cache.GetRadixJobs(ctx, handler.accounts.ServiceAccount.RadixClient, appName)
 	if err != nil {
 		return nil, err
 	}
 
 	var buildCondition v1.RadixJobCondition
-	if latestPipelineJob := getLatestPipelineJobToEnvironment(radixJobs.Items, env, pipeline); latestPipelineJob != nil {
+	if latestPipelineJob := getLatestPipelineJobToEnvironment(radixJobs, env, pipeline); latestPipelineJob != nil {
 		buildCondition = latestPipelineJob.Status.Condition
 	}
 
@@ -20,6 +20,8 @@ func TestGetSettings_Authenticated(t *testing.T) {
 		ClusterName:        "test-cluster",
 		ClusterEgressIps:   []string{"1.2.3.4"},
 		ClusterOidcIssuers: []string{"https://issuer.example.com"},
+		MaintenanceMode:    true,
+		MaintenanceReason:  "Cluster migration",
 	}
 
 	// Setup
@@ -35,6 +37,7 @@ func TestGetSettings_Authenticated(t *testing.T) {
 	assert.Equal(t, cfg.ClusterName, settings.ClusterName)
 	assert.Equal(t, cfg.ClusterEgressIps, settings.ClusterEgressIps)
 	assert.Equal(t, cfg.ClusterOidcIssuers, settings.ClusterOidcIssuers)
+	assert.Equal(t, configurationModels.Maintenance{Enabled: true, Reason: "Cluster migration"}, settings.Maintenance)
 }
 
 func TestGetSettings_NotAuthenticated(t *testing.T) {
@@ -4,11 +4,12 @@ import (
 	"context"
 
 	configurationModels "github.com/equinor/radix-api/api/configuration/models"
+	"github.com/equinor/radix-api/api/middleware/maintenance"
 	"github.com/equinor/radix-api/internal/config"
 )
 
 type configurationHandler struct {
-	config config.Config
+	getConfig func() config.Config
 }
 
 type ConfigurationHandler interface {
@@ -17,17 +18,27 @@ type ConfigurationHandler interface {
 }
 
 // Init Constructor
-func Init(config config.Config) ConfigurationHandler {
+func Init(c config.Config) ConfigurationHandler {
 	return &configurationHandler{
-		config: config,
+		getConfig: func() config.Config { return c },
+	}
+}
+
+// InitWithWatcher Constructor for a handler showing the latest reloaded config
+func InitWithWatcher(watcher *config.Watcher) ConfigurationHandler {
+	return &configurationHandler{
+		getConfig: watcher.Current,
 	}
 }
 
 func (h *configurationHandler) GetClusterConfiguration(ctx context.Context) (configurationModels.ClusterConfiguration, error) {
+	c := h.getConfig()
+	mode := maintenance.NewStatus(c.MaintenanceMode, c.MaintenanceReason)
 	return configurationModels.ClusterConfiguration{
-		ClusterEgressIps:   h.config.ClusterEgressIps,
-		ClusterOidcIssuers: h.config.ClusterOidcIssuers,
-		DNSZone:            h.config.DNSZone,
-		ClusterName:        h.config.ClusterName,
+		ClusterEgressIps:   c.ClusterEgressIps,
+		ClusterOidcIssuers: c.ClusterOidcIssuers,
+		DNSZone:            c.DNSZone,
+		ClusterName:        c.ClusterName,
+		Maintenance:        configurationModels.Maintenance{Enabled: mode.Enabled, Reason: mode.Reason},
 	}, nil
 }
@@ -18,4 +18,23 @@ type ClusterConfiguration struct {
 	//
 	// example: weekly-40
 	ClusterName string `json:"clusterName"`
+
+	// Maintenance read-only maintenance mode of the cluster
+	//
+	// required: true
+	Maintenance Maintenance `json:"maintenance"`
+}
+
+// Maintenance holds the status of the read-only maintenance mode.
+// swagger:model Maintenance
+type Maintenance struct {
+	// Enabled when changes are rejected with 503 Service Unavailable
+	//
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// Reason for the maintenance, shown to users
+	//
+	// example: Cluster migration until 14:00 UTC
+	Reason string `json:"reason,omitempty"`
 }
@@ -0,0 +1,73 @@
+package currentuser
+
+import (
+	"net/http"
+
+	"github.com/equinor/radix-api/models"
+)
+
+const rootPath = "/me"
+
+type currentUserController struct {
+	*models.DefaultController
+}
+
+// NewCurrentUserController Constructor
+func NewCurrentUserController() models.Controller {
+	return &currentUserController{}
+}
+
+// GetRoutes List the supported routes of this handler
+func (c *currentUserController) GetRoutes() models.Routes {
+	routes := models.Routes{
+		models.Route{
+			Path:        rootPath,
+			Method:      http.MethodGet,
+			HandlerFunc: c.GetCurrentUser,
+		},
+	}
+
+	return routes
+}
+
+// GetCurrentUser Get the authenticated user, and optionally the permissions of the user in an application
+func (c *currentUserController) GetCurrentUser(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /me user getCurrentUser
+	// ---
+	// summary: Gets the authenticated user. With appName, the actions the user is allowed to do in the application are included
+	// parameters:
+	// - name: appName
+	//   in: query
+	//   description: Name of an application to get the permissions of the user for
+	//   type: string
+	//   required: false
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//        "$ref": "#/definitions/CurrentUser"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found"
+	appName := r.URL.Query().Get("appName")
+	currentUser, err := NewHandler(accounts).GetCurrentUser(r.Context(), appName)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, currentUser)
+}
@@ -0,0 +1,85 @@
+package currentuser_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/equinor/radix-api/api/currentuser"
+	currentUserModels "github.com/equinor/radix-api/api/currentuser/models"
+	controllertest "github.com/equinor/radix-api/api/test"
+	authnmock "github.com/equinor/radix-api/api/utils/token/mock"
+	operatorutils "github.com/equinor/radix-operator/pkg/apis/utils"
+	radixfake "github.com/equinor/radix-operator/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	authorizationapiv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	testing2 "k8s.io/client-go/testing"
+)
+
+const anyAppName = "any-app"
+
+func setupTest(t *testing.T) *controllertest.Utils {
+	kubeClient := kubefake.NewSimpleClientset()   //nolint:staticcheck
+	radixClient := radixfake.NewSimpleClientset() //nolint:staticcheck
+	ra := operatorutils.NewRadixApplicationBuilder().WithAppName(anyAppName).WithEnvironment("dev", "main").WithEnvironment("prod", "").BuildRA()
+	_, err := radixClient.RadixV1().RadixApplications(operatorutils.GetAppNamespace(anyAppName)).Create(context.Background(), ra, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// The user can trigger pipelines, and change secrets in dev
+	kubeClient.PrependReactor("create", "selfsubjectaccessreviews", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(testing2.CreateAction).GetObject().(*authorizationapiv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		review.Status.Allowed = attributes.Resource == "radixjobs" ||
+			(attributes.Resource == "secrets" && attributes.Namespace == operatorutils.GetEnvironmentNamespace(anyAppName, "dev"))
+		return true, review, nil
+	})
+
+	mockValidator := authnmock.NewMockValidatorInterface(gomock.NewController(t))
+	mockValidator.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).AnyTimes().Return(controllertest.NewTestPrincipal(true), nil)
+	controllerTestUtils := controllertest.NewTestUtils(kubeClient, radixClient, nil, nil, nil, nil, mockValidator, currentuser.NewCurrentUserController())
+	return &controllerTestUtils
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	controllerTestUtils := setupTest(t)
+
+	response := <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/me")
+
+	require.Equal(t, http.StatusOK, response.Code)
+	var currentUser currentUserModels.CurrentUser
+	require.NoError(t, controllertest.GetResponseBody(response, &currentUser))
+	assert.Equal(t, currentUserModels.CurrentUser{Id: "test-id", Name: "test-principal"}, currentUser)
+}
+
+func TestGetCurrentUser_WithApplicationPermissions(t *testing.T) {
+	controllerTestUtils := setupTest(t)
+
+	response := <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/me?appName="+anyAppName)
+
+	require.Equal(t, http.StatusOK, response.Code)
+	var currentUser currentUserModels.CurrentUser
+	require.NoError(t, controllertest.GetResponseBody(response, &currentUser))
+	expected := &currentUserModels.ApplicationPermissions{
+		AppName:         anyAppName,
+		Admin:           false,
+		TriggerPipeline: true,
+		Environments: []currentUserModels.EnvironmentPermissions{
+			{Name: "dev", ChangeSecrets: true, StopEnvironment: false},
+			{Name: "prod", ChangeSecrets: false, StopEnvironment: false},
+		},
+	}
+	assert.Equal(t, expected, currentUser.Permissions)
+}
+
+func TestGetCurrentUser_ApplicationNotFound(t *testing.T) {
+	controllerTestUtils := setupTest(t)
+
+	response := <-controllerTestUtils.ExecuteRequest("GET", "/api/v1/me?appName=other-app")
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+}
@@ -0,0 +1,110 @@
+package currentuser
+
+import (
+	"context"
+
+	currentUserModels "github.com/equinor/radix-api/api/currentuser/models"
+	"github.com/equinor/radix-api/api/kubequery"
+	"github.com/equinor/radix-api/api/middleware/auth"
+	"github.com/equinor/radix-api/api/utils/access"
+	"github.com/equinor/radix-api/models"
+	radixv1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	operatorUtils "github.com/equinor/radix-operator/pkg/apis/utils"
+	"golang.org/x/sync/errgroup"
+	authorizationapi "k8s.io/api/authorization/v1"
+)
+
+const maxConcurrentAccessReviews = 10
+
+// Handler Describes the current user and the permissions of the user
+type Handler struct {
+	accounts models.Accounts
+}
+
+// NewHandler Constructor
+func NewHandler(accounts models.Accounts) Handler {
+	return Handler{accounts: accounts}
+}
+
+// GetCurrentUser returns the authenticated user. When appName is set, the permissions of the user in the application are included
+func (h Handler) GetCurrentUser(ctx context.Context, appName string) (*currentUserModels.CurrentUser, error) {
+	principal := auth.CtxTokenPrincipal(ctx)
+	impersonation := auth.CtxImpersonation(ctx)
+	currentUser := currentUserModels.CurrentUser{
+		Id:   principal.Id(),
+		Name: principal.Name(),
+	}
+	if impersonation.PerformImpersonation() {
+		currentUser.ImpersonateUser = impersonation.User
+		currentUser.ImpersonateGroups = impersonation.Groups
+	}
+
+	if appName != "" {
+		permissions, err := h.getApplicationPermissions(ctx, appName)
+		if err != nil {
+			return nil, err
+		}
+		currentUser.Permissions = permissions
+	}
+	return &currentUser, nil
+}
+
+// getApplicationPermissions checks the permissions with SelfSubjectAccessReviews for the resources changed by each action
+func (h Handler) getApplicationPermissions(ctx context.Context, appName string) (*currentUserModels.ApplicationPermissions, error) {
+	ra, err := kubequery.GetRadixApplication(ctx, h.accounts.UserAccount.RadixClient, appName)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := currentUserModels.ApplicationPermissions{
+		AppName:      appName,
+		Environments: make([]currentUserModels.EnvironmentPermissions, len(ra.Spec.Environments)),
+	}
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentAccessReviews)
+	check := func(allowed *bool, attributes *authorizationapi.ResourceAttributes) {
+		g.Go(func() error {
+			var err error
+			*allowed, err = access.HasAccess(ctx, h.accounts.UserAccount.Client, attributes)
+			return err
+		})
+	}
+
+	check(&permissions.Admin, &authorizationapi.ResourceAttributes{
+		Verb:     "patch",
+		Group:    radixv1.GroupName,
+		Resource: radixv1.ResourceRadixRegistrations,
+		Version:  "*",
+		Name:     appName,
+	})
+	check(&permissions.TriggerPipeline, &authorizationapi.ResourceAttributes{
+		Verb:      "create",
+		Group:     radixv1.GroupName,
+		Resource:  "radixjobs",
+		Version:   "*",
+		Namespace: operatorUtils.GetAppNamespace(appName),
+	})
+	for i, env := range ra.Spec.Environments {
+		envPermissions := &permissions.Environments[i]
+		envPermissions.Name = env.Name
+		envNamespace := operatorUtils.GetEnvironmentNamespace(appName, env.Name)
+		check(&envPermissions.ChangeSecrets, &authorizationapi.ResourceAttributes{
+			Verb:      "update",
+			Resource:  "secrets",
+			Version:   "*",
+			Namespace: envNamespace,
+		})
+		check(&envPermissions.StopEnvironment, &authorizationapi.ResourceAttributes{
+			Verb:      "patch",
+			Group:     radixv1.GroupName,
+			Resource:  "radixdeployments",
+			Version:   "*",
+			Namespace: envNamespace,
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return &permissions, nil
+}
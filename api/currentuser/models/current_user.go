@@ -0,0 +1,77 @@
+package models
+
+// CurrentUser describes the authenticated user, and optionally what the user is allowed to do in an application
+// swagger:model CurrentUser
+type CurrentUser struct {
+	// Id of the user
+	//
+	// required: true
+	// example: oid:12345678-1234-1234-1234-1234fc8fa0ea
+	Id string `json:"id"`
+
+	// Name of the user
+	//
+	// required: true
+	// example: a_user@equinor.com
+	Name string `json:"name"`
+
+	// ImpersonateUser user impersonated in the request
+	//
+	// required: false
+	ImpersonateUser string `json:"impersonateUser,omitempty"`
+
+	// ImpersonateGroups groups impersonated in the request
+	//
+	// required: false
+	ImpersonateGroups []string `json:"impersonateGroups,omitempty"`
+
+	// Permissions of the user in the application given by the appName query parameter
+	//
+	// required: false
+	Permissions *ApplicationPermissions `json:"permissions,omitempty"`
+}
+
+// ApplicationPermissions describes the actions the user is allowed to do in an application
+// swagger:model ApplicationPermissions
+type ApplicationPermissions struct {
+	// AppName name of the application
+	//
+	// required: true
+	// example: radix-canary-golang
+	AppName string `json:"appName"`
+
+	// Admin the user can change the application registration, and manage access tokens
+	//
+	// required: true
+	Admin bool `json:"admin"`
+
+	// TriggerPipeline the user can trigger pipeline jobs
+	//
+	// required: true
+	TriggerPipeline bool `json:"triggerPipeline"`
+
+	// Environments permissions of the user in each environment of the application
+	//
+	// required: true
+	Environments []EnvironmentPermissions `json:"environments"`
+}
+
+// EnvironmentPermissions describes the actions the user is allowed to do in an environment
+// swagger:model EnvironmentPermissions
+type EnvironmentPermissions struct {
+	// Name of the environment
+	//
+	// required: true
+	// example: prod
+	Name string `json:"name"`
+
+	// ChangeSecrets the user can change the secrets of the components in the environment
+	//
+	// required: true
+	ChangeSecrets bool `json:"changeSecrets"`
+
+	// StopEnvironment the user can stop, start, restart and scale the components in the environment
+	//
+	// required: true
+	StopEnvironment bool `json:"stopEnvironment"`
+}
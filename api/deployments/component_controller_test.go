@@ -10,6 +10,7 @@ import (
 	controllertest "github.com/equinor/radix-api/api/test"
 	"github.com/equinor/radix-api/api/utils"
 	"github.com/equinor/radix-api/api/utils/labelselector"
+	"github.com/equinor/radix-api/api/utils/problem"
 	radixhttp "github.com/equinor/radix-common/net/http"
 	radixutils "github.com/equinor/radix-common/utils"
 	"github.com/equinor/radix-common/utils/pointers"
@@ -59,10 +60,13 @@ func TestGetComponents_non_existing_deployment(t *testing.T) {
 	response := <-responseChannel
 
 	assert.Equal(t, 404, response.Code)
-	errorResponse, _ := controllertest.GetErrorResponse(response)
+	errorResponse, _ := controllertest.GetProblemResponse(response)
 	expectedError := deploymentModels.NonExistingDeployment(nil, "any-non-existing-deployment")
 
-	assert.Equal(t, (expectedError.(*radixhttp.Error)).Message, errorResponse.Message)
+	var expectedHttpErr *radixhttp.Error
+	require.ErrorAs(t, expectedError, &expectedHttpErr)
+	assert.Equal(t, expectedHttpErr.Message, errorResponse.Message)
+	assert.Equal(t, problem.GetCode(expectedError), errorResponse.Code)
 }
 
 func TestGetComponents_active_deployment(t *testing.T) {
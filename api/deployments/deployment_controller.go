@@ -7,7 +7,10 @@ import (
 	"strings"
 	"time"
 
+	deploymentModels "github.com/equinor/radix-api/api/deployments/models"
 	"github.com/equinor/radix-api/api/utils/logs"
+	"github.com/equinor/radix-api/api/utils/pagination"
+	"github.com/equinor/radix-api/api/utils/token"
 	"github.com/equinor/radix-api/models"
 	"github.com/gorilla/mux"
 )
@@ -30,21 +33,31 @@ func (dc *deploymentController) GetRoutes() models.Routes {
 			Path:        rootPath + "/deployments",
 			Method:      "GET",
 			HandlerFunc: dc.GetDeployments,
+			TokenScope:  token.ScopeRead,
+		},
+		models.Route{
+			Path:        rootPath + "/deployments/_page",
+			Method:      "GET",
+			HandlerFunc: dc.GetDeploymentsPage,
+			TokenScope:  token.ScopeRead,
 		},
 		models.Route{
 			Path:        rootPath + "/deployments/{deploymentName}",
 			Method:      "GET",
 			HandlerFunc: dc.GetDeployment,
+			TokenScope:  token.ScopeRead,
 		},
 		models.Route{
 			Path:        rootPath + "/deployments/{deploymentName}/components/{componentName}/replicas/{podName}/logs",
 			Method:      "GET",
 			HandlerFunc: dc.GetPodLog,
+			TokenScope:  token.ScopeRead,
 		},
 		models.Route{
 			Path:        rootPath + "/deployments/{deploymentName}/components",
 			Method:      "GET",
 			HandlerFunc: dc.GetComponents,
+			TokenScope:  token.ScopeRead,
 		},
 	}
 
@@ -118,6 +131,94 @@ func (dc *deploymentController) GetDeployments(accounts models.Accounts, w http.
 	dc.JSONResponse(w, r, appDeployments)
 }
 
+// GetDeploymentsPage Lists a page of the deployments
+func (dc *deploymentController) GetDeploymentsPage(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/deployments/_page application getDeploymentsPage
+	// ---
+	// summary: Lists the application deployments, one page at a time
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: environment
+	//   in: query
+	//   description: environment of Radix application
+	//   type: string
+	//   required: false
+	// - name: latest
+	//   in: query
+	//   description: indicator to allow only listing latest
+	//   type: boolean
+	//   required: false
+	// - name: limit
+	//   in: query
+	//   description: Maximum number of deployments in the page. Defaults to 500
+	//   type: integer
+	//   required: false
+	// - name: continue
+	//   in: query
+	//   description: The next value from the previous page, to get the following page of deployments
+	//   type: string
+	//   required: false
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//        "$ref": "#/definitions/DeploymentSummaryPage"
+	//   "400":
+	//     description: "Invalid limit or continue"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	environment := r.FormValue("environment")
+	latest := r.FormValue("latest")
+
+	var err error
+	var useLatest = false
+	if strings.TrimSpace(latest) != "" {
+		useLatest, err = strconv.ParseBool(r.FormValue("latest"))
+		if err != nil {
+			dc.ErrorResponse(w, r, err)
+			return
+		}
+	}
+	pageParams, err := pagination.GetParams(r)
+	if err != nil {
+		dc.ErrorResponse(w, r, err)
+		return
+	}
+
+	deployHandler := Init(accounts)
+	appDeployments, err := deployHandler.GetDeploymentsForApplicationEnvironment(r.Context(), appName, environment, useLatest)
+
+	if err != nil {
+		dc.ErrorResponse(w, r, err)
+		return
+	}
+
+	response, err := pagination.Paginate(appDeployments, pageParams, func(deployment *deploymentModels.DeploymentSummary) string { return deployment.Name })
+	if err != nil {
+		dc.ErrorResponse(w, r, err)
+		return
+	}
+
+	dc.JSONResponse(w, r, response)
+}
+
 // GetDeployment Get deployment details
 func (dc *deploymentController) GetDeployment(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /applications/{appName}/deployments/{deploymentName} deployment getDeployment
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	certfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/equinor/radix-api/api/utils/problem"
 	authnmock "github.com/equinor/radix-api/api/utils/token/mock"
 	"github.com/equinor/radix-operator/pkg/apis/kube"
 	kedav2 "github.com/kedacore/keda/v2/pkg/generated/clientset/versioned"
@@ -92,10 +93,13 @@ func TestGetPodLog_No_Pod(t *testing.T) {
 	response := <-responseChannel
 
 	assert.Equal(t, 404, response.Code)
-	errorResponse, _ := controllertest.GetErrorResponse(response)
+	errorResponse, _ := controllertest.GetProblemResponse(response)
 	expectedError := deploymentModels.NonExistingPod(anyAppName, anyPodName)
 
-	assert.Equal(t, (expectedError.(*radixhttp.Error)).Error(), errorResponse.Error())
+	var expectedHttpErr *radixhttp.Error
+	require.ErrorAs(t, expectedError, &expectedHttpErr)
+	assert.Equal(t, expectedHttpErr.Message, errorResponse.Message)
+	assert.Equal(t, problem.GetCode(expectedError), errorResponse.Code)
 
 }
 
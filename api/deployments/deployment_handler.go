@@ -3,7 +3,7 @@ package deployments
 import (
 	"context"
 	"io"
-	"sort"
+	"slices"
 	"strings"
 	"time"
 
@@ -299,15 +299,19 @@ func (deploy *deployHandler) getDeployments(ctx context.Context, appName string,
 }
 
 func sortRdsByActiveFromDesc(rds []radixv1.RadixDeployment) []radixv1.RadixDeployment {
-	sort.Slice(rds, func(i, j int) bool {
-		if rds[j].Status.ActiveFrom.IsZero() {
-			return true
+	// The name is the tie-breaker, since it is the key of the page cursor
+	slices.SortStableFunc(rds, func(a, b radixv1.RadixDeployment) int {
+		switch {
+		case a.Status.ActiveFrom.Equal(&b.Status.ActiveFrom):
+			return strings.Compare(a.Name, b.Name)
+		case b.Status.ActiveFrom.IsZero():
+			return -1
+		case a.Status.ActiveFrom.IsZero():
+			return 1
+		case b.Status.ActiveFrom.Before(&a.Status.ActiveFrom):
+			return -1
 		}
-
-		if rds[i].Status.ActiveFrom.IsZero() {
-			return false
-		}
-		return rds[j].Status.ActiveFrom.Before(&rds[i].Status.ActiveFrom)
+		return 1
 	})
 	return rds
 }
@@ -157,3 +157,18 @@ type DeploymentItem struct {
 	// example: 4faca8595c5283a9d0f17a623b9255a0d9866a2e
 	GitCommitHash string `json:"gitCommitHash,omitempty"`
 }
+
+// DeploymentSummaryPage is a page of deployments
+// swagger:model DeploymentSummaryPage
+type DeploymentSummaryPage struct {
+	// Items in the page
+	//
+	// required: true
+	Items []DeploymentSummary `json:"items"`
+
+	// Next is the value of the continue query parameter to get the next page. Omitted when there are no more deployments
+	//
+	// required: false
+	// example: cmFkaXgtY2FuYXJ5LWdvbGFuZy10emJxaQ
+	Next string `json:"next,omitempty"`
+}
@@ -168,3 +168,33 @@ type ScheduledBatchSummary struct {
 	// required: true
 	DeploymentName string `json:"deploymentName"`
 }
+
+// ScheduledJobSummaryPage is a page of scheduled jobs
+// swagger:model ScheduledJobSummaryPage
+type ScheduledJobSummaryPage struct {
+	// Items in the page
+	//
+	// required: true
+	Items []ScheduledJobSummary `json:"items"`
+
+	// Next is the value of the continue query parameter to get the next page. Omitted when there are no more scheduled jobs
+	//
+	// required: false
+	// example: am9iLWNvbXBvbmVudC0yMDE4MTAyOTEzNTY0NC1hbGdwdi02aHpuaA
+	Next string `json:"next,omitempty"`
+}
+
+// ScheduledBatchSummaryPage is a page of scheduled batches
+// swagger:model ScheduledBatchSummaryPage
+type ScheduledBatchSummaryPage struct {
+	// Items in the page
+	//
+	// required: true
+	Items []ScheduledBatchSummary `json:"items"`
+
+	// Next is the value of the continue query parameter to get the next page. Omitted when there are no more scheduled batches
+	//
+	// required: false
+	// example: YmF0Y2gtMjAxODEwMjkxMzU2NDQtYWxncHYtNmh6bmg
+	Next string `json:"next,omitempty"`
+}
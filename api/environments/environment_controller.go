@@ -9,8 +9,12 @@ import (
 	"time"
 
 	"github.com/equinor/radix-api/api/deployments"
+	deploymentModels "github.com/equinor/radix-api/api/deployments/models"
 	environmentsModels "github.com/equinor/radix-api/api/environments/models"
+	"github.com/equinor/radix-api/api/utils/fieldset"
 	"github.com/equinor/radix-api/api/utils/logs"
+	"github.com/equinor/radix-api/api/utils/pagination"
+	"github.com/equinor/radix-api/api/utils/token"
 	"github.com/equinor/radix-api/models"
 	"github.com/gorilla/mux"
 )
@@ -36,16 +40,25 @@ func (c *environmentController) GetRoutes() models.Routes {
 			Path:        rootPath + "/environments/{envName}/deployments",
 			Method:      http.MethodGet,
 			HandlerFunc: c.GetApplicationEnvironmentDeployments,
+			TokenScope:  token.ScopeRead,
+		},
+		models.Route{
+			Path:        rootPath + "/environments/{envName}/deployments/_page",
+			Method:      http.MethodGet,
+			HandlerFunc: c.GetApplicationEnvironmentDeploymentsPage,
+			TokenScope:  token.ScopeRead,
 		},
 		models.Route{
 			Path:        rootPath + "/environments",
 			Method:      http.MethodGet,
 			HandlerFunc: c.GetEnvironmentSummary,
+			TokenScope:  token.ScopeRead,
 		},
 		models.Route{
 			Path:        rootPath + "/environments/{envName}",
 			Method:      http.MethodGet,
 			HandlerFunc: c.GetEnvironment,
+			TokenScope:  token.ScopeRead,
 		},
 		models.Route{
 			Path:        rootPath + "/environments/{envName}",
@@ -57,6 +70,11 @@ func (c *environmentController) GetRoutes() models.Routes {
 			Method:      http.MethodDelete,
 			HandlerFunc: c.DeleteEnvironment,
 		},
+		models.Route{
+			Path:        rootPath + "/environments/{envName}/watch",
+			Method:      http.MethodGet,
+			HandlerFunc: c.WatchEnvironment,
+		},
 		models.Route{
 			Path:        rootPath + "/environments/{envName}/events",
 			Method:      http.MethodGet,
@@ -162,6 +180,11 @@ func (c *environmentController) GetRoutes() models.Routes {
 			Method:      http.MethodGet,
 			HandlerFunc: c.GetJobs,
 		},
+		models.Route{
+			Path:        rootPath + "/environments/{envName}/jobcomponents/{jobComponentName}/jobs/_page",
+			Method:      http.MethodGet,
+			HandlerFunc: c.GetJobsPage,
+		},
 		models.Route{
 			Path:        rootPath + "/environments/{envName}/jobcomponents/{jobComponentName}/jobs/{jobName}",
 			Method:      http.MethodGet,
@@ -192,6 +215,11 @@ func (c *environmentController) GetRoutes() models.Routes {
 			Method:      http.MethodGet,
 			HandlerFunc: c.GetBatches,
 		},
+		models.Route{
+			Path:        rootPath + "/environments/{envName}/jobcomponents/{jobComponentName}/batches/_page",
+			Method:      http.MethodGet,
+			HandlerFunc: c.GetBatchesPage,
+		},
 		models.Route{
 			Path:        rootPath + "/environments/{envName}/jobcomponents/{jobComponentName}/batches/{batchName}",
 			Method:      http.MethodGet,
@@ -312,11 +340,100 @@ func (c *environmentController) GetApplicationEnvironmentDeployments(accounts mo
 	appEnvironmentDeployments, err := deploymentHandler.GetDeploymentsForApplicationEnvironment(r.Context(), appName, envName, useLatest)
 	if err != nil {
 		c.ErrorResponse(w, r, err)
+		return
 	}
 
 	c.JSONResponse(w, r, appEnvironmentDeployments)
 }
 
+// GetApplicationEnvironmentDeploymentsPage Lists a page of the application environment deployments
+func (c *environmentController) GetApplicationEnvironmentDeploymentsPage(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/environments/{envName}/deployments/_page environment getApplicationEnvironmentDeploymentsPage
+	// ---
+	// summary: Lists the application environment deployments, one page at a time
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: envName
+	//   in: path
+	//   description: environment of Radix application
+	//   type: string
+	//   required: true
+	// - name: latest
+	//   in: query
+	//   description: indicator to allow only listing the latest
+	//   type: boolean
+	//   required: false
+	// - name: limit
+	//   in: query
+	//   description: Maximum number of deployments in the page. Defaults to 500
+	//   type: integer
+	//   required: false
+	// - name: continue
+	//   in: query
+	//   description: The next value from the previous page, to get the following page of deployments
+	//   type: string
+	//   required: false
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//        "$ref": "#/definitions/DeploymentSummaryPage"
+	//   "400":
+	//     description: "Invalid limit or continue"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	envName := mux.Vars(r)["envName"]
+	latest := r.FormValue("latest")
+
+	var err error
+	var useLatest = false
+	if strings.TrimSpace(latest) != "" {
+		useLatest, err = strconv.ParseBool(r.FormValue("latest"))
+		if err != nil {
+			c.ErrorResponse(w, r, err)
+			return
+		}
+	}
+	pageParams, err := pagination.GetParams(r)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	deploymentHandler := deployments.Init(accounts)
+
+	appEnvironmentDeployments, err := deploymentHandler.GetDeploymentsForApplicationEnvironment(r.Context(), appName, envName, useLatest)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	response, err := pagination.Paginate(appEnvironmentDeployments, pageParams, func(deployment *deploymentModels.DeploymentSummary) string { return deployment.Name })
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, response)
+}
+
 // CreateEnvironment Creates a new environment
 func (c *environmentController) CreateEnvironment(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
 	// swagger:operation POST /applications/{appName}/environments/{envName} environment createEnvironment
@@ -380,6 +497,11 @@ func (c *environmentController) GetEnvironment(accounts models.Accounts, w http.
 	//   description: name of environment
 	//   type: string
 	//   required: true
+	// - name: fields
+	//   in: query
+	//   description: Comma separated list of fields to return, e.g. name,status,activeDeployment. Omitted fields are not read from the cluster. All fields are returned when not set
+	//   type: string
+	//   required: false
 	// - name: Impersonate-User
 	//   in: header
 	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
@@ -395,6 +517,8 @@ func (c *environmentController) GetEnvironment(accounts models.Accounts, w http.
 	//     description: "Successful get environment"
 	//     schema:
 	//        "$ref": "#/definitions/Environment"
+	//   "400":
+	//     description: "Invalid fields"
 	//   "401":
 	//     description: "Unauthorized"
 	//   "404":
@@ -402,17 +526,82 @@ func (c *environmentController) GetEnvironment(accounts models.Accounts, w http.
 
 	appName := mux.Vars(r)["appName"]
 	envName := mux.Vars(r)["envName"]
+	fields, err := fieldset.GetFields(r, environmentsModels.Environment{})
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
 
 	environmentHandler := c.environmentHandlerFactory(accounts)
-	appEnvironment, err := environmentHandler.GetEnvironment(r.Context(), appName, envName)
+	appEnvironment, err := environmentHandler.GetEnvironment(r.Context(), appName, envName, fields)
+
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
 
+	response, err := fieldset.Select(appEnvironment, fields)
 	if err != nil {
 		c.ErrorResponse(w, r, err)
 		return
 	}
 
-	c.JSONResponse(w, r, appEnvironment)
+	c.JSONResponse(w, r, response)
+
+}
+
+// WatchEnvironment Streams changes in the environment as server-sent events
+func (c *environmentController) WatchEnvironment(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/environments/{envName}/watch environment watchEnvironment
+	// ---
+	// summary: Watch an application environment for changes
+	// description: |
+	//   Streams changes to the active deployment, component statuses, replicas and scheduled batches as server-sent events.
+	//   The event name is the type of the change, and the data is a JSON encoded EnvironmentEvent.
+	//   The current state is sent when the stream starts. The stream is completed when the watch is closed by the cluster, and clients should then reconnect.
+	// produces:
+	// - text/event-stream
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: envName
+	//   in: path
+	//   description: name of environment
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Stream of environment events"
+	//     schema:
+	//        "$ref": "#/definitions/EnvironmentEvent"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	envName := mux.Vars(r)["envName"]
+
+	environmentHandler := c.environmentHandlerFactory(accounts)
+	events, err := environmentHandler.WatchEnvironment(r.Context(), appName, envName)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
 
+	models.JSONEventStreamResponse(w, r, events, func(event environmentsModels.EnvironmentEvent) string { return string(event.Type) })
 }
 
 // DeleteEnvironment Deletes environment
@@ -1643,6 +1832,82 @@ func (c *environmentController) GetJobs(accounts models.Accounts, w http.Respons
 	c.JSONResponse(w, r, jobSummaries)
 }
 
+// GetJobsPage Lists a page of the scheduled jobs
+func (c *environmentController) GetJobsPage(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/environments/{envName}/jobcomponents/{jobComponentName}/jobs/_page job getJobsPage
+	// ---
+	// summary: Get list of scheduled jobs, one page at a time
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: Name of application
+	//   type: string
+	//   required: true
+	// - name: envName
+	//   in: path
+	//   description: Name of environment
+	//   type: string
+	//   required: true
+	// - name: jobComponentName
+	//   in: path
+	//   description: Name of job-component
+	//   type: string
+	//   required: true
+	// - name: limit
+	//   in: query
+	//   description: Maximum number of jobs in the page. Defaults to 500
+	//   type: integer
+	//   required: false
+	// - name: continue
+	//   in: query
+	//   description: The next value from the previous page, to get the following page of jobs
+	//   type: string
+	//   required: false
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "scheduled jobs"
+	//     schema:
+	//        "$ref": "#/definitions/ScheduledJobSummaryPage"
+	//   "400":
+	//     description: "Invalid limit or continue"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	envName := mux.Vars(r)["envName"]
+	jobComponentName := mux.Vars(r)["jobComponentName"]
+	pageParams, err := pagination.GetParams(r)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	eh := c.environmentHandlerFactory(accounts)
+	jobSummaries, err := eh.GetJobs(r.Context(), appName, envName, jobComponentName)
+
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	response, err := pagination.Paginate(jobSummaries, pageParams, func(summary deploymentModels.ScheduledJobSummary) string { return summary.Name })
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, response)
+}
+
 // GetJob Get a scheduled job
 func (c *environmentController) GetJob(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /applications/{appName}/environments/{envName}/jobcomponents/{jobComponentName}/jobs/{jobName} job getJob
@@ -2003,6 +2268,82 @@ func (c *environmentController) GetBatches(accounts models.Accounts, w http.Resp
 	c.JSONResponse(w, r, batchSummaries)
 }
 
+// GetBatchesPage Lists a page of the scheduled batches
+func (c *environmentController) GetBatchesPage(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/environments/{envName}/jobcomponents/{jobComponentName}/batches/_page job getBatchesPage
+	// ---
+	// summary: Get list of scheduled batches, one page at a time
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: Name of application
+	//   type: string
+	//   required: true
+	// - name: envName
+	//   in: path
+	//   description: Name of environment
+	//   type: string
+	//   required: true
+	// - name: jobComponentName
+	//   in: path
+	//   description: Name of job-component
+	//   type: string
+	//   required: true
+	// - name: limit
+	//   in: query
+	//   description: Maximum number of batches in the page. Defaults to 500
+	//   type: integer
+	//   required: false
+	// - name: continue
+	//   in: query
+	//   description: The next value from the previous page, to get the following page of batches
+	//   type: string
+	//   required: false
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "scheduled batches"
+	//     schema:
+	//        "$ref": "#/definitions/ScheduledBatchSummaryPage"
+	//   "400":
+	//     description: "Invalid limit or continue"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	envName := mux.Vars(r)["envName"]
+	jobComponentName := mux.Vars(r)["jobComponentName"]
+	pageParams, err := pagination.GetParams(r)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	eh := c.environmentHandlerFactory(accounts)
+	batchSummaries, err := eh.GetBatches(r.Context(), appName, envName, jobComponentName)
+
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	response, err := pagination.Paginate(batchSummaries, pageParams, func(summary deploymentModels.ScheduledBatchSummary) string { return summary.Name })
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, response)
+}
+
 // GetBatch Get a scheduled batch
 func (c *environmentController) GetBatch(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /applications/{appName}/environments/{envName}/jobcomponents/{jobComponentName}/batches/{batchName} job getBatch
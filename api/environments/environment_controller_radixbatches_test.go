@@ -798,15 +798,17 @@ func Test_GetJobPayload(t *testing.T) {
 	responseChannel = environmentControllerTestUtils.ExecuteRequest("GET", fmt.Sprintf("/api/v1/applications/%s/environments/%s/jobcomponents/%s/jobs/%s/payload", anyAppName, anyEnvironment, anyJobName, "job-batch1-job3"))
 	response = <-responseChannel
 	assert.Equal(t, http.StatusNotFound, response.Code)
-	errorResponse, _ := test.GetErrorResponse(response)
-	assert.Equal(t, environmentModels.ScheduledJobPayloadNotFoundError(anyAppName, "job-batch1-job3"), errorResponse)
+	errorResponse, _ := test.GetProblemResponse(response)
+	assert.Equal(t, environmentModels.CodeScheduledJobPayloadNotFound, errorResponse.Code)
+	assert.Equal(t, fmt.Sprintf("payload not found for job job-batch1-job3 in app %s", anyAppName), errorResponse.Message)
 
 	// Test job4 payload
 	responseChannel = environmentControllerTestUtils.ExecuteRequest("GET", fmt.Sprintf("/api/v1/applications/%s/environments/%s/jobcomponents/%s/jobs/%s/payload", anyAppName, anyEnvironment, anyJobName, "job-batch1-job4"))
 	response = <-responseChannel
 	assert.Equal(t, http.StatusNotFound, response.Code)
-	errorResponse, _ = test.GetErrorResponse(response)
-	assert.Equal(t, environmentModels.ScheduledJobPayloadNotFoundError(anyAppName, "job-batch1-job4"), errorResponse)
+	errorResponse, _ = test.GetProblemResponse(response)
+	assert.Equal(t, environmentModels.CodeScheduledJobPayloadNotFound, errorResponse.Code)
+	assert.Equal(t, fmt.Sprintf("payload not found for job job-batch1-job4 in app %s", anyAppName), errorResponse.Message)
 
 }
 
@@ -17,6 +17,7 @@ import (
 	"github.com/equinor/radix-api/api/secrets/suffix"
 	controllertest "github.com/equinor/radix-api/api/test"
 	"github.com/equinor/radix-api/api/utils"
+	"github.com/equinor/radix-api/api/utils/problem"
 	authnmock "github.com/equinor/radix-api/api/utils/token/mock"
 	radixhttp "github.com/equinor/radix-common/net/http"
 	"github.com/equinor/radix-common/utils/pointers"
@@ -343,9 +344,12 @@ func TestDeleteEnvironment_OneOrphanedEnvironment_OnlyOrphanedCanBeDeleted(t *te
 	responseChannel = environmentControllerTestUtils.ExecuteRequest("DELETE", fmt.Sprintf("/api/v1/applications/%s/environments/%s", anyAppName, anyNonOrphanedEnvironment))
 	response = <-responseChannel
 	assert.Equal(t, http.StatusBadRequest, response.Code)
-	errorResponse, _ := controllertest.GetErrorResponse(response)
+	errorResponse, _ := controllertest.GetProblemResponse(response)
 	expectedError := environmentModels.CannotDeleteNonOrphanedEnvironment(anyAppName, anyNonOrphanedEnvironment)
-	assert.Equal(t, (expectedError.(*radixhttp.Error)).Message, errorResponse.Message)
+	var expectedHttpErr *radixhttp.Error
+	require.ErrorAs(t, expectedError, &expectedHttpErr)
+	assert.Equal(t, expectedHttpErr.Message, errorResponse.Message)
+	assert.Equal(t, problem.GetCode(expectedError), errorResponse.Code)
 
 	// Only one remaining environment after delete
 	responseChannel = environmentControllerTestUtils.ExecuteRequest("GET", fmt.Sprintf("/api/v1/applications/%s/environments", anyAppName))
@@ -374,9 +378,12 @@ func TestGetEnvironment_NoExistingEnvironment_ReturnsAnError(t *testing.T) {
 	response := <-responseChannel
 	assert.Equal(t, http.StatusNotFound, response.Code)
 
-	errorResponse, _ := controllertest.GetErrorResponse(response)
+	errorResponse, _ := controllertest.GetProblemResponse(response)
 	expectedError := environmentModels.NonExistingEnvironment(nil, anyAppName, anyNonExistingEnvironment)
-	assert.Equal(t, (expectedError.(*radixhttp.Error)).Message, errorResponse.Message)
+	var expectedHttpErr *radixhttp.Error
+	require.ErrorAs(t, expectedError, &expectedHttpErr)
+	assert.Equal(t, expectedHttpErr.Message, errorResponse.Message)
+	assert.Equal(t, problem.GetCode(expectedError), errorResponse.Code)
 }
 
 func TestGetEnvironment_ExistingEnvironmentInConfig_ReturnsAPendingEnvironment(t *testing.T) {
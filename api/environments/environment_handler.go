@@ -6,6 +6,7 @@ import (
 	"io"
 	"time"
 
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/equinor/radix-api/api/deployments"
 	deploymentModels "github.com/equinor/radix-api/api/deployments/models"
 	environmentModels "github.com/equinor/radix-api/api/environments/models"
@@ -14,6 +15,7 @@ import (
 	apimodels "github.com/equinor/radix-api/api/models"
 	"github.com/equinor/radix-api/api/pods"
 	"github.com/equinor/radix-api/api/utils"
+	"github.com/equinor/radix-api/api/utils/fieldset"
 	"github.com/equinor/radix-api/api/utils/predicate"
 	"github.com/equinor/radix-api/api/utils/tlsvalidation"
 	"github.com/equinor/radix-api/models"
@@ -24,11 +26,16 @@ import (
 	"github.com/equinor/radix-operator/pkg/apis/kube"
 	radixv1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
 	k8sObjectUtils "github.com/equinor/radix-operator/pkg/apis/utils"
+	"github.com/kedacore/keda/v2/apis/keda/v1alpha1"
 	"github.com/rs/zerolog/log"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	secretsstorev1 "sigs.k8s.io/secrets-store-csi-driver/apis/v1"
 )
 
 // EnvironmentHandlerOptions defines a configuration function
@@ -57,6 +64,13 @@ func WithTLSValidator(validator tlsvalidation.Validator) EnvironmentHandlerOptio
 	}
 }
 
+// WithCache configures the cache used by EnvironmentHandler for reads with the service account
+func WithCache(cache *kubequery.Cache) EnvironmentHandlerOptions {
+	return func(eh *EnvironmentHandler) {
+		eh.cache = cache
+	}
+}
+
 func WithComponentStatuserFunc(statuser deploymentModels.ComponentStatuserFunc) EnvironmentHandlerOptions {
 	return func(eh *EnvironmentHandler) {
 		eh.ComponentStatuser = statuser
@@ -85,6 +99,7 @@ type EnvironmentHandler struct {
 	eventHandler      events.EventHandler
 	accounts          models.Accounts
 	tlsValidator      tlsvalidation.Validator
+	cache             *kubequery.Cache
 	ComponentStatuser deploymentModels.ComponentStatuserFunc
 }
 
@@ -117,7 +132,7 @@ func (eh EnvironmentHandler) GetEnvironmentSummary(ctx context.Context, appName
 		}
 		return nil, err
 	}
-	reList, err := kubequery.GetRadixEnvironments(ctx, eh.accounts.ServiceAccount.RadixClient, appName)
+	reList, err := eh.cache.GetRadixEnvironments(ctx, eh.accounts.ServiceAccount.RadixClient, appName)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +141,7 @@ func (eh EnvironmentHandler) GetEnvironmentSummary(ctx context.Context, appName
 		return nil, err
 	}
 	envNames := slice.Map(reList, func(re radixv1.RadixEnvironment) string { return re.Spec.EnvName })
-	rdList, err := kubequery.GetRadixDeploymentsForEnvironments(ctx, eh.accounts.UserAccount.RadixClient, appName, envNames, 10)
+	rdList, err := eh.cache.GetRadixDeploymentsForEnvironments(ctx, eh.accounts.ServiceAccount.RadixClient, appName, envNames)
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +151,7 @@ func (eh EnvironmentHandler) GetEnvironmentSummary(ctx context.Context, appName
 }
 
 // GetEnvironment Handler for GetEnvironment
-func (eh EnvironmentHandler) GetEnvironment(ctx context.Context, appName, envName string) (*environmentModels.Environment, error) {
+func (eh EnvironmentHandler) GetEnvironment(ctx context.Context, appName, envName string, fields fieldset.Fields) (*environmentModels.Environment, error) {
 	rr, err := kubequery.GetRadixRegistration(ctx, eh.accounts.UserAccount.RadixClient, appName)
 	if err != nil {
 		return nil, err
@@ -153,53 +168,67 @@ func (eh EnvironmentHandler) GetEnvironment(ctx context.Context, appName, envNam
 		}
 		return nil, err
 	}
-	rdList, err := kubequery.GetRadixDeploymentsForEnvironment(ctx, eh.accounts.UserAccount.RadixClient, appName, envName)
-	if err != nil {
-		return nil, err
-	}
-	rjList, err := kubequery.GetRadixJobs(ctx, eh.accounts.UserAccount.RadixClient, appName)
-	if err != nil {
-		return nil, err
-	}
-	deploymentList, err := kubequery.GetDeploymentsForEnvironment(ctx, eh.accounts.UserAccount.Client, appName, envName)
-	if err != nil {
-		return nil, err
-	}
-	componentPodList, err := kubequery.GetPodsForEnvironmentComponents(ctx, eh.accounts.UserAccount.Client, appName, envName)
-	if err != nil {
-		return nil, err
-	}
-	hpaList, err := kubequery.GetHorizontalPodAutoscalersForEnvironment(ctx, eh.accounts.UserAccount.Client, appName, envName)
-	if err != nil {
-		return nil, err
-	}
-	scaledObjects, err := kubequery.GetScaledObjectsForEnvironment(ctx, eh.accounts.UserAccount.KedaClient, appName, envName)
-	if err != nil {
-		return nil, err
-	}
-	noJobPayloadReq, err := labels.NewRequirement(kube.RadixSecretTypeLabel, selection.NotEquals, []string{string(kube.RadixSecretJobPayload)})
-	if err != nil {
-		return nil, err
-	}
-	secretList, err := kubequery.GetSecretsForEnvironment(ctx, eh.accounts.ServiceAccount.Client, appName, envName, *noJobPayloadReq)
-	if err != nil {
-		return nil, err
+
+	var (
+		rdList                  []radixv1.RadixDeployment
+		rjList                  []radixv1.RadixJob
+		deploymentList          []appsv1.Deployment
+		componentPodList        []corev1.Pod
+		hpaList                 []autoscalingv2.HorizontalPodAutoscaler
+		scaledObjects           []v1alpha1.ScaledObject
+		secretList              []corev1.Secret
+		secretProviderClassList []secretsstorev1.SecretProviderClass
+		eventList               []corev1.Event
+		certs                   []cmv1.Certificate
+		certRequests            []cmv1.CertificateRequest
+	)
+	// The active deployment decides the branch mapping and which secrets exist
+	if fields.Has("branchMapping", "deployments", "activeDeployment", "secrets") {
+		if rdList, err = kubequery.GetRadixDeploymentsForEnvironment(ctx, eh.accounts.UserAccount.RadixClient, appName, envName); err != nil {
+			return nil, err
+		}
 	}
-	secretProviderClassList, err := kubequery.GetSecretProviderClassesForEnvironment(ctx, eh.accounts.ServiceAccount.SecretProviderClient, appName, envName)
-	if err != nil {
-		return nil, err
+	if fields.Has("deployments", "activeDeployment") {
+		if rjList, err = kubequery.GetRadixJobs(ctx, eh.accounts.UserAccount.RadixClient, appName); err != nil {
+			return nil, err
+		}
 	}
-	eventList, err := kubequery.GetEventsForEnvironment(ctx, eh.accounts.UserAccount.Client, appName, envName)
-	if err != nil {
-		return nil, err
+	if fields.Has("activeDeployment") {
+		if deploymentList, err = kubequery.GetDeploymentsForEnvironment(ctx, eh.accounts.UserAccount.Client, appName, envName); err != nil {
+			return nil, err
+		}
+		if componentPodList, err = kubequery.GetPodsForEnvironmentComponents(ctx, eh.accounts.UserAccount.Client, appName, envName); err != nil {
+			return nil, err
+		}
+		if hpaList, err = kubequery.GetHorizontalPodAutoscalersForEnvironment(ctx, eh.accounts.UserAccount.Client, appName, envName); err != nil {
+			return nil, err
+		}
+		if scaledObjects, err = kubequery.GetScaledObjectsForEnvironment(ctx, eh.accounts.UserAccount.KedaClient, appName, envName); err != nil {
+			return nil, err
+		}
+		if eventList, err = kubequery.GetEventsForEnvironment(ctx, eh.accounts.UserAccount.Client, appName, envName); err != nil {
+			return nil, err
+		}
+		if certs, err = kubequery.GetCertificatesForEnvironment(ctx, eh.accounts.ServiceAccount.CertManagerClient, appName, envName); err != nil {
+			return nil, err
+		}
+		if certRequests, err = kubequery.GetCertificateRequestsForEnvironment(ctx, eh.accounts.ServiceAccount.CertManagerClient, appName, envName); err != nil {
+			return nil, err
+		}
 	}
-	certs, err := kubequery.GetCertificatesForEnvironment(ctx, eh.accounts.ServiceAccount.CertManagerClient, appName, envName)
-	if err != nil {
-		return nil, err
+	if fields.Has("activeDeployment", "secrets") {
+		noJobPayloadReq, err := labels.NewRequirement(kube.RadixSecretTypeLabel, selection.NotEquals, []string{string(kube.RadixSecretJobPayload)})
+		if err != nil {
+			return nil, err
+		}
+		if secretList, err = kubequery.GetSecretsForEnvironment(ctx, eh.accounts.ServiceAccount.Client, appName, envName, *noJobPayloadReq); err != nil {
+			return nil, err
+		}
 	}
-	certRequests, err := kubequery.GetCertificateRequestsForEnvironment(ctx, eh.accounts.ServiceAccount.CertManagerClient, appName, envName)
-	if err != nil {
-		return nil, err
+	if fields.Has("secrets") {
+		if secretProviderClassList, err = kubequery.GetSecretProviderClassesForEnvironment(ctx, eh.accounts.ServiceAccount.SecretProviderClient, appName, envName); err != nil {
+			return nil, err
+		}
 	}
 
 	env := apimodels.BuildEnvironment(ctx, rr, ra, re, rdList, rjList, deploymentList, componentPodList, hpaList, secretList, secretProviderClassList, eventList, certs, certRequests, eh.tlsValidator, scaledObjects)
@@ -6,7 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"sort"
+	"slices"
 	"strings"
 
 	deploymentModels "github.com/equinor/radix-api/api/deployments/models"
@@ -38,8 +38,8 @@ func (eh EnvironmentHandler) GetBatches(ctx context.Context, appName, envName, j
 	}
 	radixBatchStatuses := jobSchedulerBatch.GetRadixBatchStatuses(radixBatches, activeRadixDeployJobComponent)
 	batchSummaryList := models.GetScheduledBatchSummaryList(radixBatches, radixBatchStatuses, radixDeploymentsMap, jobComponentName)
-	sort.SliceStable(batchSummaryList, func(i, j int) bool {
-		return utils.IsBefore(&batchSummaryList[j], &batchSummaryList[i])
+	slices.SortStableFunc(batchSummaryList, func(a, b deploymentModels.ScheduledBatchSummary) int {
+		return utils.CompareNewestFirst(&a, &b, a.Name, b.Name)
 	})
 	return batchSummaryList, nil
 }
@@ -56,8 +56,8 @@ func (eh EnvironmentHandler) GetJobs(ctx context.Context, appName, envName, jobC
 	}
 	radixBatchStatuses := jobSchedulerBatch.GetRadixBatchStatuses(radixBatches, activeRadixDeployJobComponent)
 	jobSummaryList := models.GetScheduledSingleJobSummaryList(radixBatches, radixBatchStatuses, radixDeploymentsMap, jobComponentName)
-	sort.SliceStable(jobSummaryList, func(i, j int) bool {
-		return utils.IsBefore(&jobSummaryList[j], &jobSummaryList[i])
+	slices.SortStableFunc(jobSummaryList, func(a, b deploymentModels.ScheduledJobSummary) int {
+		return utils.CompareNewestFirst(&a, &b, a.Name, b.Name)
 	})
 	return jobSummaryList, nil
 }
@@ -4,70 +4,88 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/equinor/radix-api/api/utils/problem"
 	radixhttp "github.com/equinor/radix-common/net/http"
 )
 
+// Error codes of environment errors
+const (
+	CodeEnvironmentNotFound                 problem.Code = "environment-not-found"
+	CodeEnvironmentNotOrphaned              problem.Code = "environment-not-orphaned"
+	CodeComponentNotFound                   problem.Code = "component-not-found"
+	CodeAuxiliaryResourceNotFound           problem.Code = "auxiliary-resource-not-found"
+	CodeComponentNotStoppable               problem.Code = "component-not-stoppable"
+	CodeComponentNotManuallyScaled          problem.Code = "component-not-manually-scaled"
+	CodeComponentNotRestartable             problem.Code = "component-not-restartable"
+	CodeAuxiliaryResourceNotRestartable     problem.Code = "auxiliary-resource-not-restartable"
+	CodeAuxiliaryResourceDeploymentNotFound problem.Code = "auxiliary-resource-deployment-not-found"
+	CodeJobComponentCanOnlyBeRestarted      problem.Code = "job-component-can-only-be-restarted"
+	CodeComponentNotScalable                problem.Code = "component-not-scalable"
+	CodeNegativeReplicas                    problem.Code = "negative-replicas"
+	CodeTooManyReplicas                     problem.Code = "too-many-replicas"
+)
+
 // NonExistingEnvironment No application found by name
 func NonExistingEnvironment(underlyingError error, appName, envName string) error {
-	return radixhttp.TypeMissingError(fmt.Sprintf("Unable to get environment %s for app %s", envName, appName), underlyingError)
+	return problem.WithCode(CodeEnvironmentNotFound, radixhttp.TypeMissingError(fmt.Sprintf("Unable to get environment %s for app %s", envName, appName), underlyingError))
 }
 
 // CannotDeleteNonOrphanedEnvironment Can only delete orphaned environments
 func CannotDeleteNonOrphanedEnvironment(appName, envName string) error {
-	return radixhttp.ValidationError("Radix Application Environment", fmt.Sprintf("Cannot delete non-orphaned environment %s for application %s", envName, appName))
+	return problem.WithCode(CodeEnvironmentNotOrphaned, radixhttp.ValidationError("Radix Application Environment", fmt.Sprintf("Cannot delete non-orphaned environment %s for application %s", envName, appName)))
 }
 
 // NonExistingComponent No component found by name
 func NonExistingComponent(appName, componentName string) error {
-	return radixhttp.TypeMissingError(fmt.Sprintf("Unable to get component %s for app %s", componentName, appName), nil)
+	return problem.WithCode(CodeComponentNotFound, radixhttp.TypeMissingError(fmt.Sprintf("Unable to get component %s for app %s", componentName, appName), nil))
 }
 
 // NonExistingComponentAuxiliaryType Auxiliary resource for component component not found
 func NonExistingComponentAuxiliaryType(appName, componentName, auxType string) error {
-	return radixhttp.TypeMissingError(fmt.Sprintf("%s resource does not exist for component %s in app %s", auxType, componentName, appName), nil)
+	return problem.WithCode(CodeAuxiliaryResourceNotFound, radixhttp.TypeMissingError(fmt.Sprintf("%s resource does not exist for component %s in app %s", auxType, componentName, appName), nil))
 }
 
 // CannotStopComponent Component cannot be stopped
 func CannotStopComponent(appName, componentName, state string) error {
-	return radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s cannot be stopped when in %s state", componentName, appName, strings.ToLower(state)))
+	return problem.WithCode(CodeComponentNotStoppable, radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s cannot be stopped when in %s state", componentName, appName, strings.ToLower(state))))
 }
 
 // CannotResetScaledComponent Component cannot be started
 func CannotResetScaledComponent(appName, componentName string) error {
-	return radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s cannot be reset when not manually scaled", componentName, appName))
+	return problem.WithCode(CodeComponentNotManuallyScaled, radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s cannot be reset when not manually scaled", componentName, appName)))
 }
 
 // CannotRestartComponent Component cannot be restarted
 func CannotRestartComponent(appName, componentName, state string) error {
-	return radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s cannot be restarted when in %s state", componentName, appName, strings.ToLower(state)))
+	return problem.WithCode(CodeComponentNotRestartable, radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s cannot be restarted when in %s state", componentName, appName, strings.ToLower(state))))
 }
 
 // CannotRestartAuxiliaryResource Auxiliary resource cannot be restarted
 func CannotRestartAuxiliaryResource(appName, componentName string) error {
-	return radixhttp.ValidationError("Radix Application Auxiliary Resource", fmt.Sprintf("Auxiliary resource for component %s for app %s cannot be restarted", componentName, appName))
+	return problem.WithCode(CodeAuxiliaryResourceNotRestartable, radixhttp.ValidationError("Radix Application Auxiliary Resource", fmt.Sprintf("Auxiliary resource for component %s for app %s cannot be restarted", componentName, appName)))
 }
 
 // MissingAuxiliaryResourceDeployment Auxiliary resource cannot be found
 func MissingAuxiliaryResourceDeployment(appName, componentName string) error {
-	return radixhttp.UnexpectedError("Radix Application Auxiliary Resource", fmt.Errorf("deployment for auxiliary resource not found"))
+	return problem.WithCode(CodeAuxiliaryResourceDeploymentNotFound, radixhttp.UnexpectedError("Radix Application Auxiliary Resource", fmt.Errorf("deployment for auxiliary resource not found")))
 }
 
 // JobComponentCanOnlyBeRestarted Job component cannot be started or stopped, but only restarted
 func JobComponentCanOnlyBeRestarted() error {
-	return radixhttp.UnexpectedError("Radix Application Job Component", fmt.Errorf("job component can only be restarted"))
+	return problem.WithCode(CodeJobComponentCanOnlyBeRestarted, radixhttp.UnexpectedError("Radix Application Job Component", fmt.Errorf("job component can only be restarted")))
 }
 
 // CannotScaleComponent Component cannot be scaled
 func CannotScaleComponent(appName, envName, componentName, state string) error {
-	return radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s, environment %s cannot be scaled when in %s state", componentName, appName, envName, strings.ToLower(state)))
+	return problem.WithCode(CodeComponentNotScalable, radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s, environment %s cannot be scaled when in %s state", componentName, appName, envName, strings.ToLower(state))))
 }
 
 // CannotScaleComponentToNegativeReplicas Component cannot be scaled to negative replica amount
 func CannotScaleComponentToNegativeReplicas(appName, envName, componentName string) error {
-	return radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s, environment %s cannot be scaled to negative value", componentName, appName, envName))
+	return problem.WithCode(CodeNegativeReplicas, radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s, environment %s cannot be scaled to negative value", componentName, appName, envName)))
 }
 
 // CannotScaleComponentToMoreThanMaxReplicas Component cannot be scaled to more than max replicas
 func CannotScaleComponentToMoreThanMaxReplicas(appName, envName, componentName string, maxScaleReplicas int) error {
-	return radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s, environment %s cannot be scaled to more than %d replicas", componentName, appName, envName, maxScaleReplicas))
+	return problem.WithCode(CodeTooManyReplicas, radixhttp.ValidationError("Radix Application Component", fmt.Sprintf("Component %s for app %s, environment %s cannot be scaled to more than %d replicas", componentName, appName, envName, maxScaleReplicas)))
 }
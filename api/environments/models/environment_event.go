@@ -0,0 +1,73 @@
+package models
+
+import "time"
+
+// EnvironmentEventType the kind of change described by an EnvironmentEvent
+type EnvironmentEventType string
+
+const (
+	// DeploymentActivated a new deployment has become the active deployment in the environment
+	DeploymentActivated EnvironmentEventType = "DeploymentActivated"
+
+	// ComponentStatusChanged the status of a component in the active deployment has changed
+	ComponentStatusChanged EnvironmentEventType = "ComponentStatusChanged"
+
+	// ReplicaAdded a new replica has been created for a component
+	ReplicaAdded EnvironmentEventType = "ReplicaAdded"
+
+	// ReplicaStatusChanged the status of a replica has changed
+	ReplicaStatusChanged EnvironmentEventType = "ReplicaStatusChanged"
+
+	// ReplicaRemoved a replica has been deleted
+	ReplicaRemoved EnvironmentEventType = "ReplicaRemoved"
+
+	// BatchStatusChanged the status of a scheduled batch or single job has changed
+	BatchStatusChanged EnvironmentEventType = "BatchStatusChanged"
+)
+
+// EnvironmentEvent describes a change of state in an environment, sent by the environment watch event stream
+// swagger:model EnvironmentEvent
+type EnvironmentEvent struct {
+	// Type of change
+	//
+	// required: true
+	// enum: DeploymentActivated,ComponentStatusChanged,ReplicaAdded,ReplicaStatusChanged,ReplicaRemoved,BatchStatusChanged
+	// example: ComponentStatusChanged
+	Type EnvironmentEventType `json:"type"`
+
+	// Deployment name of the active deployment
+	//
+	// required: false
+	// example: radix-canary-golang-tzbqi
+	Deployment string `json:"deployment,omitempty"`
+
+	// Component name of the component the change applies to
+	//
+	// required: false
+	// example: server
+	Component string `json:"component,omitempty"`
+
+	// Replica name of the replica (pod) the change applies to
+	//
+	// required: false
+	// example: server-78fc8857c4-hm76l
+	Replica string `json:"replica,omitempty"`
+
+	// Batch name of the scheduled batch or single job the change applies to
+	//
+	// required: false
+	// example: batch-compute-20230220100755-xkoxce5
+	Batch string `json:"batch,omitempty"`
+
+	// Status the new status of the deployment, component, replica or batch
+	//
+	// required: false
+	// example: Consistent
+	Status string `json:"status,omitempty"`
+
+	// Timestamp when the change was observed
+	//
+	// required: true
+	// swagger:strfmt date-time
+	Timestamp time.Time `json:"timestamp"`
+}
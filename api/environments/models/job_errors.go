@@ -3,15 +3,22 @@ package models
 import (
 	"fmt"
 
+	"github.com/equinor/radix-api/api/utils/problem"
 	radixhttp "github.com/equinor/radix-common/net/http"
 )
 
+// Error codes of scheduled job errors
+const (
+	CodeScheduledJobPayloadNotFound problem.Code = "scheduled-job-payload-not-found"
+	CodeScheduledJobPayloadError    problem.Code = "scheduled-job-payload-error"
+)
+
 // ScheduledJobPayloadNotFoundError Payload for the scheduled job not found
 func ScheduledJobPayloadNotFoundError(appName, jobName string) error {
-	return radixhttp.TypeMissingError(fmt.Sprintf("payload not found for job %s in app %s", jobName, appName), nil)
+	return problem.WithCode(CodeScheduledJobPayloadNotFound, radixhttp.TypeMissingError(fmt.Sprintf("payload not found for job %s in app %s", jobName, appName), nil))
 }
 
 // ScheduledJobPayloadUnexpectedError Scheduled job has unexpected error
 func ScheduledJobPayloadUnexpectedError(message, appName, jobName string) error {
-	return radixhttp.UnexpectedError(fmt.Sprintf("error for job %s in app %s: %s", jobName, appName, message), nil)
+	return problem.WithCode(CodeScheduledJobPayloadError, radixhttp.UnexpectedError(fmt.Sprintf("error for job %s in app %s: %s", jobName, appName, message), nil))
 }
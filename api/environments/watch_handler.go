@@ -0,0 +1,286 @@
+package environments
+
+import (
+	"context"
+	"time"
+
+	deploymentModels "github.com/equinor/radix-api/api/deployments/models"
+	environmentModels "github.com/equinor/radix-api/api/environments/models"
+	"github.com/equinor/radix-api/api/utils"
+	"github.com/equinor/radix-api/api/utils/labelselector"
+	"github.com/equinor/radix-api/api/utils/predicate"
+	"github.com/equinor/radix-operator/pkg/apis/kube"
+	radixv1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	k8sObjectUtils "github.com/equinor/radix-operator/pkg/apis/utils"
+	"github.com/rs/zerolog/log"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// WatchEnvironment watches RadixDeployments, Deployments, Pods and RadixBatches in the environment,
+// and sends an EnvironmentEvent on the returned channel when the active deployment, or the status of a component, replica or batch changes.
+// The current state is sent as events when the watch starts.
+// The channel is closed when ctx is cancelled, or when one of the underlying watches is closed by the Kubernetes API. Clients should then reconnect.
+func (eh EnvironmentHandler) WatchEnvironment(ctx context.Context, appName, envName string) (<-chan environmentModels.EnvironmentEvent, error) {
+	if _, err := eh.cache.GetRadixEnvironment(ctx, eh.accounts.ServiceAccount.RadixClient, appName, envName); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, environmentModels.NonExistingEnvironment(err, appName, envName)
+		}
+		return nil, err
+	}
+
+	ns := k8sObjectUtils.GetEnvironmentNamespace(appName, envName)
+	appSelector := labelselector.ForApplication(appName).AsSelector()
+	noJobTypeLabel, err := labels.NewRequirement(kube.RadixJobTypeLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+	noBatchNameLabel, err := labels.NewRequirement(kube.RadixBatchNameLabel, selection.DoesNotExist, nil)
+	if err != nil {
+		return nil, err
+	}
+	componentPodSelector := appSelector.Add(*noJobTypeLabel, *noBatchNameLabel)
+
+	var watchers []watch.Interface
+	stopWatchers := func() {
+		for _, w := range watchers {
+			w.Stop()
+		}
+	}
+	startWatch := func(watchFunc func() (watch.Interface, error)) error {
+		w, err := watchFunc()
+		if err != nil {
+			stopWatchers()
+			return err
+		}
+		watchers = append(watchers, w)
+		return nil
+	}
+
+	if err := startWatch(func() (watch.Interface, error) {
+		return eh.accounts.UserAccount.RadixClient.RadixV1().RadixDeployments(ns).Watch(ctx, metav1.ListOptions{})
+	}); err != nil {
+		return nil, err
+	}
+	if err := startWatch(func() (watch.Interface, error) {
+		return eh.accounts.UserAccount.Client.AppsV1().Deployments(ns).Watch(ctx, metav1.ListOptions{LabelSelector: appSelector.String()})
+	}); err != nil {
+		return nil, err
+	}
+	if err := startWatch(func() (watch.Interface, error) {
+		return eh.accounts.UserAccount.Client.CoreV1().Pods(ns).Watch(ctx, metav1.ListOptions{LabelSelector: componentPodSelector.String()})
+	}); err != nil {
+		return nil, err
+	}
+	if err := startWatch(func() (watch.Interface, error) {
+		return eh.accounts.UserAccount.RadixClient.RadixV1().RadixBatches(ns).Watch(ctx, metav1.ListOptions{})
+	}); err != nil {
+		return nil, err
+	}
+
+	events := make(chan environmentModels.EnvironmentEvent)
+	go func() {
+		defer close(events)
+		defer stopWatchers()
+
+		state := newEnvironmentWatchState(eh.ComponentStatuser)
+		for {
+			var changes []environmentModels.EnvironmentEvent
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watchers[0].ResultChan():
+				if !ok {
+					return
+				}
+				if rd, isRd := event.Object.(*radixv1.RadixDeployment); isRd {
+					changes = state.onRadixDeployment(event.Type, rd)
+				}
+			case event, ok := <-watchers[1].ResultChan():
+				if !ok {
+					return
+				}
+				if kd, isKd := event.Object.(*appsv1.Deployment); isKd {
+					changes = state.onDeployment(event.Type, kd)
+				}
+			case event, ok := <-watchers[2].ResultChan():
+				if !ok {
+					return
+				}
+				if pod, isPod := event.Object.(*corev1.Pod); isPod {
+					changes = state.onPod(event.Type, pod)
+				}
+			case event, ok := <-watchers[3].ResultChan():
+				if !ok {
+					return
+				}
+				if batch, isBatch := event.Object.(*radixv1.RadixBatch); isBatch {
+					changes = state.onRadixBatch(event.Type, batch)
+				}
+			}
+
+			for _, change := range changes {
+				select {
+				case events <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	log.Ctx(ctx).Debug().Msgf("Watching environment %s in application %s", envName, appName)
+	return events, nil
+}
+
+// environmentWatchState keeps the last known state of the objects in an environment,
+// and translates watch events into EnvironmentEvents when the state changes
+type environmentWatchState struct {
+	componentStatuser deploymentModels.ComponentStatuserFunc
+	activeRd          *radixv1.RadixDeployment
+	deployments       map[string]*appsv1.Deployment
+	componentStatuses map[string]string
+	replicaStatuses   map[string]string
+	batchStatuses     map[string]string
+	now               func() time.Time
+}
+
+func newEnvironmentWatchState(componentStatuser deploymentModels.ComponentStatuserFunc) *environmentWatchState {
+	return &environmentWatchState{
+		componentStatuser: componentStatuser,
+		deployments:       map[string]*appsv1.Deployment{},
+		componentStatuses: map[string]string{},
+		replicaStatuses:   map[string]string{},
+		batchStatuses:     map[string]string{},
+		now:               time.Now,
+	}
+}
+
+func (s *environmentWatchState) onRadixDeployment(eventType watch.EventType, rd *radixv1.RadixDeployment) []environmentModels.EnvironmentEvent {
+	isCurrentActive := s.activeRd != nil && s.activeRd.Name == rd.Name
+	if eventType == watch.Deleted || !predicate.IsActiveRadixDeployment(*rd) {
+		if isCurrentActive {
+			s.activeRd = nil
+		}
+		return nil
+	}
+
+	var events []environmentModels.EnvironmentEvent
+	if !isCurrentActive {
+		events = append(events, s.newEvent(environmentModels.DeploymentActivated, func(e *environmentModels.EnvironmentEvent) {
+			e.Deployment = rd.Name
+		}))
+	}
+	s.activeRd = rd
+	for _, component := range s.activeComponents() {
+		events = append(events, s.updateComponentStatus(component)...)
+	}
+	return events
+}
+
+func (s *environmentWatchState) onDeployment(eventType watch.EventType, kd *appsv1.Deployment) []environmentModels.EnvironmentEvent {
+	componentName := kd.Labels[kube.RadixComponentLabel]
+	if componentName == "" {
+		return nil
+	}
+	if eventType == watch.Deleted {
+		delete(s.deployments, componentName)
+	} else {
+		s.deployments[componentName] = kd
+	}
+
+	for _, component := range s.activeComponents() {
+		if component.GetName() == componentName {
+			return s.updateComponentStatus(component)
+		}
+	}
+	return nil
+}
+
+func (s *environmentWatchState) onPod(eventType watch.EventType, pod *corev1.Pod) []environmentModels.EnvironmentEvent {
+	setReplica := func(e *environmentModels.EnvironmentEvent) {
+		e.Component = pod.Labels[kube.RadixComponentLabel]
+		e.Replica = pod.Name
+	}
+
+	if eventType == watch.Deleted {
+		if _, ok := s.replicaStatuses[pod.Name]; !ok {
+			return nil
+		}
+		delete(s.replicaStatuses, pod.Name)
+		return []environmentModels.EnvironmentEvent{s.newEvent(environmentModels.ReplicaRemoved, setReplica)}
+	}
+
+	status := string(deploymentModels.GetReplicaSummary(*pod, "").Status.Status)
+	previousStatus, exists := s.replicaStatuses[pod.Name]
+	s.replicaStatuses[pod.Name] = status
+	setStatus := func(e *environmentModels.EnvironmentEvent) {
+		setReplica(e)
+		e.Status = status
+	}
+	switch {
+	case !exists:
+		return []environmentModels.EnvironmentEvent{s.newEvent(environmentModels.ReplicaAdded, setStatus)}
+	case previousStatus != status:
+		return []environmentModels.EnvironmentEvent{s.newEvent(environmentModels.ReplicaStatusChanged, setStatus)}
+	}
+	return nil
+}
+
+func (s *environmentWatchState) onRadixBatch(eventType watch.EventType, batch *radixv1.RadixBatch) []environmentModels.EnvironmentEvent {
+	if eventType == watch.Deleted {
+		delete(s.batchStatuses, batch.Name)
+		return nil
+	}
+
+	status := string(utils.GetBatchJobStatusByJobApiCondition(batch.Status.Condition.Type))
+	if previousStatus, exists := s.batchStatuses[batch.Name]; exists && previousStatus == status {
+		return nil
+	}
+	s.batchStatuses[batch.Name] = status
+	return []environmentModels.EnvironmentEvent{s.newEvent(environmentModels.BatchStatusChanged, func(e *environmentModels.EnvironmentEvent) {
+		e.Component = batch.Spec.RadixDeploymentJobRef.Job
+		e.Batch = batch.Name
+		e.Status = status
+	})}
+}
+
+func (s *environmentWatchState) activeComponents() []radixv1.RadixCommonDeployComponent {
+	if s.activeRd == nil {
+		return nil
+	}
+	var components []radixv1.RadixCommonDeployComponent
+	for i := range s.activeRd.Spec.Components {
+		components = append(components, &s.activeRd.Spec.Components[i])
+	}
+	for i := range s.activeRd.Spec.Jobs {
+		components = append(components, &s.activeRd.Spec.Jobs[i])
+	}
+	return components
+}
+
+func (s *environmentWatchState) updateComponentStatus(component radixv1.RadixCommonDeployComponent) []environmentModels.EnvironmentEvent {
+	status := s.componentStatuser(component, s.deployments[component.GetName()], s.activeRd).String()
+	if previousStatus, exists := s.componentStatuses[component.GetName()]; exists && previousStatus == status {
+		return nil
+	}
+	s.componentStatuses[component.GetName()] = status
+	return []environmentModels.EnvironmentEvent{s.newEvent(environmentModels.ComponentStatusChanged, func(e *environmentModels.EnvironmentEvent) {
+		e.Deployment = s.activeRd.Name
+		e.Component = component.GetName()
+		e.Status = status
+	})}
+}
+
+func (s *environmentWatchState) newEvent(eventType environmentModels.EnvironmentEventType, setters ...func(*environmentModels.EnvironmentEvent)) environmentModels.EnvironmentEvent {
+	event := environmentModels.EnvironmentEvent{Type: eventType, Timestamp: s.now()}
+	for _, set := range setters {
+		set(&event)
+	}
+	return event
+}
@@ -0,0 +1,115 @@
+package environments
+
+import (
+	"testing"
+	"time"
+
+	deploymentModels "github.com/equinor/radix-api/api/deployments/models"
+	environmentModels "github.com/equinor/radix-api/api/environments/models"
+	"github.com/equinor/radix-operator/pkg/apis/kube"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func Test_EnvironmentWatchState_DeploymentAndComponentStatus(t *testing.T) {
+	componentStatus := deploymentModels.ConsistentComponent
+	state := newEnvironmentWatchState(func(v1.RadixCommonDeployComponent, *appsv1.Deployment, *v1.RadixDeployment) deploymentModels.ComponentStatus {
+		return componentStatus
+	})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	state.now = func() time.Time { return now }
+
+	rd := &v1.RadixDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "rd-1"},
+		Spec: v1.RadixDeploymentSpec{
+			Components: []v1.RadixDeployComponent{{Name: "web"}},
+			Jobs:       []v1.RadixDeployJobComponent{{Name: "compute"}},
+		},
+		Status: v1.RadixDeployStatus{Condition: v1.DeploymentActive},
+	}
+	events := state.onRadixDeployment(watch.Added, rd)
+	assert.Equal(t, []environmentModels.EnvironmentEvent{
+		{Type: environmentModels.DeploymentActivated, Deployment: "rd-1", Timestamp: now},
+		{Type: environmentModels.ComponentStatusChanged, Deployment: "rd-1", Component: "web", Status: "Consistent", Timestamp: now},
+		{Type: environmentModels.ComponentStatusChanged, Deployment: "rd-1", Component: "compute", Status: "Consistent", Timestamp: now},
+	}, events)
+
+	kd := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Labels: map[string]string{kube.RadixComponentLabel: "web"}}}
+	assert.Empty(t, state.onDeployment(watch.Modified, kd), "unchanged status must not emit events")
+
+	componentStatus = deploymentModels.ComponentReconciling
+	events = state.onDeployment(watch.Modified, kd)
+	assert.Equal(t, []environmentModels.EnvironmentEvent{
+		{Type: environmentModels.ComponentStatusChanged, Deployment: "rd-1", Component: "web", Status: "Reconciling", Timestamp: now},
+	}, events)
+
+	inactiveRd := rd.DeepCopy()
+	inactiveRd.Name = "rd-0"
+	inactiveRd.Status.Condition = v1.DeploymentInactive
+	assert.Empty(t, state.onRadixDeployment(watch.Modified, inactiveRd))
+
+	nextRd := rd.DeepCopy()
+	nextRd.Name = "rd-2"
+	nextRd.Spec.Jobs = nil
+	events = state.onRadixDeployment(watch.Added, nextRd)
+	require.Len(t, events, 1)
+	assert.Equal(t, environmentModels.DeploymentActivated, events[0].Type)
+	assert.Equal(t, "rd-2", events[0].Deployment)
+}
+
+func Test_EnvironmentWatchState_Replicas(t *testing.T) {
+	state := newEnvironmentWatchState(deploymentModels.ComponentStatusFromDeployment)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Labels: map[string]string{kube.RadixComponentLabel: "web"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "web"}}},
+	}
+	events := state.onPod(watch.Added, pod)
+	require.Len(t, events, 1)
+	assert.Equal(t, environmentModels.ReplicaAdded, events[0].Type)
+	assert.Equal(t, "web", events[0].Component)
+	assert.Equal(t, "web-abc", events[0].Replica)
+	assert.Equal(t, string(deploymentModels.Pending), events[0].Status)
+
+	assert.Empty(t, state.onPod(watch.Modified, pod), "unchanged status must not emit events")
+
+	runningPod := pod.DeepCopy()
+	runningPod.Status.ContainerStatuses = []corev1.ContainerStatus{{Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}}
+	events = state.onPod(watch.Modified, runningPod)
+	require.Len(t, events, 1)
+	assert.Equal(t, environmentModels.ReplicaStatusChanged, events[0].Type)
+	assert.Equal(t, string(deploymentModels.Running), events[0].Status)
+
+	events = state.onPod(watch.Deleted, runningPod)
+	require.Len(t, events, 1)
+	assert.Equal(t, environmentModels.ReplicaRemoved, events[0].Type)
+	assert.Empty(t, state.onPod(watch.Deleted, runningPod), "already removed replica must not emit events")
+}
+
+func Test_EnvironmentWatchState_Batches(t *testing.T) {
+	state := newEnvironmentWatchState(deploymentModels.ComponentStatusFromDeployment)
+
+	batch := &v1.RadixBatch{
+		ObjectMeta: metav1.ObjectMeta{Name: "batch-1"},
+		Spec:       v1.RadixBatchSpec{RadixDeploymentJobRef: v1.RadixDeploymentJobComponentSelector{Job: "compute"}},
+		Status:     v1.RadixBatchStatus{Condition: v1.RadixBatchCondition{Type: v1.BatchConditionTypeActive}},
+	}
+	events := state.onRadixBatch(watch.Added, batch)
+	require.Len(t, events, 1)
+	assert.Equal(t, environmentModels.BatchStatusChanged, events[0].Type)
+	assert.Equal(t, "compute", events[0].Component)
+	assert.Equal(t, "batch-1", events[0].Batch)
+
+	assert.Empty(t, state.onRadixBatch(watch.Modified, batch))
+
+	completedBatch := batch.DeepCopy()
+	completedBatch.Status.Condition.Type = v1.BatchConditionTypeCompleted
+	events = state.onRadixBatch(watch.Modified, completedBatch)
+	require.Len(t, events, 1)
+	assert.NotEqual(t, events[0].Status, state.onRadixBatch(watch.Added, batch)[0].Status)
+}
@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"github.com/equinor/radix-api/api/deployments"
+	jobModels "github.com/equinor/radix-api/api/jobs/models"
 	"github.com/equinor/radix-api/api/utils/logs"
+	"github.com/equinor/radix-api/api/utils/pagination"
+	"github.com/equinor/radix-api/api/utils/token"
 	"github.com/equinor/radix-api/models"
 	"github.com/gorilla/mux"
 )
@@ -29,11 +32,19 @@ func (jc *jobController) GetRoutes() models.Routes {
 			Path:        rootPath + "/jobs",
 			Method:      "GET",
 			HandlerFunc: jc.GetApplicationJobs,
+			TokenScope:  token.ScopeRead,
+		},
+		models.Route{
+			Path:        rootPath + "/jobs/_page",
+			Method:      "GET",
+			HandlerFunc: jc.GetApplicationJobsPage,
+			TokenScope:  token.ScopeRead,
 		},
 		models.Route{
 			Path:        rootPath + "/jobs/{jobName}",
 			Method:      "GET",
 			HandlerFunc: jc.GetApplicationJob,
+			TokenScope:  token.ScopeRead,
 		},
 		models.Route{
 			Path:        rootPath + "/jobs/{jobName}/stop",
@@ -84,6 +95,7 @@ func (jc *jobController) GetRoutes() models.Routes {
 			Path:        rootPath + "/jobs/{jobName}/logs/{stepName}",
 			Method:      "GET",
 			HandlerFunc: jc.GetPipelineJobStepLogs,
+			TokenScope:  token.ScopeRead,
 		},
 	}
 
@@ -135,6 +147,72 @@ func (jc *jobController) GetApplicationJobs(accounts models.Accounts, w http.Res
 	jc.JSONResponse(w, r, jobSummaries)
 }
 
+// GetApplicationJobsPage Lists a page of the pipeline-job summaries
+func (jc *jobController) GetApplicationJobsPage(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/jobs/_page pipeline-job getApplicationJobsPage
+	// ---
+	// summary: Gets the summary of jobs for a given application, one page at a time
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: limit
+	//   in: query
+	//   description: Maximum number of jobs in the page. Defaults to 500
+	//   type: integer
+	//   required: false
+	// - name: continue
+	//   in: query
+	//   description: The next value from the previous page, to get the following page of jobs
+	//   type: string
+	//   required: false
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//        "$ref": "#/definitions/JobSummaryPage"
+	//   "400":
+	//     description: "Invalid limit or continue"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	pageParams, err := pagination.GetParams(r)
+	if err != nil {
+		jc.ErrorResponse(w, r, err)
+		return
+	}
+
+	handler := Init(accounts, deployments.Init(accounts))
+	jobSummaries, err := handler.GetApplicationJobs(r.Context(), appName)
+
+	if err != nil {
+		jc.ErrorResponse(w, r, err)
+		return
+	}
+
+	response, err := pagination.Paginate(jobSummaries, pageParams, func(job *jobModels.JobSummary) string { return job.Name })
+	if err != nil {
+		jc.ErrorResponse(w, r, err)
+		return
+	}
+
+	jc.JSONResponse(w, r, response)
+}
+
 // GetApplicationJob gets specific pipeline-job details
 func (jc *jobController) GetApplicationJob(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
 	// swagger:operation GET /applications/{appName}/jobs/{jobName} pipeline-job getApplicationJob
@@ -11,6 +11,7 @@ import (
 	"github.com/equinor/radix-api/api/jobs"
 	jobmodels "github.com/equinor/radix-api/api/jobs/models"
 	controllertest "github.com/equinor/radix-api/api/test"
+	"github.com/equinor/radix-api/api/utils/pagination"
 	authnmock "github.com/equinor/radix-api/api/utils/token/mock"
 	"github.com/equinor/radix-operator/pkg/apis/git"
 	"github.com/equinor/radix-operator/pkg/apis/pipeline"
@@ -226,6 +227,47 @@ func TestGetApplicationJob_RadixJobSpecExists(t *testing.T) {
 
 }
 
+func TestGetApplicationJobs_Paged(t *testing.T) {
+	commonTestUtils, controllerTestUtils, _, _, _, _, _ := setupTest(t)
+	for _, jobName := range []string{"job-1", "job-2", "job-3"} {
+		_, err := commonTestUtils.ApplyJob(builders.AStartedBuildDeployJob().WithAppName(anyAppName).WithJobName(jobName))
+		require.NoError(t, err)
+	}
+
+	var actualJobNames []string
+	next := ""
+	for range 3 {
+		responseChannel := controllerTestUtils.ExecuteRequest("GET", fmt.Sprintf("/api/v1/applications/%s/jobs/_page?limit=2&continue=%s", anyAppName, next))
+		response := <-responseChannel
+		require.Equal(t, http.StatusOK, response.Code)
+
+		page := pagination.Page[jobmodels.JobSummary]{}
+		err := controllertest.GetResponseBody(response, &page)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(page.Items), 2)
+		for _, job := range page.Items {
+			actualJobNames = append(actualJobNames, job.Name)
+		}
+		next = page.Next
+		if next == "" {
+			break
+		}
+	}
+	assert.Empty(t, next)
+	assert.ElementsMatch(t, []string{"job-1", "job-2", "job-3"}, actualJobNames)
+
+	responseChannel := controllerTestUtils.ExecuteRequest("GET", fmt.Sprintf("/api/v1/applications/%s/jobs/_page?limit=0", anyAppName))
+	response := <-responseChannel
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+
+	responseChannel = controllerTestUtils.ExecuteRequest("GET", fmt.Sprintf("/api/v1/applications/%s/jobs?limit=2", anyAppName))
+	response = <-responseChannel
+	require.Equal(t, http.StatusOK, response.Code)
+	var jobs []jobmodels.JobSummary
+	require.NoError(t, controllertest.GetResponseBody(response, &jobs))
+	assert.Len(t, jobs, 3, "the list of jobs should not be paged")
+}
+
 func TestGetPipelineJobLogsError(t *testing.T) {
 	commonTestUtils, controllerTestUtils, _, _, _, _, _ := setupTest(t)
 
@@ -3,6 +3,7 @@ package jobs
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -58,9 +59,9 @@ func (jh JobHandler) GetApplicationJobs(ctx context.Context, appName string) ([]
 		return nil, err
 	}
 
-	// Sort jobs descending
-	sort.Slice(jobs, func(i, j int) bool {
-		return utils.IsBefore(jobs[j], jobs[i])
+	// Sort jobs descending, with the name as tie-breaker since it is the key of the page cursor
+	slices.SortStableFunc(jobs, func(a, b *jobModels.JobSummary) int {
+		return utils.CompareNewestFirst(a, b, a.Name, b.Name)
 	})
 
 	return jobs, nil
@@ -3,31 +3,41 @@ package models
 import (
 	"fmt"
 
+	"github.com/equinor/radix-api/api/utils/problem"
 	radixhttp "github.com/equinor/radix-common/net/http"
 	radixv1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
 )
 
+// Error codes of pipeline job errors
+const (
+	CodePipelineJobNotFound        problem.Code = "pipeline-job-not-found"
+	CodePipelineJobStepNotFound    problem.Code = "pipeline-job-step-not-found"
+	CodePipelineJobNotRerunnable   problem.Code = "pipeline-job-not-rerunnable"
+	CodePipelineJobAlreadyStopping problem.Code = "pipeline-job-already-stopping"
+	CodePipelineJobNotStoppable    problem.Code = "pipeline-job-not-stoppable"
+)
+
 // PipelineNotFoundError Pipeline job not found
 func PipelineNotFoundError(appName, jobName string) error {
-	return radixhttp.TypeMissingError(fmt.Sprintf("job %s not found for the app %s", jobName, appName), nil)
+	return problem.WithCode(CodePipelineJobNotFound, radixhttp.TypeMissingError(fmt.Sprintf("job %s not found for the app %s", jobName, appName), nil))
 }
 
 // PipelineStepNotFoundError Pipeline job step not found
 func PipelineStepNotFoundError(appName, jobName, stepName string) error {
-	return radixhttp.TypeMissingError(fmt.Sprintf("step %s for the job %s not found for the app %s", stepName, jobName, appName), nil)
+	return problem.WithCode(CodePipelineJobStepNotFound, radixhttp.TypeMissingError(fmt.Sprintf("step %s for the job %s not found for the app %s", stepName, jobName, appName), nil))
 }
 
 // JobHasInvalidConditionToRerunError Pipeline job cannot be rerun due to invalid condition
 func JobHasInvalidConditionToRerunError(appName, jobName string, jobCondition radixv1.RadixJobCondition) error {
-	return radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("only pipeline jobs that have the status Failed or Stopped can be rerun, but the job %s for the app %s has status %s", appName, jobName, jobCondition))
+	return problem.WithCode(CodePipelineJobNotRerunnable, radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("only pipeline jobs that have the status Failed or Stopped can be rerun, but the job %s for the app %s has status %s", appName, jobName, jobCondition)))
 }
 
 // JobAlreadyRequestedToStopError Pipeline job was already requested to stop
 func JobAlreadyRequestedToStopError(appName, jobName string) error {
-	return radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("job %s for the app %s has already been requested to be stopped", appName, jobName))
+	return problem.WithCode(CodePipelineJobAlreadyStopping, radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("job %s for the app %s has already been requested to be stopped", appName, jobName)))
 }
 
 // JobHasInvalidConditionToStopError Pipeline job cannot be stopped due to invalid condition
 func JobHasInvalidConditionToStopError(appName, jobName string, jobCondition radixv1.RadixJobCondition) error {
-	return radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("only pipeline jobs that doesn't have the status Failed or Stopped can be stopped, but the job %s for the app %s has status %s", appName, jobName, jobCondition))
+	return problem.WithCode(CodePipelineJobNotStoppable, radixhttp.ValidationError("Radix Application Pipeline", fmt.Sprintf("only pipeline jobs that doesn't have the status Failed or Stopped can be stopped, but the job %s for the app %s has status %s", appName, jobName, jobCondition)))
 }
@@ -246,3 +246,18 @@ func (job *JobSummary) GetEnded() *time.Time {
 func (job *JobSummary) GetStatus() string {
 	return job.Status
 }
+
+// JobSummaryPage is a page of jobs
+// swagger:model JobSummaryPage
+type JobSummaryPage struct {
+	// Items in the page
+	//
+	// required: true
+	Items []JobSummary `json:"items"`
+
+	// Next is the value of the continue query parameter to get the next page. Omitted when there are no more jobs
+	//
+	// required: false
+	// example: cmFkaXgtcGlwZWxpbmUtMjAxODEwMjkxMzU2NDQtYWxncHYtNmh6bmg
+	Next string `json:"next,omitempty"`
+}
@@ -0,0 +1,268 @@
+package kubequery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/equinor/radix-api/api/utils/labelselector"
+	radixv1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	operatorUtils "github.com/equinor/radix-operator/pkg/apis/utils"
+	radixclient "github.com/equinor/radix-operator/pkg/client/clientset/versioned"
+	radixinformers "github.com/equinor/radix-operator/pkg/client/informers/externalversions"
+	radixlisters "github.com/equinor/radix-operator/pkg/client/listers/radix/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	cacheResourceRadixRegistrations = "radixregistrations"
+	cacheResourceRadixJobs          = "radixjobs"
+	cacheResourceRadixEnvironments  = "radixenvironments"
+	cacheResourceRadixDeployments   = "radixdeployments"
+
+	lastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+var (
+	cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "radix_api_kube_cache_requests_total",
+		Help: "The total number of reads through the Kubernetes cache, by resource and result (hit or miss)",
+	}, []string{"resource", "result"})
+	cacheLastEvent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "radix_api_kube_cache_last_event_timestamp_seconds",
+		Help: "Unix time of the last add, update, delete or resync event received by the Kubernetes cache, by resource. Staleness is time() minus this value",
+	}, []string{"resource"})
+	cacheSynced = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "radix_api_kube_cache_synced",
+		Help: "Whether the informer of the Kubernetes cache has completed the initial sync (1) or reads fall back to the Kubernetes API (0), by resource",
+	}, []string{"resource"})
+	cacheAddEventLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "radix_api_kube_cache_add_event_lag_seconds",
+		Help:    "Time from a resource is created until the Kubernetes cache receives it, by resource. The resolution is one second",
+		Buckets: []float64{1, 2, 5, 10, 30, 60, 300},
+	}, []string{"resource"})
+)
+
+// Cache is a cache of RadixRegistrations, RadixJobs, RadixEnvironments and RadixDeployments backed by shared informers.
+// It must only be used for reads with the service account, since the permissions of the user are not checked.
+// Reads of a resource fall back to the client until its informer is synced. A nil Cache always reads from the client.
+type Cache struct {
+	factory   radixinformers.SharedInformerFactory
+	informers map[string]cache.SharedIndexInformer
+	rrLister  radixlisters.RadixRegistrationLister
+	rjLister  radixlisters.RadixJobLister
+	reLister  radixlisters.RadixEnvironmentLister
+	rdLister  radixlisters.RadixDeploymentLister
+	synced    chan struct{}
+}
+
+// NewCache creates a Cache for the service account client. The informers are resynced every resyncPeriod.
+// Managed fields and the last applied configuration are removed from cached objects to reduce memory use.
+// Call Start to start the informers.
+func NewCache(client radixclient.Interface, resyncPeriod time.Duration) (*Cache, error) {
+	factory := radixinformers.NewSharedInformerFactory(client, resyncPeriod)
+	radixV1 := factory.Radix().V1()
+	informers := map[string]cache.SharedIndexInformer{
+		cacheResourceRadixRegistrations: radixV1.RadixRegistrations().Informer(),
+		cacheResourceRadixJobs:          radixV1.RadixJobs().Informer(),
+		cacheResourceRadixEnvironments:  radixV1.RadixEnvironments().Informer(),
+		cacheResourceRadixDeployments:   radixV1.RadixDeployments().Informer(),
+	}
+	for resource, informer := range informers {
+		if err := informer.SetTransform(trimObject); err != nil {
+			return nil, fmt.Errorf("failed to set transform for %s: %w", resource, err)
+		}
+		if _, err := informer.AddEventHandler(newEventRecorder(resource)); err != nil {
+			return nil, fmt.Errorf("failed to add event handler for %s: %w", resource, err)
+		}
+		cacheSynced.WithLabelValues(resource).Set(0)
+	}
+
+	return &Cache{
+		factory:   factory,
+		informers: informers,
+		rrLister:  radixV1.RadixRegistrations().Lister(),
+		rjLister:  radixV1.RadixJobs().Lister(),
+		reLister:  radixV1.RadixEnvironments().Lister(),
+		rdLister:  radixV1.RadixDeployments().Lister(),
+		synced:    make(chan struct{}),
+	}, nil
+}
+
+// Start starts the informers, and blocks until all informers are synced or ctx is done.
+// Each resource is read from the cache as soon as its informer is synced. The informers are stopped when ctx is done.
+func (c *Cache) Start(ctx context.Context) error {
+	c.factory.Start(ctx.Done())
+	for resource, informer := range c.informers {
+		if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+			return fmt.Errorf("failed to sync cache for %s", resource)
+		}
+		cacheSynced.WithLabelValues(resource).Set(1)
+	}
+	close(c.synced)
+	return nil
+}
+
+// HasSynced returns true when all informers of the cache have completed the initial sync
+func (c *Cache) HasSynced() bool {
+	if c == nil {
+		return false
+	}
+	select {
+	case <-c.synced:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetRadixRegistrations returns all RadixRegistrations.
+func (c *Cache) GetRadixRegistrations(ctx context.Context, client radixclient.Interface) ([]radixv1.RadixRegistration, error) {
+	if !c.useCache(cacheResourceRadixRegistrations) {
+		rrList, err := client.RadixV1().RadixRegistrations().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return rrList.Items, nil
+	}
+	rrs, err := c.rrLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return copyObjects(rrs, (*radixv1.RadixRegistration).DeepCopy), nil
+}
+
+// GetRadixRegistration returns the RadixRegistration for the specified application name.
+func (c *Cache) GetRadixRegistration(ctx context.Context, client radixclient.Interface, appName string) (*radixv1.RadixRegistration, error) {
+	if !c.useCache(cacheResourceRadixRegistrations) {
+		return GetRadixRegistration(ctx, client, appName)
+	}
+	rr, err := c.rrLister.Get(appName)
+	if err != nil {
+		return nil, err
+	}
+	return rr.DeepCopy(), nil
+}
+
+// GetRadixJobs returns all RadixJobs for the specified application.
+func (c *Cache) GetRadixJobs(ctx context.Context, client radixclient.Interface, appName string) ([]radixv1.RadixJob, error) {
+	if !c.useCache(cacheResourceRadixJobs) {
+		return GetRadixJobs(ctx, client, appName)
+	}
+	rjs, err := c.rjLister.RadixJobs(operatorUtils.GetAppNamespace(appName)).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return copyObjects(rjs, (*radixv1.RadixJob).DeepCopy), nil
+}
+
+// GetRadixEnvironments returns all RadixEnvironments for the specified application.
+func (c *Cache) GetRadixEnvironments(ctx context.Context, client radixclient.Interface, appName string) ([]radixv1.RadixEnvironment, error) {
+	if !c.useCache(cacheResourceRadixEnvironments) {
+		return GetRadixEnvironments(ctx, client, appName)
+	}
+	res, err := c.reLister.List(labelselector.ForApplication(appName).AsSelector())
+	if err != nil {
+		return nil, err
+	}
+	return copyObjects(res, (*radixv1.RadixEnvironment).DeepCopy), nil
+}
+
+// GetRadixEnvironment returns the RadixEnvironment for the specified application and environment.
+func (c *Cache) GetRadixEnvironment(ctx context.Context, client radixclient.Interface, appName, envName string) (*radixv1.RadixEnvironment, error) {
+	if !c.useCache(cacheResourceRadixEnvironments) {
+		return GetRadixEnvironment(ctx, client, appName, envName)
+	}
+	re, err := c.reLister.Get(operatorUtils.GetEnvironmentNamespace(appName, envName))
+	if err != nil {
+		return nil, err
+	}
+	return re.DeepCopy(), nil
+}
+
+// GetRadixDeploymentsForEnvironment returns all RadixDeployments for the specified application and environment.
+func (c *Cache) GetRadixDeploymentsForEnvironment(ctx context.Context, client radixclient.Interface, appName, envName string) ([]radixv1.RadixDeployment, error) {
+	if !c.useCache(cacheResourceRadixDeployments) {
+		return GetRadixDeploymentsForEnvironment(ctx, client, appName, envName)
+	}
+	rds, err := c.rdLister.RadixDeployments(operatorUtils.GetEnvironmentNamespace(appName, envName)).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	return copyObjects(rds, (*radixv1.RadixDeployment).DeepCopy), nil
+}
+
+// GetRadixDeploymentsForEnvironments returns all RadixDeployments for the specified application and environments.
+func (c *Cache) GetRadixDeploymentsForEnvironments(ctx context.Context, client radixclient.Interface, appName string, envNames []string) ([]radixv1.RadixDeployment, error) {
+	if !c.useCache(cacheResourceRadixDeployments) {
+		return GetRadixDeploymentsForEnvironments(ctx, client, appName, envNames, 10)
+	}
+	var rdList []radixv1.RadixDeployment
+	for _, envName := range envNames {
+		rds, err := c.rdLister.RadixDeployments(operatorUtils.GetEnvironmentNamespace(appName, envName)).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		rdList = append(rdList, copyObjects(rds, (*radixv1.RadixDeployment).DeepCopy)...)
+	}
+	return rdList, nil
+}
+
+func (c *Cache) useCache(resource string) bool {
+	if c == nil {
+		return false
+	}
+	result := "miss"
+	synced := c.informers[resource].HasSynced()
+	if synced {
+		result = "hit"
+	}
+	cacheRequests.WithLabelValues(resource, result).Inc()
+	return synced
+}
+
+// copyObjects returns copies of the objects, since objects in the informer store must not be modified
+func copyObjects[T any](objects []*T, deepCopy func(*T) *T) []T {
+	copies := make([]T, 0, len(objects))
+	for _, obj := range objects {
+		copies = append(copies, *deepCopy(obj))
+	}
+	return copies
+}
+
+// trimObject removes fields that are not read by the API from objects before they are stored in the informer
+func trimObject(obj any) (any, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		// Tombstones of deleted objects are passed through unchanged
+		return obj, nil
+	}
+	accessor.SetManagedFields(nil)
+	if annotations := accessor.GetAnnotations(); annotations != nil {
+		delete(annotations, lastAppliedConfigAnnotation)
+	}
+	return obj, nil
+}
+
+// newEventRecorder records the time of each event, and the lag of objects created after the initial sync
+func newEventRecorder(resource string) cache.ResourceEventHandler {
+	record := func() { cacheLastEvent.WithLabelValues(resource).Set(float64(time.Now().Unix())) }
+	return cache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(obj any, isInInitialList bool) {
+			record()
+			if isInInitialList {
+				return
+			}
+			if accessor, err := meta.Accessor(obj); err == nil {
+				cacheAddEventLag.WithLabelValues(resource).Observe(time.Since(accessor.GetCreationTimestamp().Time).Seconds())
+			}
+		},
+		UpdateFunc: func(any, any) { record() },
+		DeleteFunc: func(any) { record() },
+	}
+}
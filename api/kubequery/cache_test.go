@@ -0,0 +1,117 @@
+package kubequery
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	radixv1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	"github.com/equinor/radix-operator/pkg/apis/utils/labels"
+	radixfake "github.com/equinor/radix-operator/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_Cache_ReadsFromInformers(t *testing.T) {
+	rr := radixv1.RadixRegistration{ObjectMeta: metav1.ObjectMeta{Name: "app1"}}
+	rj := radixv1.RadixJob{ObjectMeta: metav1.ObjectMeta{Name: "job1", Namespace: "app1-app"}}
+	re := radixv1.RadixEnvironment{ObjectMeta: metav1.ObjectMeta{Name: "app1-env1", Labels: labels.ForApplicationName("app1")}}
+	otherRe := radixv1.RadixEnvironment{ObjectMeta: metav1.ObjectMeta{Name: "app2-env1", Labels: labels.ForApplicationName("app2")}}
+	rd := radixv1.RadixDeployment{ObjectMeta: metav1.ObjectMeta{Name: "rd1", Namespace: "app1-env1"}}
+	client := radixfake.NewSimpleClientset(&rr, &rj, &re, &otherRe, &rd) //nolint:staticcheck
+
+	cache, err := NewCache(client, time.Hour)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, cache.Start(ctx))
+	assert.True(t, cache.HasSynced())
+
+	rrs, err := cache.GetRadixRegistrations(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []radixv1.RadixRegistration{rr}, rrs)
+
+	actualRr, err := cache.GetRadixRegistration(ctx, nil, "app1")
+	require.NoError(t, err)
+	assert.Equal(t, &rr, actualRr)
+	_, err = cache.GetRadixRegistration(ctx, nil, "any-app")
+	assert.True(t, errors.IsNotFound(err))
+
+	rjs, err := cache.GetRadixJobs(ctx, nil, "app1")
+	require.NoError(t, err)
+	assert.Equal(t, []radixv1.RadixJob{rj}, rjs)
+
+	res, err := cache.GetRadixEnvironments(ctx, nil, "app1")
+	require.NoError(t, err)
+	assert.Equal(t, []radixv1.RadixEnvironment{re}, res)
+
+	actualRe, err := cache.GetRadixEnvironment(ctx, nil, "app1", "env1")
+	require.NoError(t, err)
+	assert.Equal(t, &re, actualRe)
+
+	rds, err := cache.GetRadixDeploymentsForEnvironment(ctx, nil, "app1", "env1")
+	require.NoError(t, err)
+	assert.Equal(t, []radixv1.RadixDeployment{rd}, rds)
+
+	rds, err = cache.GetRadixDeploymentsForEnvironments(ctx, nil, "app1", []string{"env1", "env2"})
+	require.NoError(t, err)
+	assert.Equal(t, []radixv1.RadixDeployment{rd}, rds)
+}
+
+func Test_Cache_TrimsObjects(t *testing.T) {
+	rd := radixv1.RadixDeployment{ObjectMeta: metav1.ObjectMeta{
+		Name:          "rd1",
+		Namespace:     "app1-env1",
+		Annotations:   map[string]string{lastAppliedConfigAnnotation: "{}", "any-annotation": "any-value"},
+		ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "any-manager"}},
+	}}
+	client := radixfake.NewSimpleClientset(&rd) //nolint:staticcheck
+	cache, err := NewCache(client, time.Hour)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, cache.Start(ctx))
+
+	rds, err := cache.GetRadixDeploymentsForEnvironment(ctx, nil, "app1", "env1")
+	require.NoError(t, err)
+	require.Len(t, rds, 1)
+	assert.Empty(t, rds[0].ManagedFields)
+	assert.Equal(t, map[string]string{"any-annotation": "any-value"}, rds[0].Annotations)
+}
+
+func Test_Cache_ReturnsCopies(t *testing.T) {
+	rr := radixv1.RadixRegistration{ObjectMeta: metav1.ObjectMeta{Name: "app1"}}
+	client := radixfake.NewSimpleClientset(&rr) //nolint:staticcheck
+	cache, err := NewCache(client, time.Hour)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, cache.Start(ctx))
+
+	actual, err := cache.GetRadixRegistration(ctx, nil, "app1")
+	require.NoError(t, err)
+	actual.Spec.Owner = "modified"
+
+	actual, err = cache.GetRadixRegistration(ctx, nil, "app1")
+	require.NoError(t, err)
+	assert.Empty(t, actual.Spec.Owner)
+}
+
+func Test_Cache_FallbackToClient(t *testing.T) {
+	rr := radixv1.RadixRegistration{ObjectMeta: metav1.ObjectMeta{Name: "app1"}}
+	client := radixfake.NewSimpleClientset(&rr) //nolint:staticcheck
+
+	var nilCache *Cache
+	rrs, err := nilCache.GetRadixRegistrations(context.Background(), client)
+	require.NoError(t, err)
+	assert.Equal(t, []radixv1.RadixRegistration{rr}, rrs)
+
+	notStarted, err := NewCache(client, time.Hour)
+	require.NoError(t, err)
+	assert.False(t, notStarted.HasSynced())
+	actual, err := notStarted.GetRadixRegistration(context.Background(), client, "app1")
+	require.NoError(t, err)
+	assert.Equal(t, &rr, actual)
+}
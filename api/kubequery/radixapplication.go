@@ -19,11 +19,21 @@ func GetRadixApplication(ctx context.Context, client radixclient.Interface, appN
 }
 
 func IsRadixApplicationAdmin(ctx context.Context, kubeClient kubernetes.Interface, appName string) (bool, error) {
-	return access.HasAccess(ctx, kubeClient, &authorizationapi.ResourceAttributes{
+	return access.HasAccess(ctx, kubeClient, radixApplicationAdminAttributes(appName))
+}
+
+// IsUserRadixApplicationAdmin returns true when the user, with the groups, is administrator of the application.
+// The kubeClient must be allowed to create SubjectAccessReviews
+func IsUserRadixApplicationAdmin(ctx context.Context, kubeClient kubernetes.Interface, user string, groups []string, appName string) (bool, error) {
+	return access.HasSubjectAccess(ctx, kubeClient, user, groups, radixApplicationAdminAttributes(appName))
+}
+
+func radixApplicationAdminAttributes(appName string) *authorizationapi.ResourceAttributes {
+	return &authorizationapi.ResourceAttributes{
 		Verb:     "patch",
 		Group:    radixv1.GroupName,
 		Resource: radixv1.ResourceRadixRegistrations,
 		Version:  "*",
 		Name:     appName,
-	})
+	}
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/equinor/radix-api/api/metrics"
 	"github.com/equinor/radix-api/api/utils/logs"
+	"github.com/equinor/radix-api/api/utils/tracing"
 	"github.com/equinor/radix-common/utils/slice"
 	prometheusApi "github.com/prometheus/client_golang/api"
 	prometheusV1 "github.com/prometheus/client_golang/api/prometheus/v1"
@@ -30,17 +31,27 @@ type Client struct {
 
 // NewPrometheusClient Constructor for a Prometheus Metrics Client
 func NewPrometheusClient(prometheusUrl string) (metrics.Client, error) {
+	api, err := NewQueryAPI(prometheusUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClient(api), nil
+}
+
+// NewQueryAPI Constructor for the Prometheus query API at prometheusUrl
+func NewQueryAPI(prometheusUrl string) (QueryAPI, error) {
 	logger := logs.NewRoundtripLogger(func(e *zerolog.Event) {
 		e.Str("PrometheusClient", "prometheus")
 	})
 
-	apiClient, err := prometheusApi.NewClient(prometheusApi.Config{Address: prometheusUrl, RoundTripper: logger(prometheusApi.DefaultRoundTripper)})
+	tracer := tracing.NewRoundtripTracer("prometheus")
+
+	apiClient, err := prometheusApi.NewClient(prometheusApi.Config{Address: prometheusUrl, RoundTripper: tracer(logger(prometheusApi.DefaultRoundTripper))})
 	if err != nil {
 		return nil, errors.New("failed to create the Prometheus API PrometheusClient")
 	}
-	api := prometheusV1.NewAPI(apiClient)
-
-	return NewClient(api), nil
+	return prometheusV1.NewAPI(apiClient), nil
 }
 
 func NewClient(api QueryAPI) metrics.Client {
@@ -0,0 +1,33 @@
+package prometheus
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	prometheusV1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+)
+
+// ReloadableQueryAPI delegates to a query API that can be replaced while serving requests, e.g. when the Prometheus URL is reloaded
+type ReloadableQueryAPI struct {
+	api atomic.Pointer[QueryAPI]
+}
+
+var _ QueryAPI = &ReloadableQueryAPI{}
+
+// NewReloadableQueryAPI Constructor for ReloadableQueryAPI
+func NewReloadableQueryAPI(api QueryAPI) *ReloadableQueryAPI {
+	r := &ReloadableQueryAPI{}
+	r.Set(api)
+	return r
+}
+
+// Set replaces the query API used for new queries
+func (r *ReloadableQueryAPI) Set(api QueryAPI) {
+	r.api.Store(&api)
+}
+
+func (r *ReloadableQueryAPI) Query(ctx context.Context, query string, ts time.Time, opts ...prometheusV1.Option) (model.Value, prometheusV1.Warnings, error) {
+	return (*r.api.Load()).Query(ctx, query, ts, opts...)
+}
@@ -2,13 +2,18 @@ package auth
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
+	"github.com/equinor/radix-api/api/kubequery"
+	"github.com/equinor/radix-api/api/utils/problem"
 	"github.com/equinor/radix-api/api/utils/token"
 	"github.com/equinor/radix-common/models"
 	radixhttp "github.com/equinor/radix-common/net/http"
+	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
 	"github.com/urfave/negroni/v3"
+	"k8s.io/client-go/kubernetes"
 )
 
 type ctxUserKey struct{}
@@ -26,7 +31,7 @@ func NewAuthenticationMiddleware(validator token.ValidatorInterface) negroni.Han
 		token, err := radixhttp.GetBearerTokenFromHeader(r)
 		if err != nil {
 			logger.Warn().Err(err).Msg("authentication error")
-			if err = radixhttp.ErrorResponse(w, r, err); err != nil {
+			if err = problem.ErrorResponse(w, r, err); err != nil {
 				logger.Err(err).Msg("failed to write response")
 			}
 			return
@@ -34,7 +39,7 @@ func NewAuthenticationMiddleware(validator token.ValidatorInterface) negroni.Han
 		principal, err := validator.ValidateToken(ctx, token)
 		if err != nil {
 			logger.Warn().Err(err).Msg("authentication error")
-			if err = radixhttp.ErrorResponse(w, r, err); err != nil {
+			if err = problem.ErrorResponse(w, r, err); err != nil {
 				logger.Err(err).Msg("failed to write response")
 			}
 			return
@@ -43,7 +48,7 @@ func NewAuthenticationMiddleware(validator token.ValidatorInterface) negroni.Han
 		impersonation, err := radixhttp.GetImpersonationFromHeader(r)
 		if err != nil {
 			logger.Warn().Err(err).Msg("authorization error")
-			if err = radixhttp.ErrorResponse(w, r, radixhttp.UnexpectedError("Problems impersonating", err)); err != nil {
+			if err = problem.ErrorResponse(w, r, radixhttp.UnexpectedError("Problems impersonating", err)); err != nil {
 				logger.Err(err).Msg("failed to write response")
 			}
 			return
@@ -86,6 +91,19 @@ func GetOriginator(ctx context.Context) string {
 	return principal.Name()
 }
 
+// CtxUserPrincipalName returns the user principal name of the user the token of the request is issued to.
+// It returns false when the request is impersonated, or the token is not issued to a user, since the name cannot be trusted then
+func CtxUserPrincipalName(ctx context.Context) (string, bool) {
+	if CtxImpersonation(ctx).PerformImpersonation() {
+		return "", false
+	}
+	principal, ok := CtxTokenPrincipal(ctx).(token.UserPrincipal)
+	if !ok || !principal.IsAuthenticated() || principal.UserPrincipalName() == "" {
+		return "", false
+	}
+	return principal.UserPrincipalName(), true
+}
+
 func NewZerologAuthenticationDetailsMiddleware() negroni.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 		ctx := r.Context()
@@ -115,7 +133,32 @@ func NewAuthorizeRequiredMiddleware() negroni.HandlerFunc {
 
 		if !user.IsAuthenticated() {
 			logger.Warn().Msg("authorization error")
-			if err := radixhttp.ErrorResponse(w, r, radixhttp.ForbiddenError("Authorization is required")); err != nil {
+			if err := problem.ErrorResponse(w, r, radixhttp.ForbiddenError("Authorization is required")); err != nil {
+				logger.Err(err).Msg("failed to write response")
+			}
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// NewPersonalAccessTokenMiddleware Rejects requests authenticated with a personal access token, unless the token has the scope of the route,
+// is bound to the application in the route, and the creator of the token is still administrator of the application as a user.
+// Group membership of the creator is not considered, since it cannot be resolved when the token is used.
+// Impersonation is not allowed with personal access tokens. The kubeClient must be allowed to create SubjectAccessReviews
+func NewPersonalAccessTokenMiddleware(scope token.Scope, kubeClient kubernetes.Interface) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		principal, ok := CtxTokenPrincipal(r.Context()).(*token.PersonalAccessTokenPrincipal)
+		if !ok {
+			next(w, r)
+			return
+		}
+
+		if err := authorizePersonalAccessToken(r, kubeClient, principal.PersonalAccessToken(), scope); err != nil {
+			logger := log.Ctx(r.Context())
+			logger.Warn().Err(err).Msg("authorization error")
+			if err = problem.ErrorResponse(w, r, err); err != nil {
 				logger.Err(err).Msg("failed to write response")
 			}
 			return
@@ -124,3 +167,38 @@ func NewAuthorizeRequiredMiddleware() negroni.HandlerFunc {
 		next(w, r)
 	}
 }
+
+func authorizePersonalAccessToken(r *http.Request, kubeClient kubernetes.Interface, pat token.PersonalAccessToken, scope token.Scope) error {
+	if CtxImpersonation(r.Context()).PerformImpersonation() {
+		return radixhttp.ForbiddenError("Impersonation is not allowed with personal access tokens")
+	}
+	if scope == "" {
+		return radixhttp.ForbiddenError("Personal access tokens cannot be used for this operation")
+	}
+	if appName := mux.Vars(r)["appName"]; appName != pat.AppName {
+		return radixhttp.ForbiddenError(fmt.Sprintf("The personal access token is only valid for the application %s", pat.AppName))
+	}
+	if !pat.HasScope(scope) {
+		return radixhttp.ForbiddenError(fmt.Sprintf("The personal access token does not have the scope %s", scope))
+	}
+	if pat.CreatorUser == "" {
+		return radixhttp.ForbiddenError("The creator of the personal access token is unknown")
+	}
+	isAdmin, err := kubequery.IsUserRadixApplicationAdmin(r.Context(), kubeClient, pat.CreatorUser, nil, pat.AppName)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return radixhttp.ForbiddenError(fmt.Sprintf("The creator of the personal access token is no longer administrator of the application %s", pat.AppName))
+	}
+	return nil
+}
+
+// CtxPersonalAccessToken returns the personal access token, when the request is authenticated with a personal access token
+func CtxPersonalAccessToken(ctx context.Context) (token.PersonalAccessToken, bool) {
+	principal, ok := CtxTokenPrincipal(ctx).(*token.PersonalAccessTokenPrincipal)
+	if !ok {
+		return token.PersonalAccessToken{}, false
+	}
+	return principal.PersonalAccessToken(), true
+}
@@ -6,12 +6,21 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/equinor/radix-api/api/middleware/auth"
 	controllertest "github.com/equinor/radix-api/api/test"
+	"github.com/equinor/radix-api/api/utils/token"
 	authnmock "github.com/equinor/radix-api/api/utils/token/mock"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/negroni/v3"
 	"go.uber.org/mock/gomock"
+	authorizationapiv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	testing2 "k8s.io/client-go/testing"
 )
 
 func TestAuthenticatedRequest(t *testing.T) {
@@ -92,3 +101,63 @@ func newNullMiddleware() func(writer http.ResponseWriter, request *http.Request)
 		_, _ = writer.Write([]byte("hello world"))
 	}
 }
+
+func TestPersonalAccessTokenMiddleware(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(testing2.CreateAction).GetObject().(*authorizationapiv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Name == "any-app" && review.Spec.User == "any-user" && len(review.Spec.Groups) == 0
+		return true, review, nil
+	})
+	store := token.NewPersonalAccessTokenStore(kubeClient, "radix-api-prod")
+	createToken := func(creatorUser string) string {
+		_, tokenValue, err := store.Create(context.Background(), token.PersonalAccessToken{
+			AppName:     "any-app",
+			Scopes:      []token.Scope{token.ScopePipelinesTrigger},
+			CreatorUser: creatorUser,
+			Expires:     time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		return tokenValue
+	}
+	tokenValue := createToken("any-user")
+	formerAdminTokenValue := createToken("former-admin-user")
+
+	scenarios := []struct {
+		name           string
+		path           string
+		scope          token.Scope
+		impersonate    bool
+		tokenValue     string
+		expectedStatus int
+	}{
+		{name: "route with scope of token", path: "/api/v1/applications/any-app/pipelines/build", scope: token.ScopePipelinesTrigger, expectedStatus: http.StatusOK},
+		{name: "route without scope", path: "/api/v1/applications/any-app/pipelines/build", expectedStatus: http.StatusForbidden},
+		{name: "route with other scope", path: "/api/v1/applications/any-app/pipelines/build", scope: token.ScopeRead, expectedStatus: http.StatusForbidden},
+		{name: "other application", path: "/api/v1/applications/other-app/pipelines/build", scope: token.ScopePipelinesTrigger, expectedStatus: http.StatusForbidden},
+		{name: "impersonation", path: "/api/v1/applications/any-app/pipelines/build", scope: token.ScopePipelinesTrigger, impersonate: true, expectedStatus: http.StatusForbidden},
+		{name: "creator no longer administrator", path: "/api/v1/applications/any-app/pipelines/build", scope: token.ScopePipelinesTrigger, tokenValue: formerAdminTokenValue, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, ts := range scenarios {
+		t.Run(ts.name, func(t *testing.T) {
+			n := negroni.New(auth.NewAuthenticationMiddleware(token.NewPersonalAccessTokenValidator(store)), auth.NewPersonalAccessTokenMiddleware(ts.scope, kubeClient))
+			n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+			router := mux.NewRouter()
+			router.Handle("/api/v1/applications/{appName}/pipelines/build", n)
+
+			req := httptest.NewRequest(http.MethodPost, ts.path, nil)
+			if ts.tokenValue == "" {
+				ts.tokenValue = tokenValue
+			}
+			req.Header.Add("Authorization", "Bearer "+ts.tokenValue)
+			if ts.impersonate {
+				req.Header.Add("Impersonate-User", "any-user")
+				req.Header.Add("Impersonate-Group", "any-group")
+			}
+			rw := httptest.NewRecorder()
+			router.ServeHTTP(rw, req)
+			assert.Equal(t, ts.expectedStatus, rw.Code)
+		})
+	}
+}
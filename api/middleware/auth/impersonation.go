@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+
+	"github.com/equinor/radix-api/api/utils/problem"
+	"github.com/equinor/radix-api/api/utils/token"
+	"github.com/equinor/radix-common/models"
+	radixhttp "github.com/equinor/radix-common/net/http"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/negroni/v3"
+)
+
+// CodeImpersonationNotAllowed the principal is not allowed to impersonate, or to impersonate the user or groups
+const CodeImpersonationNotAllowed problem.Code = "impersonation-not-allowed"
+
+// ImpersonationPolicy which principals are allowed to impersonate, and which users and groups they can impersonate.
+// The lists are glob patterns matched with path.Match. Principals are matched by id only, since the name of a principal may be chosen by its owner.
+// An empty list of users or groups allows all. A nil ImpersonationPolicy allows all impersonation
+type ImpersonationPolicy struct {
+	disabled   bool
+	principals []string
+	users      []string
+	groups     []string
+}
+
+// NewImpersonationPolicy Constructor for ImpersonationPolicy. The principals allowed to impersonate are required when impersonation is enabled
+func NewImpersonationPolicy(enabled bool, principals, users, groups []string) (*ImpersonationPolicy, error) {
+	if enabled && len(principals) == 0 {
+		return nil, errors.New("the principals allowed to impersonate are required when impersonation is enabled")
+	}
+	for _, pattern := range slices.Concat(principals, users, groups) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid impersonation pattern %q: %w", pattern, err)
+		}
+	}
+	return &ImpersonationPolicy{disabled: !enabled, principals: principals, users: users, groups: groups}, nil
+}
+
+// Authorize returns a Forbidden error when the principal is not allowed to perform the impersonation
+func (p *ImpersonationPolicy) Authorize(principal token.TokenPrincipal, impersonation models.Impersonation) error {
+	if p == nil || !impersonation.PerformImpersonation() {
+		return nil
+	}
+	if p.disabled {
+		return impersonationNotAllowedError("Impersonation is disabled")
+	}
+	if !matchAny(p.principals, principal.Id()) {
+		return impersonationNotAllowedError(fmt.Sprintf("%s is not allowed to impersonate", principal.Name()))
+	}
+	if !matchAny(p.users, impersonation.User) {
+		return impersonationNotAllowedError(fmt.Sprintf("The user %s cannot be impersonated", impersonation.User))
+	}
+	for _, group := range impersonation.Groups {
+		if !matchAny(p.groups, group) {
+			return impersonationNotAllowedError(fmt.Sprintf("The group %s cannot be impersonated", group))
+		}
+	}
+	return nil
+}
+
+// NewImpersonationMiddleware rejects requests with impersonation not allowed by the policy, and logs every impersonated request
+func NewImpersonationMiddleware(policy *ImpersonationPolicy) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		ctx := r.Context()
+		impersonation := CtxImpersonation(ctx)
+		if !impersonation.PerformImpersonation() {
+			next(w, r)
+			return
+		}
+
+		logger := log.Ctx(ctx)
+		principal := CtxTokenPrincipal(ctx)
+		if err := policy.Authorize(principal, impersonation); err != nil {
+			logger.Warn().Err(err).Str("user_name", principal.Name()).Msg("impersonation rejected")
+			if err = problem.ErrorResponse(w, r, err); err != nil {
+				logger.Err(err).Msg("failed to write response")
+			}
+			return
+		}
+
+		logger.Info().Str("user_name", principal.Name()).Msg("impersonated request")
+		next(w, r)
+	}
+}
+
+func matchAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func impersonationNotAllowedError(message string) error {
+	return problem.WithCode(CodeImpersonationNotAllowed, radixhttp.ForbiddenError(message))
+}
@@ -0,0 +1,89 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/equinor/radix-api/api/middleware/auth"
+	controllertest "github.com/equinor/radix-api/api/test"
+	"github.com/equinor/radix-api/api/utils/problem"
+	authnmock "github.com/equinor/radix-api/api/utils/token/mock"
+	"github.com/equinor/radix-common/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/negroni/v3"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImpersonationPolicy_Authorize(t *testing.T) {
+	principal := controllertest.NewTestPrincipal(true)
+	scenarios := []struct {
+		name          string
+		enabled       bool
+		principals    []string
+		users         []string
+		groups        []string
+		impersonation models.Impersonation
+		expectAllowed bool
+	}{
+		{name: "no impersonation", impersonation: models.Impersonation{}, expectAllowed: true},
+		{name: "disabled", enabled: false, impersonation: models.Impersonation{User: "any-user", Groups: []string{"any-group"}}, expectAllowed: false},
+		{name: "principal allowed by id", enabled: true, principals: []string{"test-*"}, impersonation: models.Impersonation{User: "any-user", Groups: []string{"any-group"}}, expectAllowed: true},
+		{name: "principal not allowed by name", enabled: true, principals: []string{"test-principal"}, impersonation: models.Impersonation{User: "any-user", Groups: []string{"any-group"}}, expectAllowed: false},
+		{name: "principal not allowed", enabled: true, principals: []string{"other-id"}, impersonation: models.Impersonation{User: "any-user", Groups: []string{"any-group"}}, expectAllowed: false},
+		{name: "user allowed", enabled: true, principals: []string{"test-id"}, users: []string{"test-user-*"}, impersonation: models.Impersonation{User: "test-user-1", Groups: []string{"any-group"}}, expectAllowed: true},
+		{name: "user not allowed", enabled: true, principals: []string{"test-id"}, users: []string{"test-user-*"}, impersonation: models.Impersonation{User: "admin", Groups: []string{"any-group"}}, expectAllowed: false},
+		{name: "group not allowed", enabled: true, principals: []string{"test-id"}, groups: []string{"test-group-*"}, impersonation: models.Impersonation{User: "any-user", Groups: []string{"test-group-1", "admins"}}, expectAllowed: false},
+	}
+
+	for _, ts := range scenarios {
+		t.Run(ts.name, func(t *testing.T) {
+			policy, err := auth.NewImpersonationPolicy(ts.enabled, ts.principals, ts.users, ts.groups)
+			require.NoError(t, err)
+
+			err = policy.Authorize(principal, ts.impersonation)
+			if ts.expectAllowed {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, auth.CodeImpersonationNotAllowed, problem.GetCode(err))
+			}
+		})
+	}
+}
+
+func TestNewImpersonationPolicy_InvalidPattern(t *testing.T) {
+	_, err := auth.NewImpersonationPolicy(true, []string{"test-id"}, []string{"test-["}, nil)
+	assert.Error(t, err)
+}
+
+func TestNewImpersonationPolicy_EnabledWithoutPrincipals(t *testing.T) {
+	_, err := auth.NewImpersonationPolicy(true, nil, nil, nil)
+	assert.Error(t, err, "all principals would be allowed to impersonate")
+
+	_, err = auth.NewImpersonationPolicy(false, nil, nil, nil)
+	assert.NoError(t, err)
+}
+
+func TestImpersonationMiddleware(t *testing.T) {
+	validator := authnmock.NewMockValidatorInterface(gomock.NewController(t))
+	validator.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).AnyTimes().Return(controllertest.NewTestPrincipal(true), nil)
+	policy, err := auth.NewImpersonationPolicy(true, []string{"test-id"}, []string{"test-user"}, nil)
+	require.NoError(t, err)
+	n := negroni.New(auth.NewAuthenticationMiddleware(validator), auth.NewImpersonationMiddleware(policy))
+	n.UseHandlerFunc(newNullMiddleware())
+
+	for user, expectedStatus := range map[string]int{"test-user": http.StatusOK, "admin": http.StatusForbidden} {
+		t.Run(user, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/anyendpoint", nil)
+			req.Header.Add("Authorization", "Bearer any-token")
+			req.Header.Add("Impersonate-User", user)
+			req.Header.Add("Impersonate-Group", "any-group")
+			rw := httptest.NewRecorder()
+
+			n.ServeHTTP(rw, req)
+
+			assert.Equal(t, expectedStatus, rw.Code)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"net"
 	"net/http"
 
@@ -35,14 +36,25 @@ func NewZerologResponseLoggerMiddleware() negroni.HandlerFunc {
 	}
 }
 
+type requestIdKey struct{}
+
 func NewZerologRequestIdMiddleware() negroni.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
-		logger := log.Ctx(r.Context()).With().Str("request_id", xid.New().String()).Logger()
-		r = r.WithContext(logger.WithContext(r.Context()))
+		requestId := xid.New().String()
+		logger := log.Ctx(r.Context()).With().Str("request_id", requestId).Logger()
+		ctx := context.WithValue(logger.WithContext(r.Context()), requestIdKey{}, requestId)
+		r = r.WithContext(ctx)
 
 		next(w, r)
 	}
 }
+
+// GetRequestId returns the ID of the request set by NewZerologRequestIdMiddleware, or an empty string
+func GetRequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdKey{}).(string)
+	return requestId
+}
+
 func NewZerologRequestDetailsMiddleware() negroni.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
 		remoteIp, _, _ := net.SplitHostPort(r.RemoteAddr)
@@ -0,0 +1,78 @@
+package maintenance
+
+import (
+	"net/http"
+	"sync/atomic"
+
+	"github.com/equinor/radix-api/api/utils/problem"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/negroni/v3"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// CodeMaintenanceMode Error code when a request is rejected because the API is in read-only maintenance mode
+const CodeMaintenanceMode problem.Code = "maintenance-mode"
+
+const defaultReason = "Radix is in maintenance mode, changes are not allowed. Please try again later"
+
+// Status of the maintenance mode
+type Status struct {
+	Enabled bool
+	// Reason shown to users when requests are rejected
+	Reason string
+}
+
+// NewStatus returns the status of maintenance mode. A default reason is used when reason is empty
+func NewStatus(enabled bool, reason string) Status {
+	if !enabled {
+		return Status{}
+	}
+	if reason == "" {
+		reason = defaultReason
+	}
+	return Status{Enabled: true, Reason: reason}
+}
+
+// Mode read-only maintenance mode, which can be enabled and disabled while serving requests
+type Mode struct {
+	status atomic.Pointer[Status]
+}
+
+// NewMode Constructor for Mode
+func NewMode(enabled bool, reason string) *Mode {
+	m := &Mode{}
+	m.Set(enabled, reason)
+	return m
+}
+
+// Set enables or disables maintenance mode. A default reason is used when reason is empty
+func (m *Mode) Set(enabled bool, reason string) {
+	status := NewStatus(enabled, reason)
+	m.status.Store(&status)
+}
+
+// Status returns the current status. A nil Mode is never enabled
+func (m *Mode) Status() Status {
+	if m == nil {
+		return Status{}
+	}
+	return *m.status.Load()
+}
+
+// NewMaintenanceMiddleware Rejects requests with 503 Service Unavailable and the reason when maintenance mode is enabled.
+// Use only for the routes that change resources
+func NewMaintenanceMiddleware(mode *Mode) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		status := mode.Status()
+		if !status.Enabled {
+			next(w, r)
+			return
+		}
+
+		logger := log.Ctx(r.Context())
+		logger.Info().Msg("request rejected in maintenance mode")
+		if err := problem.ErrorResponse(w, r, problem.WithCode(CodeMaintenanceMode, k8serrors.NewServiceUnavailable(status.Reason))); err != nil {
+			logger.Err(err).Msg("failed to write response")
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package maintenance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/equinor/radix-api/api/utils/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceMiddleware(t *testing.T) {
+	mode := NewMode(false, "")
+	middleware := NewMaintenanceMiddleware(mode)
+	serve := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		middleware(w, httptest.NewRequest(http.MethodPost, "/api/v1/applications", nil), func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, serve().Code)
+
+	mode.Set(true, "Cluster migration until 14:00")
+	w := serve()
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var p problem.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	assert.Equal(t, CodeMaintenanceMode, p.Code)
+	assert.Equal(t, "Cluster migration until 14:00", p.Detail)
+
+	mode.Set(false, "")
+	assert.Equal(t, http.StatusOK, serve().Code)
+}
+
+func TestMode_Status(t *testing.T) {
+	var nilMode *Mode
+	assert.Equal(t, Status{}, nilMode.Status())
+	assert.Equal(t, Status{Enabled: true, Reason: defaultReason}, NewMode(true, "").Status())
+	assert.Equal(t, Status{}, NewMode(false, "any reason").Status())
+}
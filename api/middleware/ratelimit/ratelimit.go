@@ -0,0 +1,188 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/equinor/radix-api/api/middleware/auth"
+	"github.com/equinor/radix-api/api/utils/problem"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/negroni/v3"
+	"golang.org/x/time/rate"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const sweepInterval = 5 * time.Minute
+
+var rejectedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "radix_api_rate_limited_requests_total",
+	Help: "The total number of requests rejected by the rate limiter, by route",
+}, []string{"route"})
+
+// Budget The number of requests per second, and the burst of requests, a principal is allowed to send to a route
+type Budget struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// IsZero returns true when neither RequestsPerSecond nor Burst is set
+func (b Budget) IsZero() bool {
+	return b.RequestsPerSecond == 0 && b.Burst == 0
+}
+
+// RateLimiter Keeps a token bucket per route and principal
+type RateLimiter struct {
+	defaultBudget  Budget
+	trustedProxies int
+	routeBudgetsMu sync.RWMutex
+	routeBudgets   map[string]Budget
+	mu             sync.Mutex
+	buckets        map[string]*bucket
+	lastSweep      time.Time
+	now            func() time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewRateLimiter Constructor for RateLimiter. defaultBudget is used for routes without a budget.
+// trustedProxies is the number of reverse proxies in front of radix-api appending the address of their client to X-Forwarded-For
+func NewRateLimiter(defaultBudget Budget, trustedProxies int) *RateLimiter {
+	return &RateLimiter{
+		defaultBudget:  defaultBudget,
+		trustedProxies: trustedProxies,
+		buckets:        map[string]*bucket{},
+		lastSweep:      time.Now(),
+		now:            time.Now,
+	}
+}
+
+// NewRateLimitMiddleware Rejects requests with 429 Too Many Requests and a Retry-After header when the principal has exceeded the budget for the route.
+// Authenticated requests are limited per TokenPrincipal.Id(), anonymous requests per client IP.
+// A nil rateLimiter disables rate limiting.
+func NewRateLimitMiddleware(rateLimiter *RateLimiter, route string, budget Budget) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		if rateLimiter == nil {
+			next(w, r)
+			return
+		}
+
+		principalKey := rateLimiter.getPrincipalKey(r)
+		allowed, retryAfter := rateLimiter.reserve(route+"|"+principalKey, rateLimiter.getRouteBudget(route, budget))
+		if allowed {
+			next(w, r)
+			return
+		}
+
+		retryAfterSeconds := int(math.Max(1, math.Ceil(retryAfter.Seconds())))
+		rejectedRequests.WithLabelValues(route).Inc()
+		logger := log.Ctx(r.Context())
+		logger.Warn().Str("rate_limit_key", principalKey).Msgf("rate limit exceeded, retry after %d seconds", retryAfterSeconds)
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+		if err := problem.ErrorResponse(w, r, k8serrors.NewTooManyRequests(fmt.Sprintf("Rate limit exceeded, retry after %d seconds", retryAfterSeconds), retryAfterSeconds)); err != nil {
+			logger.Err(err).Msg("failed to write response")
+		}
+	}
+}
+
+// SetRouteBudgets sets the budgets of routes, by the method and path of the route, e.g. "POST /api/v1/applications/{appName}/pipelines/build".
+// They override the budget of the route set in code. The buckets in use are changed to the new budgets
+func (l *RateLimiter) SetRouteBudgets(routeBudgets map[string]Budget) {
+	l.routeBudgetsMu.Lock()
+	defer l.routeBudgetsMu.Unlock()
+	l.routeBudgets = routeBudgets
+}
+
+// getRouteBudget returns the budget configured for the route, or budget when none is configured
+func (l *RateLimiter) getRouteBudget(route string, budget Budget) Budget {
+	l.routeBudgetsMu.RLock()
+	defer l.routeBudgetsMu.RUnlock()
+	if routeBudget, ok := l.routeBudgets[route]; ok {
+		return routeBudget
+	}
+	return budget
+}
+
+// reserve takes a token from the bucket for key. When the bucket is empty, the time until a token is available is returned
+func (l *RateLimiter) reserve(key string, budget Budget) (bool, time.Duration) {
+	if budget.IsZero() {
+		budget = l.defaultBudget
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(rate.Limit(budget.RequestsPerSecond), budget.Burst)}
+		l.buckets[key] = b
+	}
+	if b.limiter.Limit() != rate.Limit(budget.RequestsPerSecond) || b.limiter.Burst() != budget.Burst {
+		b.limiter.SetLimitAt(now, rate.Limit(budget.RequestsPerSecond))
+		b.limiter.SetBurstAt(now, budget.Burst)
+	}
+	b.lastSeen = now
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// sweep removes buckets not used since the previous sweep, to keep memory bounded by the number of active principals
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	for key, b := range l.buckets {
+		if b.lastSeen.Before(l.lastSweep) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+func (l *RateLimiter) getPrincipalKey(r *http.Request) string {
+	if principal := auth.CtxTokenPrincipal(r.Context()); principal.IsAuthenticated() {
+		return "id:" + principal.Id()
+	}
+	return "ip:" + l.getClientIp(r)
+}
+
+// getClientIp returns the address in X-Forwarded-For appended by the outermost trusted proxy, or the remote address of the request.
+// Addresses to the left of it are set by the client, and cannot be trusted
+func (l *RateLimiter) getClientIp(r *http.Request) string {
+	var forwardedFor []string
+	for _, value := range r.Header.Values("X-Forwarded-For") {
+		for _, ip := range strings.Split(value, ",") {
+			forwardedFor = append(forwardedFor, strings.TrimSpace(ip))
+		}
+	}
+	if l.trustedProxies > 0 && len(forwardedFor) >= l.trustedProxies {
+		if clientIp := forwardedFor[len(forwardedFor)-l.trustedProxies]; clientIp != "" {
+			return clientIp
+		}
+	}
+	remoteIp, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return remoteIp
+}
@@ -0,0 +1,161 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/equinor/radix-api/api/middleware/auth"
+	"github.com/equinor/radix-api/api/utils/token"
+	tokenmock "github.com/equinor/radix-api/api/utils/token/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/negroni/v3"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRateLimitMiddleware_AnonymousLimitedPerIp(t *testing.T) {
+	rateLimiter := NewRateLimiter(Budget{RequestsPerSecond: 1, Burst: 2}, 1)
+	now := time.Now()
+	rateLimiter.now = func() time.Time { return now }
+	handler := negroni.New(NewRateLimitMiddleware(rateLimiter, "GET /api/v1/badge", Budget{}))
+	handler.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	send := func(remoteAddr string, forwardedFor ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/badge", nil)
+		req.RemoteAddr = remoteAddr
+		for _, ip := range forwardedFor {
+			req.Header.Add("X-Forwarded-For", ip)
+		}
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		return rw
+	}
+
+	assert.Equal(t, http.StatusOK, send("10.0.0.1:1000").Code)
+	assert.Equal(t, http.StatusOK, send("10.0.0.1:1001").Code)
+	rw := send("10.0.0.1:1002")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "1", rw.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, send("10.0.0.2:1000").Code, "other IPs have their own budget")
+	assert.Equal(t, http.StatusOK, send("10.0.0.1:1004", "192.168.0.1").Code, "forwarded client IPs have their own budget")
+
+	now = now.Add(time.Second)
+	assert.Equal(t, http.StatusOK, send("10.0.0.1:1003").Code, "budget is refilled")
+}
+
+func TestRateLimitMiddleware_SpoofedForwardedForIgnored(t *testing.T) {
+	rateLimiter := NewRateLimiter(Budget{RequestsPerSecond: 1, Burst: 1}, 2)
+	now := time.Now()
+	rateLimiter.now = func() time.Time { return now }
+	handler := negroni.New(NewRateLimitMiddleware(rateLimiter, "GET /api/v1/badge", Budget{}))
+	handler.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	// The client address is appended by the outer proxy, and the address of the outer proxy by the ingress controller
+	send := func(forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/badge", nil)
+		req.RemoteAddr = "10.0.0.1:1000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		return rw.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send("203.0.113.7, 10.1.0.1"))
+	assert.Equal(t, http.StatusTooManyRequests, send("203.0.113.7, 10.1.0.1"))
+	assert.Equal(t, http.StatusTooManyRequests, send("198.51.100.1, 203.0.113.7, 10.1.0.1"), "an address set by the client should not get a new budget")
+	assert.Equal(t, http.StatusTooManyRequests, send("198.51.100.2,203.0.113.7,10.1.0.1"), "an address set by the client should not get a new budget")
+	assert.Equal(t, http.StatusOK, send("203.0.113.8, 10.1.0.1"), "other clients have their own budget")
+	assert.Equal(t, http.StatusOK, send("10.1.0.1"), "the remote address is used when the request did not pass all trusted proxies")
+}
+
+func TestRateLimitMiddleware_AuthenticatedLimitedPerPrincipalAndRoute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	principals := map[string]*tokenmock.MockTokenPrincipal{}
+	for _, id := range []string{"user1", "user2"} {
+		principal := tokenmock.NewMockTokenPrincipal(ctrl)
+		principal.EXPECT().Id().Return(id).AnyTimes()
+		principal.EXPECT().IsAuthenticated().Return(true).AnyTimes()
+		principals[id] = principal
+	}
+	validator := tokenmock.NewMockValidatorInterface(ctrl)
+	validator.EXPECT().ValidateToken(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, bearer string) (token.TokenPrincipal, error) {
+		return principals[bearer], nil
+	}).AnyTimes()
+
+	rateLimiter := NewRateLimiter(Budget{RequestsPerSecond: 100, Burst: 100}, 1)
+	now := time.Now()
+	rateLimiter.now = func() time.Time { return now }
+	newHandler := func(route string) http.Handler {
+		n := negroni.New(auth.NewAuthenticationMiddleware(validator), NewRateLimitMiddleware(rateLimiter, route, Budget{RequestsPerSecond: 0.1, Burst: 1}))
+		n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+		return n
+	}
+	jobsHandler, envsHandler := newHandler("GET /jobs"), newHandler("GET /environments")
+
+	send := func(handler http.Handler, principalId string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+principalId)
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, req)
+		return rw
+	}
+
+	assert.Equal(t, http.StatusOK, send(jobsHandler, "user1").Code)
+	rw := send(jobsHandler, "user1")
+	assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	assert.Equal(t, "10", rw.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, send(jobsHandler, "user2").Code, "other principals have their own budget")
+	assert.Equal(t, http.StatusOK, send(envsHandler, "user1").Code, "other routes have their own budget")
+}
+
+func TestRateLimitMiddleware_RouteBudgetOverridesBudgetInCode(t *testing.T) {
+	rateLimiter := NewRateLimiter(Budget{RequestsPerSecond: 1, Burst: 1}, 1)
+	now := time.Now()
+	rateLimiter.now = func() time.Time { return now }
+	handler := negroni.New(NewRateLimitMiddleware(rateLimiter, "GET /api/v1/badge", Budget{RequestsPerSecond: 1, Burst: 2}))
+	handler.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	send := func() int {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/api/v1/badge", nil))
+		return rw.Code
+	}
+
+	assert.Equal(t, http.StatusOK, send())
+	assert.Equal(t, http.StatusOK, send())
+	assert.Equal(t, http.StatusTooManyRequests, send(), "the budget of the route in code should be used")
+
+	rateLimiter.SetRouteBudgets(map[string]Budget{"GET /api/v1/badge": {RequestsPerSecond: 1, Burst: 4}})
+	assert.Equal(t, http.StatusTooManyRequests, send(), "the tokens used should not be refilled")
+	now = now.Add(4 * time.Second)
+	for range 4 {
+		assert.Equal(t, http.StatusOK, send(), "the configured budget should be used by the bucket in use")
+	}
+	assert.Equal(t, http.StatusTooManyRequests, send())
+}
+
+func TestRateLimiter_SweepsIdleBuckets(t *testing.T) {
+	rateLimiter := NewRateLimiter(Budget{RequestsPerSecond: 1, Burst: 1}, 1)
+	now := time.Now()
+	rateLimiter.now = func() time.Time { return now }
+
+	rateLimiter.reserve("idle", Budget{})
+	now = now.Add(sweepInterval)
+	rateLimiter.reserve("active", Budget{})
+	assert.Len(t, rateLimiter.buckets, 2, "buckets used since the previous sweep are kept")
+	now = now.Add(sweepInterval)
+	rateLimiter.reserve("active", Budget{})
+	assert.Len(t, rateLimiter.buckets, 1)
+	assert.Contains(t, rateLimiter.buckets, "active")
+}
+
+func TestNewRateLimitMiddleware_NilRateLimiterDisablesLimiting(t *testing.T) {
+	handler := negroni.New(NewRateLimitMiddleware(nil, "GET /", Budget{}))
+	handler.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	for range 10 {
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, http.StatusOK, rw.Code)
+	}
+}
@@ -0,0 +1,30 @@
+package pipelineschedules
+
+import (
+	"fmt"
+
+	"github.com/equinor/radix-api/api/utils/problem"
+	radixhttp "github.com/equinor/radix-common/net/http"
+)
+
+// Error codes of pipeline schedule errors
+const (
+	CodeInvalidPipelineSchedule       problem.Code = "invalid-pipeline-schedule"
+	CodePipelineScheduleNotFound      problem.Code = "pipeline-schedule-not-found"
+	CodePipelineScheduleAlreadyExists problem.Code = "pipeline-schedule-already-exists"
+)
+
+// InvalidPipelineScheduleError the request to create or change a pipeline schedule is invalid
+func InvalidPipelineScheduleError(reason string) error {
+	return problem.WithCode(CodeInvalidPipelineSchedule, radixhttp.ValidationError("PipelineSchedule", reason))
+}
+
+// PipelineScheduleNotFoundError the application has no pipeline schedule with the name
+func PipelineScheduleNotFoundError(appName, name string) error {
+	return problem.WithCode(CodePipelineScheduleNotFound, radixhttp.NotFoundError(fmt.Sprintf("pipeline schedule %s not found for application %s", name, appName)))
+}
+
+// PipelineScheduleAlreadyExistsError the application has a pipeline schedule with the name
+func PipelineScheduleAlreadyExistsError(appName, name string) error {
+	return problem.WithCode(CodePipelineScheduleAlreadyExists, radixhttp.ValidationError("PipelineSchedule", fmt.Sprintf("pipeline schedule %s already exists for application %s", name, appName)))
+}
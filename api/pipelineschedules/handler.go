@@ -0,0 +1,225 @@
+package pipelineschedules
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/equinor/radix-api/api/kubequery"
+	"github.com/equinor/radix-api/api/middleware/auth"
+	pipelineScheduleModels "github.com/equinor/radix-api/api/pipelineschedules/models"
+	"github.com/equinor/radix-api/api/utils/cron"
+	"github.com/equinor/radix-api/models"
+	radixhttp "github.com/equinor/radix-common/net/http"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var supportedPipelines = []v1.RadixPipelineType{v1.Build, v1.BuildDeploy, v1.Deploy, v1.ApplyConfig}
+
+// Handler Manages the pipeline schedules of applications. Users with access to the application can read the schedules, and only application administrators can change them
+type Handler struct {
+	accounts models.Accounts
+	store    *Store
+	now      func() time.Time
+}
+
+// NewHandler Constructor
+func NewHandler(accounts models.Accounts, store *Store) Handler {
+	return Handler{accounts: accounts, store: store, now: time.Now}
+}
+
+// GetPipelineSchedules lists the pipeline schedules of the application, sorted by name
+func (h Handler) GetPipelineSchedules(ctx context.Context, appName string) ([]pipelineScheduleModels.PipelineSchedule, error) {
+	if _, err := kubequery.GetRadixRegistration(ctx, h.accounts.UserAccount.RadixClient, appName); err != nil {
+		return nil, err
+	}
+
+	schedules, err := h.store.List(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	pipelineSchedules := make([]pipelineScheduleModels.PipelineSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		pipelineSchedules = append(pipelineSchedules, toModel(schedule))
+	}
+	return pipelineSchedules, nil
+}
+
+// GetPipelineSchedule gets a pipeline schedule of the application
+func (h Handler) GetPipelineSchedule(ctx context.Context, appName, name string) (*pipelineScheduleModels.PipelineSchedule, error) {
+	if _, err := kubequery.GetRadixRegistration(ctx, h.accounts.UserAccount.RadixClient, appName); err != nil {
+		return nil, err
+	}
+
+	schedule, err := h.store.Get(ctx, appName, name)
+	if kubeerrors.IsNotFound(err) {
+		return nil, PipelineScheduleNotFoundError(appName, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	model := toModel(*schedule)
+	return &model, nil
+}
+
+// CreatePipelineSchedule creates a pipeline schedule for the application
+func (h Handler) CreatePipelineSchedule(ctx context.Context, appName string, request pipelineScheduleModels.PipelineScheduleRequest) (*pipelineScheduleModels.PipelineSchedule, error) {
+	user, err := h.getAdminUser(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(request.Name)
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return nil, InvalidPipelineScheduleError(fmt.Sprintf("invalid name %q: %s", name, strings.Join(errs, ", ")))
+	}
+	if err := validateRequest(request); err != nil {
+		return nil, err
+	}
+
+	schedule := Schedule{AppName: appName}
+	schedule.Name = name
+	h.apply(ctx, &schedule, request, user)
+	err = h.store.Create(ctx, schedule)
+	if kubeerrors.IsAlreadyExists(err) {
+		return nil, PipelineScheduleAlreadyExistsError(appName, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	model := toModel(schedule)
+	return &model, nil
+}
+
+// UpdatePipelineSchedule changes a pipeline schedule of the application. The name of the schedule cannot be changed
+func (h Handler) UpdatePipelineSchedule(ctx context.Context, appName, name string, request pipelineScheduleModels.PipelineScheduleRequest) (*pipelineScheduleModels.PipelineSchedule, error) {
+	user, err := h.getAdminUser(ctx, appName)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateRequest(request); err != nil {
+		return nil, err
+	}
+
+	schedule, err := h.store.Update(ctx, appName, name, func(schedule *Schedule) { h.apply(ctx, schedule, request, user) })
+	if kubeerrors.IsNotFound(err) {
+		return nil, PipelineScheduleNotFoundError(appName, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	model := toModel(*schedule)
+	return &model, nil
+}
+
+// DeletePipelineSchedule deletes a pipeline schedule of the application
+func (h Handler) DeletePipelineSchedule(ctx context.Context, appName, name string) error {
+	if err := h.requireAdmin(ctx, appName); err != nil {
+		return err
+	}
+
+	err := h.store.Delete(ctx, appName, name)
+	if kubeerrors.IsNotFound(err) {
+		return PipelineScheduleNotFoundError(appName, name)
+	}
+	return err
+}
+
+func (h Handler) requireAdmin(ctx context.Context, appName string) error {
+	isAdmin, err := kubequery.IsRadixApplicationAdmin(ctx, h.accounts.UserAccount.Client, appName)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return radixhttp.ForbiddenError(fmt.Sprintf("you must be administrator of the application %s to manage pipeline schedules", appName))
+	}
+	return nil
+}
+
+// getAdminUser returns the Kubernetes user of the request, who must be administrator of the application as a user.
+// Group membership is not considered, since the scheduler cannot resolve the current groups of the user when it starts a job
+func (h Handler) getAdminUser(ctx context.Context, appName string) (string, error) {
+	if err := h.requireAdmin(ctx, appName); err != nil {
+		return "", err
+	}
+	review, err := h.accounts.UserAccount.Client.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	user := review.Status.UserInfo.Username
+	isUserAdmin, err := kubequery.IsUserRadixApplicationAdmin(ctx, h.accounts.ServiceAccount.Client, user, nil, appName)
+	if err != nil {
+		return "", err
+	}
+	if !isUserAdmin {
+		return "", radixhttp.ForbiddenError(fmt.Sprintf("you must be administrator of the application %s as a user, and not only through a group, to create or change pipeline schedules", appName))
+	}
+	return user, nil
+}
+
+// apply sets the fields of the schedule from the request. The next run is counted from the time the schedule was changed
+func (h Handler) apply(ctx context.Context, schedule *Schedule, request pipelineScheduleModels.PipelineScheduleRequest, user string) {
+	schedule.Schedule = strings.TrimSpace(request.Schedule)
+	schedule.Pipeline = request.Pipeline
+	schedule.GitRef = strings.TrimSpace(request.GitRef)
+	schedule.GitRefType = request.GitRefType
+	schedule.ToEnvironment = strings.TrimSpace(request.ToEnvironment)
+	schedule.Enabled = request.Enabled == nil || *request.Enabled
+	schedule.UpdatedBy = auth.GetOriginator(ctx)
+	schedule.UpdatedByUser = user
+	schedule.Updated = h.now().UTC().Truncate(time.Second)
+}
+
+func validateRequest(request pipelineScheduleModels.PipelineScheduleRequest) error {
+	if _, err := cron.Parse(request.Schedule); err != nil {
+		return InvalidPipelineScheduleError(err.Error())
+	}
+
+	pipeline := v1.RadixPipelineType(request.Pipeline)
+	if !slices.Contains(supportedPipelines, pipeline) {
+		return InvalidPipelineScheduleError(fmt.Sprintf("invalid pipeline %q, expected one of %v", request.Pipeline, supportedPipelines))
+	}
+	switch pipeline {
+	case v1.Build, v1.BuildDeploy:
+		if strings.TrimSpace(request.GitRef) == "" {
+			return InvalidPipelineScheduleError(fmt.Sprintf("gitRef is required for the %s pipeline", pipeline))
+		}
+		if gitRefType := v1.GitRefType(request.GitRefType); gitRefType != "" && gitRefType != v1.GitRefBranch && gitRefType != v1.GitRefTag {
+			return InvalidPipelineScheduleError(fmt.Sprintf("invalid gitRefType %q, expected %s or %s", request.GitRefType, v1.GitRefBranch, v1.GitRefTag))
+		}
+	case v1.Deploy:
+		if strings.TrimSpace(request.ToEnvironment) == "" {
+			return InvalidPipelineScheduleError("toEnvironment is required for the deploy pipeline")
+		}
+	}
+	return nil
+}
+
+func toModel(schedule Schedule) pipelineScheduleModels.PipelineSchedule {
+	model := schedule.PipelineSchedule
+	model.NextRun = nil
+	if schedule.Enabled {
+		if next, err := nextRun(schedule); err == nil && !next.IsZero() {
+			model.NextRun = &next
+		}
+	}
+	return model
+}
+
+// nextRun returns the time when the schedule starts the next job, counted from when it last started a job or was changed
+func nextRun(schedule Schedule) (time.Time, error) {
+	cronSchedule, err := cron.Parse(schedule.Schedule)
+	if err != nil {
+		return time.Time{}, err
+	}
+	from := schedule.Updated
+	if schedule.LastRun != nil && schedule.LastRun.After(from) {
+		from = *schedule.LastRun
+	}
+	return cronSchedule.Next(from), nil
+}
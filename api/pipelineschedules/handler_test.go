@@ -0,0 +1,151 @@
+package pipelineschedules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pipelineScheduleModels "github.com/equinor/radix-api/api/pipelineschedules/models"
+	"github.com/equinor/radix-api/api/utils/problem"
+	"github.com/equinor/radix-api/models"
+	"github.com/equinor/radix-common/utils/pointers"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	radixfake "github.com/equinor/radix-operator/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationapiv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	testing2 "k8s.io/client-go/testing"
+)
+
+const (
+	anyAppName   = "any-app"
+	otherAppName = "other-app"
+)
+
+// 2024-05-01 is a Wednesday
+var anyTime = time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+func setupHandler() (Handler, *Store) {
+	return setupHandlerWithUser("any-user")
+}
+
+func setupHandlerWithUser(creatorUser string) (Handler, *Store) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "selfsubjectaccessreviews", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(testing2.CreateAction).GetObject().(*authorizationapiv1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Name == anyAppName
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "selfsubjectreviews", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(testing2.CreateAction).GetObject().(*authenticationv1.SelfSubjectReview)
+		review.Status.UserInfo = authenticationv1.UserInfo{Username: creatorUser, Groups: []string{"any-group", "system:authenticated"}}
+		return true, review, nil
+	})
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(testing2.CreateAction).GetObject().(*authorizationapiv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Name == anyAppName && review.Spec.User == "any-user" && len(review.Spec.Groups) == 0
+		return true, review, nil
+	})
+	radixClient := radixfake.NewSimpleClientset(&v1.RadixRegistration{ObjectMeta: metav1.ObjectMeta{Name: anyAppName}}) //nolint:staticcheck
+	store := NewStore(kubeClient, "radix-api-prod")
+	handler := NewHandler(models.Accounts{UserAccount: models.Account{Client: kubeClient, RadixClient: radixClient}, ServiceAccount: models.Account{Client: kubeClient}}, store)
+	handler.now = func() time.Time { return anyTime }
+	return handler, store
+}
+
+func Test_CreatePipelineSchedule(t *testing.T) {
+	handler, store := setupHandler()
+
+	created, err := handler.CreatePipelineSchedule(context.Background(), anyAppName, pipelineScheduleModels.PipelineScheduleRequest{
+		Name: " nightly ", Schedule: "0 2 * * mon-fri", Pipeline: "build-deploy", GitRef: "main",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "nightly", created.Name)
+	assert.True(t, created.Enabled)
+	assert.Equal(t, anyTime, created.Updated)
+	assert.Equal(t, pointers.Ptr(time.Date(2024, 5, 2, 2, 0, 0, 0, time.UTC)), created.NextRun)
+
+	schedules, err := handler.GetPipelineSchedules(context.Background(), anyAppName)
+	require.NoError(t, err)
+	assert.Equal(t, []pipelineScheduleModels.PipelineSchedule{*created}, schedules)
+	schedule, err := handler.GetPipelineSchedule(context.Background(), anyAppName, "nightly")
+	require.NoError(t, err)
+	assert.Equal(t, created, schedule)
+
+	stored, err := store.Get(context.Background(), anyAppName, "nightly")
+	require.NoError(t, err)
+	assert.Equal(t, "any-user", stored.UpdatedByUser)
+
+	_, err = handler.CreatePipelineSchedule(context.Background(), anyAppName, pipelineScheduleModels.PipelineScheduleRequest{Name: "nightly", Schedule: "@daily", Pipeline: "apply-config"})
+	assert.Equal(t, CodePipelineScheduleAlreadyExists, problem.GetCode(err))
+}
+
+func Test_CreatePipelineSchedule_InvalidRequest(t *testing.T) {
+	scenarios := map[string]pipelineScheduleModels.PipelineScheduleRequest{
+		"missing name":               {Schedule: "@daily", Pipeline: "apply-config"},
+		"invalid name":               {Name: "Nightly_Build", Schedule: "@daily", Pipeline: "apply-config"},
+		"invalid schedule":           {Name: "nightly", Schedule: "0 25 * * *", Pipeline: "apply-config"},
+		"unsupported pipeline":       {Name: "nightly", Schedule: "@daily", Pipeline: "promote"},
+		"build without gitRef":       {Name: "nightly", Schedule: "@daily", Pipeline: "build"},
+		"invalid gitRefType":         {Name: "nightly", Schedule: "@daily", Pipeline: "build", GitRef: "main", GitRefType: "commit"},
+		"deploy without environment": {Name: "nightly", Schedule: "@daily", Pipeline: "deploy"},
+	}
+
+	for name, request := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			handler, _ := setupHandler()
+			_, err := handler.CreatePipelineSchedule(context.Background(), anyAppName, request)
+			assert.Equal(t, CodeInvalidPipelineSchedule, problem.GetCode(err))
+		})
+	}
+}
+
+func Test_PipelineSchedules_RequiresAdmin(t *testing.T) {
+	handler, _ := setupHandler()
+
+	_, err := handler.CreatePipelineSchedule(context.Background(), otherAppName, pipelineScheduleModels.PipelineScheduleRequest{Name: "nightly", Schedule: "@daily", Pipeline: "apply-config"})
+	assert.Error(t, err)
+	_, err = handler.UpdatePipelineSchedule(context.Background(), otherAppName, "nightly", pipelineScheduleModels.PipelineScheduleRequest{Schedule: "@daily", Pipeline: "apply-config"})
+	assert.Error(t, err)
+	assert.Error(t, handler.DeletePipelineSchedule(context.Background(), otherAppName, "nightly"))
+}
+
+func Test_PipelineSchedules_RequiresUserAdmin(t *testing.T) {
+	handler, _ := setupHandlerWithUser("group-admin-user")
+
+	_, err := handler.CreatePipelineSchedule(context.Background(), anyAppName, pipelineScheduleModels.PipelineScheduleRequest{Name: "nightly", Schedule: "@daily", Pipeline: "apply-config"})
+	assert.Error(t, err, "an administrator only through a group cannot create schedules")
+}
+
+func Test_UpdateAndDeletePipelineSchedule(t *testing.T) {
+	handler, store := setupHandler()
+	_, err := handler.CreatePipelineSchedule(context.Background(), anyAppName, pipelineScheduleModels.PipelineScheduleRequest{Name: "nightly", Schedule: "@daily", Pipeline: "apply-config"})
+	require.NoError(t, err)
+	lastRun := anyTime.Add(-time.Hour)
+	_, err = store.Update(context.Background(), anyAppName, "nightly", func(schedule *Schedule) { schedule.LastRun, schedule.LastJobName = &lastRun, "any-job" })
+	require.NoError(t, err)
+
+	updated, err := handler.UpdatePipelineSchedule(context.Background(), anyAppName, "nightly", pipelineScheduleModels.PipelineScheduleRequest{
+		Name: "ignored", Schedule: "0 12 * * *", Pipeline: "deploy", ToEnvironment: "dev", Enabled: pointers.Ptr(false),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "nightly", updated.Name)
+	assert.Equal(t, "deploy", updated.Pipeline)
+	assert.Equal(t, "dev", updated.ToEnvironment)
+	assert.False(t, updated.Enabled)
+	assert.Nil(t, updated.NextRun, "disabled schedules have no next run")
+	assert.Equal(t, "any-job", updated.LastJobName, "the status of the schedule should be kept when it is changed")
+
+	_, err = handler.UpdatePipelineSchedule(context.Background(), anyAppName, "other", pipelineScheduleModels.PipelineScheduleRequest{Schedule: "@daily", Pipeline: "apply-config"})
+	assert.Equal(t, CodePipelineScheduleNotFound, problem.GetCode(err))
+
+	require.NoError(t, handler.DeletePipelineSchedule(context.Background(), anyAppName, "nightly"))
+	_, err = handler.GetPipelineSchedule(context.Background(), anyAppName, "nightly")
+	assert.Equal(t, CodePipelineScheduleNotFound, problem.GetCode(err))
+	err = handler.DeletePipelineSchedule(context.Background(), anyAppName, "nightly")
+	assert.Equal(t, CodePipelineScheduleNotFound, problem.GetCode(err))
+}
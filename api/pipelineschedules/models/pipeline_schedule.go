@@ -0,0 +1,128 @@
+package models
+
+import "time"
+
+// PipelineSchedule describes a schedule starting pipeline jobs for an application
+// swagger:model PipelineSchedule
+type PipelineSchedule struct {
+	// Name of the schedule
+	//
+	// required: true
+	// example: nightly-build
+	Name string `json:"name"`
+
+	// Cron expression with the fields minute, hour, day of month, month and day of week, in UTC
+	//
+	// required: true
+	// example: 0 2 * * mon-fri
+	Schedule string `json:"schedule"`
+
+	// Pipeline type of the started jobs: build, build-deploy, deploy or apply-config
+	//
+	// required: true
+	// example: build-deploy
+	Pipeline string `json:"pipeline"`
+
+	// GitRef branch or tag to build, for the build and build-deploy pipelines
+	//
+	// required: false
+	// example: main
+	GitRef string `json:"gitRef,omitempty"`
+
+	// GitRefType type of the GitRef: branch or tag
+	//
+	// required: false
+	// example: branch
+	GitRefType string `json:"gitRefType,omitempty"`
+
+	// ToEnvironment environment to deploy to. Required for the deploy pipeline
+	//
+	// required: false
+	// example: dev
+	ToEnvironment string `json:"toEnvironment,omitempty"`
+
+	// Enabled jobs are only started when the schedule is enabled
+	//
+	// required: true
+	Enabled bool `json:"enabled"`
+
+	// UpdatedBy user who created or last changed the schedule
+	//
+	// required: true
+	// example: a_user@equinor.com
+	UpdatedBy string `json:"updatedBy"`
+
+	// Updated time when the schedule was created or last changed
+	//
+	// required: true
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated"`
+
+	// NextRun time when the next job is started, when the schedule is enabled
+	//
+	// required: false
+	// swagger:strfmt date-time
+	NextRun *time.Time `json:"nextRun,omitempty"`
+
+	// LastRun time when the schedule last started a job
+	//
+	// required: false
+	// swagger:strfmt date-time
+	LastRun *time.Time `json:"lastRun,omitempty"`
+
+	// LastJobName name of the job last started by the schedule
+	//
+	// required: false
+	// example: radix-pipeline-20240501020000-abcde
+	LastJobName string `json:"lastJobName,omitempty"`
+
+	// LastError error from the last attempt to start a job
+	//
+	// required: false
+	LastError string `json:"lastError,omitempty"`
+}
+
+// PipelineScheduleRequest describes a pipeline schedule to create or change
+// swagger:model PipelineScheduleRequest
+type PipelineScheduleRequest struct {
+	// Name of the schedule. Ignored when a schedule is changed
+	//
+	// required: false
+	// example: nightly-build
+	Name string `json:"name"`
+
+	// Cron expression with the fields minute, hour, day of month, month and day of week, in UTC
+	//
+	// required: true
+	// example: 0 2 * * mon-fri
+	Schedule string `json:"schedule"`
+
+	// Pipeline type of the started jobs: build, build-deploy, deploy or apply-config
+	//
+	// required: true
+	// example: build-deploy
+	Pipeline string `json:"pipeline"`
+
+	// GitRef branch or tag to build. Required for the build and build-deploy pipelines
+	//
+	// required: false
+	// example: main
+	GitRef string `json:"gitRef,omitempty"`
+
+	// GitRefType type of the GitRef: branch or tag. Defaults to branch
+	//
+	// required: false
+	// example: branch
+	GitRefType string `json:"gitRefType,omitempty"`
+
+	// ToEnvironment environment to deploy to. Required for the deploy pipeline
+	//
+	// required: false
+	// example: dev
+	ToEnvironment string `json:"toEnvironment,omitempty"`
+
+	// Enabled jobs are only started when the schedule is enabled. Defaults to true
+	//
+	// required: false
+	Enabled *bool `json:"enabled,omitempty"`
+}
@@ -0,0 +1,310 @@
+package pipelineschedules
+
+import (
+	"encoding/json"
+	"net/http"
+
+	pipelineScheduleModels "github.com/equinor/radix-api/api/pipelineschedules/models"
+	"github.com/equinor/radix-api/api/utils/token"
+	"github.com/equinor/radix-api/models"
+	"github.com/gorilla/mux"
+)
+
+const rootPath = "/applications/{appName}/pipelineschedules"
+
+type pipelineScheduleController struct {
+	*models.DefaultController
+	store *Store
+}
+
+// NewPipelineScheduleController Constructor
+func NewPipelineScheduleController(store *Store) models.Controller {
+	return &pipelineScheduleController{store: store}
+}
+
+// GetRoutes List the supported routes of this handler
+func (c *pipelineScheduleController) GetRoutes() models.Routes {
+	routes := models.Routes{
+		models.Route{
+			Path:        rootPath,
+			Method:      http.MethodGet,
+			HandlerFunc: c.GetPipelineSchedules,
+			TokenScope:  token.ScopeRead,
+		},
+		models.Route{
+			Path:        rootPath,
+			Method:      http.MethodPost,
+			HandlerFunc: c.CreatePipelineSchedule,
+		},
+		models.Route{
+			Path:        rootPath + "/{scheduleName}",
+			Method:      http.MethodGet,
+			HandlerFunc: c.GetPipelineSchedule,
+			TokenScope:  token.ScopeRead,
+		},
+		models.Route{
+			Path:        rootPath + "/{scheduleName}",
+			Method:      http.MethodPut,
+			HandlerFunc: c.UpdatePipelineSchedule,
+		},
+		models.Route{
+			Path:        rootPath + "/{scheduleName}",
+			Method:      http.MethodDelete,
+			HandlerFunc: c.DeletePipelineSchedule,
+		},
+	}
+
+	return routes
+}
+
+// GetPipelineSchedules List the pipeline schedules of the application
+func (c *pipelineScheduleController) GetPipelineSchedules(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/pipelineschedules application getPipelineSchedules
+	// ---
+	// summary: Lists the pipeline schedules of the application, sorted by name
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//        type: "array"
+	//        items:
+	//           "$ref": "#/definitions/PipelineSchedule"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	schedules, err := NewHandler(accounts, c.store).GetPipelineSchedules(r.Context(), appName)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, schedules)
+}
+
+// GetPipelineSchedule Get a pipeline schedule of the application
+func (c *pipelineScheduleController) GetPipelineSchedule(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation GET /applications/{appName}/pipelineschedules/{scheduleName} application getPipelineSchedule
+	// ---
+	// summary: Get a pipeline schedule of the application, with the time of the next run
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: scheduleName
+	//   in: path
+	//   description: name of the schedule
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Successful operation"
+	//     schema:
+	//        "$ref": "#/definitions/PipelineSchedule"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	scheduleName := mux.Vars(r)["scheduleName"]
+	schedule, err := NewHandler(accounts, c.store).GetPipelineSchedule(r.Context(), appName, scheduleName)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, schedule)
+}
+
+// CreatePipelineSchedule Create a pipeline schedule for the application
+func (c *pipelineScheduleController) CreatePipelineSchedule(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation POST /applications/{appName}/pipelineschedules application createPipelineSchedule
+	// ---
+	// summary: Create a schedule starting pipeline jobs for the application
+	// description: |
+	//   Jobs are started by radix-api when the cron expression of an enabled schedule matches, in UTC.
+	//   Only one job is started when several runs are missed.
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: pipelineSchedule
+	//   in: body
+	//   description: Name, cron expression and pipeline of the schedule
+	//   required: true
+	//   schema:
+	//       "$ref": "#/definitions/PipelineScheduleRequest"
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Schedule created"
+	//     schema:
+	//        "$ref": "#/definitions/PipelineSchedule"
+	//   "400":
+	//     description: "Invalid schedule, or a schedule with the name already exists"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	appName := mux.Vars(r)["appName"]
+	var request pipelineScheduleModels.PipelineScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	schedule, err := NewHandler(accounts, c.store).CreatePipelineSchedule(r.Context(), appName, request)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, schedule)
+}
+
+// UpdatePipelineSchedule Change a pipeline schedule of the application
+func (c *pipelineScheduleController) UpdatePipelineSchedule(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation PUT /applications/{appName}/pipelineschedules/{scheduleName} application updatePipelineSchedule
+	// ---
+	// summary: Change the cron expression, pipeline or enabled state of a pipeline schedule. The next run is counted from the time of the change
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: scheduleName
+	//   in: path
+	//   description: name of the schedule
+	//   type: string
+	//   required: true
+	// - name: pipelineSchedule
+	//   in: body
+	//   description: Cron expression and pipeline of the schedule
+	//   required: true
+	//   schema:
+	//       "$ref": "#/definitions/PipelineScheduleRequest"
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "200":
+	//     description: "Schedule changed"
+	//     schema:
+	//        "$ref": "#/definitions/PipelineSchedule"
+	//   "400":
+	//     description: "Invalid schedule"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	scheduleName := mux.Vars(r)["scheduleName"]
+	var request pipelineScheduleModels.PipelineScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	schedule, err := NewHandler(accounts, c.store).UpdatePipelineSchedule(r.Context(), appName, scheduleName, request)
+	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	c.JSONResponse(w, r, schedule)
+}
+
+// DeletePipelineSchedule Delete a pipeline schedule of the application
+func (c *pipelineScheduleController) DeletePipelineSchedule(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	// swagger:operation DELETE /applications/{appName}/pipelineschedules/{scheduleName} application deletePipelineSchedule
+	// ---
+	// summary: Delete a pipeline schedule of the application. Jobs started by the schedule are not affected
+	// parameters:
+	// - name: appName
+	//   in: path
+	//   description: name of Radix application
+	//   type: string
+	//   required: true
+	// - name: scheduleName
+	//   in: path
+	//   description: name of the schedule
+	//   type: string
+	//   required: true
+	// - name: Impersonate-User
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of test users (Required if Impersonate-Group is set)
+	//   type: string
+	//   required: false
+	// - name: Impersonate-Group
+	//   in: header
+	//   description: Works only with custom setup of cluster. Allow impersonation of a comma-separated list of test groups (Required if Impersonate-User is set)
+	//   type: string
+	//   required: false
+	// responses:
+	//   "204":
+	//     description: "Schedule deleted"
+	//   "401":
+	//     description: "Unauthorized"
+	//   "403":
+	//     description: "Forbidden"
+	//   "404":
+	//     description: "Not found"
+	appName := mux.Vars(r)["appName"]
+	scheduleName := mux.Vars(r)["scheduleName"]
+	if err := NewHandler(accounts, c.store).DeletePipelineSchedule(r.Context(), appName, scheduleName); err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,186 @@
+package pipelineschedules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/equinor/radix-api/api/applications"
+	applicationModels "github.com/equinor/radix-api/api/applications/models"
+	jobModels "github.com/equinor/radix-api/api/jobs/models"
+	"github.com/equinor/radix-api/api/kubequery"
+	"github.com/equinor/radix-api/api/middleware/maintenance"
+	"github.com/equinor/radix-api/api/utils/leader"
+	jobPipeline "github.com/equinor/radix-operator/pkg/apis/pipeline"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	radixclient "github.com/equinor/radix-operator/pkg/client/clientset/versioned"
+	"github.com/rs/zerolog/log"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const leaseName = "radix-api-pipeline-scheduler"
+
+// Scheduler starts the pipeline jobs of enabled pipeline schedules when they are due,
+// while the user who last changed the schedule is still administrator of the application. No jobs are started in maintenance mode
+type Scheduler struct {
+	store           *Store
+	kubeClient      kubernetes.Interface
+	radixClient     radixclient.Interface
+	maintenanceMode *maintenance.Mode
+	now             func() time.Time
+}
+
+// NewScheduler Constructor. The kubeClient must be allowed to create SubjectAccessReviews,
+// and the Radix client must be allowed to get RadixRegistrations and RadixApplications, and create RadixJobs
+func NewScheduler(store *Store, kubeClient kubernetes.Interface, radixClient radixclient.Interface, maintenanceMode *maintenance.Mode) *Scheduler {
+	return &Scheduler{store: store, kubeClient: kubeClient, radixClient: radixClient, maintenanceMode: maintenanceMode, now: time.Now}
+}
+
+// RunWithLeaderElection runs the scheduler in the replica holding the lease in the namespace, so each job is started by only one replica.
+// The replicas compete for the lease until the context is cancelled
+func (s *Scheduler) RunWithLeaderElection(ctx context.Context, kubeClient kubernetes.Interface, namespace, identity string, interval time.Duration) {
+	leader.Run(ctx, kubeClient, namespace, leaseName, identity, func(ctx context.Context) { s.Run(ctx, interval) })
+}
+
+// Run starts the due pipeline jobs every interval until the context is cancelled
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.StartDue(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to start scheduled pipeline jobs")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// StartDue starts a pipeline job for each enabled schedule where the next run has passed.
+// Only one job is started for a schedule which missed several runs, e.g. while no replica held the lease.
+// Schedules of deleted applications are deleted. No jobs are started while maintenance mode is enabled,
+// and a schedule which was due in maintenance mode starts one job after it is disabled
+func (s *Scheduler) StartDue(ctx context.Context) error {
+	if s.maintenanceMode.Status().Enabled {
+		log.Ctx(ctx).Info().Msg("Skipping scheduled pipeline jobs in maintenance mode")
+		return nil
+	}
+
+	schedules, err := s.store.List(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, schedule := range schedules {
+		if !schedule.Enabled {
+			continue
+		}
+		next, err := nextRun(schedule)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("skipping pipeline schedule %s of application %s", schedule.Name, schedule.AppName)
+			continue
+		}
+		now := s.now().UTC().Truncate(time.Second)
+		if next.IsZero() || now.Before(next) {
+			continue
+		}
+
+		if _, err := s.radixClient.RadixV1().RadixRegistrations().Get(ctx, schedule.AppName, metav1.GetOptions{}); kubeerrors.IsNotFound(err) {
+			log.Ctx(ctx).Info().Msgf("Deleting pipeline schedule %s of deleted application %s", schedule.Name, schedule.AppName)
+			if err := s.store.Delete(ctx, schedule.AppName, schedule.Name); err != nil && !kubeerrors.IsNotFound(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+
+		if err := s.start(ctx, schedule, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// start records the run before the job is started, so a schedule which cannot be stored does not start a job every interval.
+// The started job, or the error starting it, is recorded afterwards
+func (s *Scheduler) start(ctx context.Context, schedule Schedule, now time.Time) error {
+	_, err := s.store.Update(ctx, schedule.AppName, schedule.Name, func(stored *Schedule) {
+		stored.LastRun = &now
+		stored.LastJobName, stored.LastError = "", ""
+	})
+	if kubeerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	jobSummary, startErr := s.startJob(ctx, schedule)
+	_, err = s.store.Update(ctx, schedule.AppName, schedule.Name, func(stored *Schedule) {
+		if startErr != nil {
+			stored.LastError = startErr.Error()
+		} else {
+			stored.LastJobName = jobSummary.Name
+		}
+	})
+	var errs []error
+	if err != nil && !kubeerrors.IsNotFound(err) {
+		errs = append(errs, err)
+	}
+	if startErr != nil {
+		errs = append(errs, fmt.Errorf("failed to start pipeline job for schedule %s of application %s: %w", schedule.Name, schedule.AppName, startErr))
+	}
+	return errors.Join(errs...)
+}
+
+// startJob starts the job after the same validation as when the pipeline is triggered by a user
+func (s *Scheduler) startJob(ctx context.Context, schedule Schedule) (*jobModels.JobSummary, error) {
+	if err := s.requireAdmin(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	pipeline, err := jobPipeline.GetPipelineFromName(schedule.Pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	triggeredBy := fmt.Sprintf("pipeline schedule %s", schedule.Name)
+	var jobParameters *jobModels.JobParameters
+	switch pipeline.Type {
+	case v1.Build, v1.BuildDeploy:
+		jobParameters = applicationModels.PipelineParametersBuild{GitRef: schedule.GitRef, GitRefType: schedule.GitRefType, ToEnvironment: schedule.ToEnvironment, TriggeredBy: triggeredBy}.MapPipelineParametersBuildToJobParameter()
+		if err := applications.ValidatePipelineBuildJobParameters(ctx, s.radixClient, schedule.AppName, jobParameters); err != nil {
+			return nil, err
+		}
+	case v1.Deploy:
+		jobParameters = applicationModels.PipelineParametersDeploy{ToEnvironment: schedule.ToEnvironment, TriggeredBy: triggeredBy}.MapPipelineParametersDeployToJobParameter()
+		if err := applications.ValidatePipelineDeployJobParameters(jobParameters); err != nil {
+			return nil, err
+		}
+	default:
+		jobParameters = applicationModels.PipelineParametersApplyConfig{TriggeredBy: triggeredBy}.MapPipelineParametersApplyConfigToJobParameter()
+	}
+
+	log.Ctx(ctx).Info().Msgf("Starting %s pipeline job for schedule %s of application %s", schedule.Pipeline, schedule.Name, schedule.AppName)
+	return applications.HandleStartPipelineJob(ctx, s.radixClient, schedule.AppName, pipeline, jobParameters)
+}
+
+// requireAdmin returns an error when the user who last changed the schedule is no longer administrator of the application
+func (s *Scheduler) requireAdmin(ctx context.Context, schedule Schedule) error {
+	if schedule.UpdatedByUser == "" {
+		return errors.New("the user who last changed the schedule is unknown, the schedule must be changed by an administrator of the application")
+	}
+	isAdmin, err := kubequery.IsUserRadixApplicationAdmin(ctx, s.kubeClient, schedule.UpdatedByUser, nil, schedule.AppName)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return fmt.Errorf("%s, who last changed the schedule, is no longer administrator of the application", schedule.UpdatedBy)
+	}
+	return nil
+}
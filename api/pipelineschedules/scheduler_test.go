@@ -0,0 +1,126 @@
+package pipelineschedules
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/equinor/radix-api/api/middleware/maintenance"
+	pipelineScheduleModels "github.com/equinor/radix-api/api/pipelineschedules/models"
+	v1 "github.com/equinor/radix-operator/pkg/apis/radix/v1"
+	radixfake "github.com/equinor/radix-operator/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationapiv1 "k8s.io/api/authorization/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	testing2 "k8s.io/client-go/testing"
+)
+
+func Test_Scheduler_StartDue(t *testing.T) {
+	radixClient := radixfake.NewSimpleClientset( //nolint:staticcheck
+		&v1.RadixRegistration{ObjectMeta: metav1.ObjectMeta{Name: anyAppName}},
+		&v1.RadixApplication{ObjectMeta: metav1.ObjectMeta{Name: anyAppName, Namespace: "any-app-app"}, Spec: v1.RadixApplicationSpec{
+			Environments: []v1.Environment{{Name: "dev", Build: v1.EnvBuild{From: "main"}}},
+		}},
+	)
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(testing2.CreateAction).GetObject().(*authorizationapiv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Name == anyAppName && review.Spec.User == "any-user"
+		return true, review, nil
+	})
+	store := NewStore(kubeClient, "radix-api-prod")
+	updated := anyTime.Add(-24 * time.Hour)
+	schedule := func(appName, name, cronExpression string, enabled bool) Schedule {
+		return Schedule{AppName: appName, UpdatedByUser: "any-user", PipelineSchedule: pipelineScheduleModels.PipelineSchedule{
+			Name: name, Schedule: cronExpression, Pipeline: "build-deploy", GitRef: "main", Enabled: enabled, Updated: updated,
+		}}
+	}
+	formerAdmin := schedule(anyAppName, "former-admin", "0 2 * * *", true)
+	formerAdmin.UpdatedByUser = "former-admin-user"
+	unmappedBranch := schedule(anyAppName, "unmapped-branch", "0 2 * * *", true)
+	unmappedBranch.GitRef = "feature"
+	for _, s := range []Schedule{
+		schedule(anyAppName, "due", "0 2 * * *", true),
+		schedule(anyAppName, "not-due", "0 0 1 6 *", true),
+		schedule(anyAppName, "disabled", "0 2 * * *", false),
+		schedule(otherAppName, "deleted-app", "0 2 * * *", true),
+		formerAdmin,
+		unmappedBranch,
+	} {
+		require.NoError(t, store.Create(context.Background(), s))
+	}
+	maintenanceMode := maintenance.NewMode(true, "")
+	scheduler := NewScheduler(store, kubeClient, radixClient, maintenanceMode)
+	scheduler.now = func() time.Time { return anyTime }
+
+	require.NoError(t, scheduler.StartDue(context.Background()))
+	jobs, err := radixClient.RadixV1().RadixJobs("any-app-app").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, jobs.Items, "no jobs should be started in maintenance mode")
+
+	maintenanceMode.Set(false, "")
+	assert.Error(t, scheduler.StartDue(context.Background()), "the former-admin and unmapped-branch schedules should fail")
+	assert.NoError(t, scheduler.StartDue(context.Background()), "a schedule should only start one job for each run")
+
+	jobs, err = radixClient.RadixV1().RadixJobs("any-app-app").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, jobs.Items, 1)
+	job := jobs.Items[0]
+	assert.Equal(t, v1.BuildDeploy, job.Spec.PipeLineType)
+	assert.Equal(t, "main", job.Spec.Build.GitRef)
+	assert.Equal(t, "pipeline schedule due", job.Spec.TriggeredBy)
+
+	due, err := store.Get(context.Background(), anyAppName, "due")
+	require.NoError(t, err)
+	assert.Equal(t, &anyTime, due.LastRun)
+	assert.Equal(t, job.Name, due.LastJobName)
+	assert.Empty(t, due.LastError)
+	for _, name := range []string{"former-admin", "unmapped-branch"} {
+		failed, err := store.Get(context.Background(), anyAppName, name)
+		require.NoError(t, err)
+		assert.Equal(t, &anyTime, failed.LastRun)
+		assert.Empty(t, failed.LastJobName)
+		assert.NotEmpty(t, failed.LastError)
+	}
+	notDue, err := store.Get(context.Background(), anyAppName, "not-due")
+	require.NoError(t, err)
+	assert.Nil(t, notDue.LastRun)
+	_, err = store.Get(context.Background(), otherAppName, "deleted-app")
+	assert.True(t, kubeerrors.IsNotFound(err), "schedules of deleted applications should be deleted")
+}
+
+func Test_Scheduler_StartDue_RecordsRunWhenJobCannotBeRecorded(t *testing.T) {
+	radixClient := radixfake.NewSimpleClientset(&v1.RadixRegistration{ObjectMeta: metav1.ObjectMeta{Name: anyAppName}}) //nolint:staticcheck
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "subjectaccessreviews", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(testing2.CreateAction).GetObject().(*authorizationapiv1.SubjectAccessReview)
+		review.Status.Allowed = true
+		return true, review, nil
+	})
+	store := NewStore(kubeClient, "radix-api-prod")
+	require.NoError(t, store.Create(context.Background(), Schedule{AppName: anyAppName, UpdatedByUser: "any-user", PipelineSchedule: pipelineScheduleModels.PipelineSchedule{
+		Name: "due", Schedule: "0 2 * * *", Pipeline: "apply-config", Enabled: true, Updated: anyTime.Add(-24 * time.Hour),
+	}}))
+	updates := 0
+	kubeClient.PrependReactor("update", "configmaps", func(action testing2.Action) (handled bool, ret runtime.Object, err error) {
+		updates++
+		if updates > 1 {
+			return true, nil, errors.New("any error")
+		}
+		return false, nil, nil
+	})
+	scheduler := NewScheduler(store, kubeClient, radixClient, nil)
+	scheduler.now = func() time.Time { return anyTime }
+
+	assert.Error(t, scheduler.StartDue(context.Background()), "recording the started job should fail")
+	assert.NoError(t, scheduler.StartDue(context.Background()))
+
+	jobs, err := radixClient.RadixV1().RadixJobs("any-app-app").List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, jobs.Items, 1, "the run should be recorded before the job is started")
+}
@@ -0,0 +1,168 @@
+package pipelineschedules
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	pipelineScheduleModels "github.com/equinor/radix-api/api/pipelineschedules/models"
+	"github.com/rs/zerolog/log"
+	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	configMapNamePrefix     = "radix-pipeline-schedule-"
+	pipelineScheduleLabel   = "radix-api-pipeline-schedule"
+	appNameLabel            = "radix-app"
+	scheduleKey             = "schedule.json"
+	updatedByUserAnnotation = "radix.equinor.com/schedule-updated-by-user"
+)
+
+// Schedule a pipeline schedule of an application.
+// UpdatedByUser is the Kubernetes user who last changed the schedule, who must still be administrator of the application when a job is started
+type Schedule struct {
+	AppName       string `json:"-"`
+	UpdatedByUser string `json:"-"`
+	pipelineScheduleModels.PipelineSchedule
+}
+
+// Store stores pipeline schedules as ConfigMaps in a namespace
+type Store struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+// NewStore Constructor for Store
+func NewStore(client kubernetes.Interface, namespace string) *Store {
+	return &Store{client: client, namespace: namespace}
+}
+
+// List returns the schedules of the application sorted by name. The schedules of all applications are returned when appName is empty
+func (s *Store) List(ctx context.Context, appName string) ([]Schedule, error) {
+	set := labels.Set{pipelineScheduleLabel: "true"}
+	if appName != "" {
+		set[appNameLabel] = appName
+	}
+	configMaps, err := s.client.CoreV1().ConfigMaps(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: labels.SelectorFromSet(set).String()})
+	if err != nil {
+		return nil, err
+	}
+
+	schedules := make([]Schedule, 0, len(configMaps.Items))
+	for _, configMap := range configMaps.Items {
+		schedule, err := toSchedule(&configMap)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("skipping invalid pipeline schedule %s", configMap.Name)
+			continue
+		}
+		schedules = append(schedules, *schedule)
+	}
+	slices.SortFunc(schedules, func(a, b Schedule) int {
+		return strings.Compare(a.AppName+"/"+a.Name, b.AppName+"/"+b.Name)
+	})
+	return schedules, nil
+}
+
+// Get returns the schedule. A NotFound error is returned when the application has no schedule with the name
+func (s *Store) Get(ctx context.Context, appName, name string) (*Schedule, error) {
+	configMap, err := s.getConfigMap(ctx, appName, name)
+	if err != nil {
+		return nil, err
+	}
+	return toSchedule(configMap)
+}
+
+// Create stores a new schedule. An AlreadyExists error is returned when the application has a schedule with the same name
+func (s *Store) Create(ctx context.Context, schedule Schedule) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getConfigMapName(schedule.AppName, schedule.Name),
+			Namespace: s.namespace,
+			Labels: map[string]string{
+				pipelineScheduleLabel: "true",
+				appNameLabel:          schedule.AppName,
+			},
+		},
+	}
+	if err := setSchedule(configMap, schedule); err != nil {
+		return err
+	}
+	_, err := s.client.CoreV1().ConfigMaps(s.namespace).Create(ctx, configMap, metav1.CreateOptions{})
+	return err
+}
+
+// Update changes the stored schedule with the update function, which is called again when the schedule was changed concurrently
+func (s *Store) Update(ctx context.Context, appName, name string, update func(schedule *Schedule)) (*Schedule, error) {
+	var updated *Schedule
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := s.getConfigMap(ctx, appName, name)
+		if err != nil {
+			return err
+		}
+		schedule, err := toSchedule(configMap)
+		if err != nil {
+			return err
+		}
+		update(schedule)
+		if err := setSchedule(configMap, *schedule); err != nil {
+			return err
+		}
+		if _, err := s.client.CoreV1().ConfigMaps(s.namespace).Update(ctx, configMap, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		updated = schedule
+		return nil
+	})
+	return updated, err
+}
+
+// Delete deletes the schedule. A NotFound error is returned when the application has no schedule with the name
+func (s *Store) Delete(ctx context.Context, appName, name string) error {
+	configMap, err := s.getConfigMap(ctx, appName, name)
+	if err != nil {
+		return err
+	}
+	return s.client.CoreV1().ConfigMaps(s.namespace).Delete(ctx, configMap.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &configMap.UID}})
+}
+
+func (s *Store) getConfigMap(ctx context.Context, appName, name string) (*corev1.ConfigMap, error) {
+	configMap, err := s.client.CoreV1().ConfigMaps(s.namespace).Get(ctx, getConfigMapName(appName, name), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if configMap.Labels[pipelineScheduleLabel] != "true" || configMap.Labels[appNameLabel] != appName {
+		return nil, kubeerrors.NewNotFound(corev1.Resource("configmaps"), configMap.Name)
+	}
+	return configMap, nil
+}
+
+// getConfigMapName separates the application and schedule names with a dot, which is not allowed in any of them
+func getConfigMapName(appName, name string) string {
+	return configMapNamePrefix + appName + "." + name
+}
+
+func toSchedule(configMap *corev1.ConfigMap) (*Schedule, error) {
+	schedule := Schedule{AppName: configMap.Labels[appNameLabel], UpdatedByUser: configMap.Annotations[updatedByUserAnnotation]}
+	if err := json.Unmarshal([]byte(configMap.Data[scheduleKey]), &schedule.PipelineSchedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// setSchedule stores the schedule in the ConfigMap. The next run is calculated when the schedule is read, and is not stored
+func setSchedule(configMap *corev1.ConfigMap, schedule Schedule) error {
+	schedule.NextRun = nil
+	data, err := json.Marshal(schedule.PipelineSchedule)
+	if err != nil {
+		return err
+	}
+	configMap.Data = map[string]string{scheduleKey: string(data)}
+	configMap.Annotations = map[string]string{updatedByUserAnnotation: schedule.UpdatedByUser}
+	return nil
+}
@@ -3,10 +3,16 @@ package pods
 import (
 	"fmt"
 
+	"github.com/equinor/radix-api/api/utils/problem"
 	radixhttp "github.com/equinor/radix-common/net/http"
 )
 
+// Error codes of pod errors
+const (
+	CodePodNotFound problem.Code = "pod-not-found"
+)
+
 // PodNotFoundError Pod not found
 func PodNotFoundError(podName string) error {
-	return radixhttp.TypeMissingError(fmt.Sprintf("Pod %s not found", podName), nil)
+	return problem.WithCode(CodePodNotFound, radixhttp.TypeMissingError(fmt.Sprintf("Pod %s not found", podName), nil))
 }
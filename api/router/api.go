@@ -3,11 +3,16 @@ package router
 import (
 	"net/http"
 
+	"github.com/equinor/radix-api/api/audit"
 	"github.com/equinor/radix-api/api/middleware/auth"
 	"github.com/equinor/radix-api/api/middleware/logger"
+	"github.com/equinor/radix-api/api/middleware/maintenance"
+	"github.com/equinor/radix-api/api/middleware/ratelimit"
 	"github.com/equinor/radix-api/api/middleware/recovery"
 	"github.com/equinor/radix-api/api/utils"
+	"github.com/equinor/radix-api/api/utils/health"
 	"github.com/equinor/radix-api/api/utils/token"
+	"github.com/equinor/radix-api/api/utils/tracing"
 	"github.com/equinor/radix-api/api/utils/warningcollector"
 	"github.com/equinor/radix-api/models"
 	"github.com/equinor/radix-api/swaggerui"
@@ -20,27 +25,32 @@ const (
 )
 
 // NewAPIHandler Constructor function
-func NewAPIHandler(validator token.ValidatorInterface, kubeUtil utils.KubeUtil, controllers ...models.Controller) http.Handler {
+func NewAPIHandler(validator token.ValidatorInterface, kubeUtil utils.KubeUtil, rateLimiter *ratelimit.RateLimiter, auditSink audit.Sink, readinessChecker *health.ReadinessChecker, maintenanceMode *maintenance.Mode, impersonationPolicy *auth.ImpersonationPolicy, controllers ...models.Controller) http.Handler {
 	serveMux := http.NewServeMux()
 
-	serveMux.Handle("/health/", createHealthHandler())
+	serveMux.Handle("/health/", createHealthHandler(readinessChecker))
 	serveMux.Handle("/swaggerui/", createSwaggerHandler())
-	serveMux.Handle("/api/", createApiRouter(kubeUtil, controllers))
+	serveMux.Handle("/api/", createApiRouter(kubeUtil, rateLimiter, auditSink, maintenanceMode, controllers))
 
 	n := negroni.New(
 		recovery.NewMiddleware(),
 		logger.NewZerologRequestIdMiddleware(),
+		tracing.NewServerSpanMiddleware(),
 		logger.NewZerologRequestDetailsMiddleware(),
 		auth.NewAuthenticationMiddleware(validator),
 		auth.NewZerologAuthenticationDetailsMiddleware(),
+		auth.NewImpersonationMiddleware(impersonationPolicy),
 		logger.NewZerologResponseLoggerMiddleware(),
 	)
 	n.UseHandler(serveMux)
 
 	return n
 }
-func createApiRouter(kubeUtil utils.KubeUtil, controllers []models.Controller) *mux.Router {
+
+// createApiRouter registers the routes of the controllers. In maintenance mode, only GET routes and routes allowing unauthenticated users are served
+func createApiRouter(kubeUtil utils.KubeUtil, rateLimiter *ratelimit.RateLimiter, auditSink audit.Sink, maintenanceMode *maintenance.Mode, controllers []models.Controller) *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
+	serviceAccountClient, _, _, _, _, _ := kubeUtil.GetServerKubernetesClient()
 	for _, controller := range controllers {
 		for _, route := range controller.GetRoutes() {
 			path := apiVersionRoute + route.Path
@@ -55,9 +65,18 @@ func createApiRouter(kubeUtil utils.KubeUtil, controllers []models.Controller) *
 			)
 
 			n := negroni.New()
+			n.Use(tracing.NewRouteMiddleware(route.Method, path))
+			n.Use(ratelimit.NewRateLimitMiddleware(rateLimiter, route.Method+" "+path, ratelimit.Budget(route.RateLimit)))
 			n.Use(warningcollector.NewWarningCollectorMiddleware())
+			if route.Method != http.MethodGet && !route.AllowUnauthenticatedUsers {
+				n.Use(maintenance.NewMaintenanceMiddleware(maintenanceMode))
+			}
+			if route.Method != http.MethodGet {
+				n.Use(audit.NewAuditMiddleware(auditSink, route.Method, path))
+			}
 			if !route.AllowUnauthenticatedUsers {
 				n.Use(auth.NewAuthorizeRequiredMiddleware())
+				n.Use(auth.NewPersonalAccessTokenMiddleware(route.TokenScope, serviceAccountClient))
 			}
 			n.UseHandler(handler)
 			router.Handle(path, n).Methods(route.Method)
@@ -73,8 +92,11 @@ func createSwaggerHandler() http.Handler {
 	return swaggerui
 }
 
-func createHealthHandler() http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	})
+// createHealthHandler serves /health/live and /health/ready. Other paths are served as liveness for existing probes
+func createHealthHandler(readinessChecker *health.ReadinessChecker) http.Handler {
+	serveMux := http.NewServeMux()
+	serveMux.Handle("/health/live", health.NewLivenessHandler())
+	serveMux.Handle("/health/ready", health.NewReadinessHandler(readinessChecker))
+	serveMux.Handle("/health/", health.NewLivenessHandler())
+	return serveMux
 }
@@ -11,6 +11,12 @@ func (p *TestPrincipal) Token() string {
 func (p *TestPrincipal) Id() string            { return "test-id" }
 func (p *TestPrincipal) Name() string          { return "test-principal" }
 func (p *TestPrincipal) IsAuthenticated() bool { return p.authenticated }
+func (p *TestPrincipal) UserPrincipalName() string {
+	if p.authenticated {
+		return "test-principal"
+	}
+	return ""
+}
 
 func NewTestPrincipal(authenticated bool) *TestPrincipal {
 	return &TestPrincipal{authenticated}
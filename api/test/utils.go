@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 
+	"github.com/equinor/radix-api/api/utils/problem"
 	token "github.com/equinor/radix-api/api/utils/token"
 	authnmock "github.com/equinor/radix-api/api/utils/token/mock"
 	kedav2 "github.com/kedacore/keda/v2/pkg/generated/clientset/versioned"
@@ -70,7 +71,7 @@ func (tu *Utils) ExecuteUnAuthorizedRequest(method, endpoint string) <-chan *htt
 	go func() {
 		rr := httptest.NewRecorder()
 		defer close(response)
-		router.NewAPIHandler(tu.validator, NewKubeUtilMock(tu.kubeClient, tu.radixClient, tu.kedaClient, tu.secretProviderClient, tu.certClient, tu.tektonClient), tu.controllers...).ServeHTTP(rr, req)
+		router.NewAPIHandler(tu.validator, NewKubeUtilMock(tu.kubeClient, tu.radixClient, tu.kedaClient, tu.secretProviderClient, tu.certClient, tu.tektonClient), nil, nil, nil, nil, nil, tu.controllers...).ServeHTTP(rr, req)
 		response <- rr
 	}()
 
@@ -79,6 +80,11 @@ func (tu *Utils) ExecuteUnAuthorizedRequest(method, endpoint string) <-chan *htt
 
 // ExecuteRequestWithParameters Helper method to issue a http request with payload
 func (tu *Utils) ExecuteRequestWithParameters(method, endpoint string, parameters interface{}) <-chan *httptest.ResponseRecorder {
+	return tu.ExecuteRequestWithHeaders(method, endpoint, parameters, nil)
+}
+
+// ExecuteRequestWithHeaders Helper method to issue a http request with payload and additional headers
+func (tu *Utils) ExecuteRequestWithHeaders(method, endpoint string, parameters interface{}, headers http.Header) <-chan *httptest.ResponseRecorder {
 	var reader io.Reader
 
 	if parameters != nil {
@@ -89,12 +95,17 @@ func (tu *Utils) ExecuteRequestWithParameters(method, endpoint string, parameter
 	req, _ := http.NewRequest(method, endpoint, reader)
 	req.Header.Add("Authorization", getFakeToken())
 	req.Header.Add("Accept", "application/json")
+	for name, values := range headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
 
 	response := make(chan *httptest.ResponseRecorder)
 	go func() {
 		rr := httptest.NewRecorder()
 		defer close(response)
-		router.NewAPIHandler(tu.validator, NewKubeUtilMock(tu.kubeClient, tu.radixClient, tu.kedaClient, tu.secretProviderClient, tu.certClient, tu.tektonClient), tu.controllers...).ServeHTTP(rr, req)
+		router.NewAPIHandler(tu.validator, NewKubeUtilMock(tu.kubeClient, tu.radixClient, tu.kedaClient, tu.secretProviderClient, tu.certClient, tu.tektonClient), nil, nil, nil, nil, nil, tu.controllers...).ServeHTTP(rr, req)
 		response <- rr
 	}()
 
@@ -119,6 +130,18 @@ func GetErrorResponse(response *httptest.ResponseRecorder) (*radixhttp.Error, er
 	return errorResponse, nil
 }
 
+// GetProblemResponse Gets problem details response
+func GetProblemResponse(response *httptest.ResponseRecorder) (*problem.Problem, error) {
+	problemResponse := &problem.Problem{}
+	err := GetResponseBody(response, problemResponse)
+	if err != nil {
+		log.Logger.Error().Err(err).Msg("Failed to get response body")
+		return nil, err
+	}
+
+	return problemResponse, nil
+}
+
 // GetResponseBody Gets response payload as type
 func GetResponseBody(response *httptest.ResponseRecorder, target interface{}) error {
 	reader := bytes.NewReader(response.Body.Bytes()) // To allow read from response body multiple times
@@ -152,6 +175,11 @@ func (ku *kubeUtilMock) GetUserKubernetesClient(_ string, impersonation radixmod
 	return ku.kubeClient, ku.radixClient, ku.kedaClient, ku.secretProviderClient, ku.tektonClient, ku.certClient
 }
 
+// GetImpersonatedServerKubernetesClient Gets a kubefake client
+func (ku *kubeUtilMock) GetImpersonatedServerKubernetesClient(_ string, _ ...utils.RestClientConfigOption) (kubernetes.Interface, radixclient.Interface, kedav2.Interface, secretsstorevclient.Interface, tektonclient.Interface, certclient.Interface) {
+	return ku.kubeClient, ku.radixClient, ku.kedaClient, ku.secretProviderClient, ku.tektonClient, ku.certClient
+}
+
 // GetServerKubernetesClient Gets a kubefake client using the config of the running pod
 func (ku *kubeUtilMock) GetServerKubernetesClient(_ ...utils.RestClientConfigOption) (kubernetes.Interface, radixclient.Interface, kedav2.Interface, secretsstorevclient.Interface, tektonclient.Interface, certclient.Interface) {
 	return ku.kubeClient, ku.radixClient, ku.kedaClient, ku.secretProviderClient, ku.tektonClient, ku.certClient
@@ -24,6 +24,23 @@ func HasAccess(ctx context.Context, client kubernetes.Interface, resourceAttribu
 	return r.Status.Allowed, nil
 }
 
+// HasSubjectAccess checks if the user, with the groups, has access to a resource
+func HasSubjectAccess(ctx context.Context, client kubernetes.Interface, user string, groups []string, resourceAttributes *authorizationapi.ResourceAttributes) (bool, error) {
+	sar := authorizationapi.SubjectAccessReview{
+		Spec: authorizationapi.SubjectAccessReviewSpec{
+			ResourceAttributes: resourceAttributes,
+			User:               user,
+			Groups:             groups,
+		},
+	}
+
+	r, err := client.AuthorizationV1().SubjectAccessReviews().Create(ctx, &sar, metav1.CreateOptions{})
+	if err != nil {
+		return false, err
+	}
+	return r.Status.Allowed, nil
+}
+
 func postSelfSubjectAccessReviews(ctx context.Context, client kubernetes.Interface, sar authorizationapi.SelfSubjectAccessReview) (*authorizationapi.SelfSubjectAccessReview, error) {
 	return client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &sar, metav1.CreateOptions{})
 }
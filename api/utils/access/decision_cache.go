@@ -0,0 +1,126 @@
+package access
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	decisionCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "radix_api_access_decision_cache_requests_total",
+		Help: "The total number of access decisions read from the access decision cache, by result (hit or miss)",
+	}, []string{"result"})
+	decisionCacheEvictions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "radix_api_access_decision_cache_evictions_total",
+		Help: "The total number of access decisions evicted from the access decision cache because it was full",
+	})
+	decisionCacheEntries = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "radix_api_access_decision_cache_entries",
+		Help: "The number of access decisions in the access decision cache",
+	})
+)
+
+type decisionKey struct {
+	principal string
+	resource  string
+}
+
+type decision struct {
+	key     decisionKey
+	version string
+	allowed bool
+	expires time.Time
+}
+
+// DecisionCache caches access decisions for each principal and resource for a TTL, so the same access review
+// is not repeated for every request. The least recently used decisions are evicted when the cache is full.
+// A nil DecisionCache caches nothing.
+type DecisionCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[decisionKey]*list.Element
+	lru        *list.List
+	now        func() time.Time
+}
+
+// NewDecisionCache creates a DecisionCache with at most maxEntries decisions
+func NewDecisionCache(ttl time.Duration, maxEntries int) *DecisionCache {
+	return &DecisionCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[decisionKey]*list.Element),
+		lru:        list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get returns the decision for the principal and resource. Version identifies the state of the resource the decision
+// depends on, e.g. the users and groups with access. A decision made for another version is not returned
+func (c *DecisionCache) Get(principal, resource, version string) (allowed bool, ok bool) {
+	if c == nil {
+		return false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := decisionKey{principal: principal, resource: resource}
+	element, found := c.entries[key]
+	if !found {
+		decisionCacheRequests.WithLabelValues("miss").Inc()
+		return false, false
+	}
+	d := element.Value.(*decision)
+	if d.version != version || !c.now().Before(d.expires) {
+		c.remove(element)
+		decisionCacheRequests.WithLabelValues("miss").Inc()
+		return false, false
+	}
+	c.lru.MoveToFront(element)
+	decisionCacheRequests.WithLabelValues("hit").Inc()
+	return d.allowed, true
+}
+
+// Set stores the decision for the principal and the version of the resource
+func (c *DecisionCache) Set(principal, resource, version string, allowed bool) {
+	if c == nil || c.maxEntries <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := decisionKey{principal: principal, resource: resource}
+	d := &decision{key: key, version: version, allowed: allowed, expires: c.now().Add(c.ttl)}
+	if element, found := c.entries[key]; found {
+		element.Value = d
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(d)
+	for c.lru.Len() > c.maxEntries {
+		c.remove(c.lru.Back())
+		decisionCacheEvictions.Inc()
+	}
+	decisionCacheEntries.Set(float64(c.lru.Len()))
+}
+
+// Len returns the number of decisions in the cache, including expired decisions not yet removed
+func (c *DecisionCache) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+func (c *DecisionCache) remove(element *list.Element) {
+	c.lru.Remove(element)
+	delete(c.entries, element.Value.(*decision).key)
+	decisionCacheEntries.Set(float64(c.lru.Len()))
+}
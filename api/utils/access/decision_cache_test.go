@@ -0,0 +1,79 @@
+package access
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecisionCache_GetSet(t *testing.T) {
+	cache := NewDecisionCache(time.Minute, 10)
+
+	_, ok := cache.Get("user1", "app1", "v1")
+	assert.False(t, ok)
+
+	cache.Set("user1", "app1", "v1", true)
+	cache.Set("user2", "app1", "v1", false)
+
+	allowed, ok := cache.Get("user1", "app1", "v1")
+	assert.True(t, ok)
+	assert.True(t, allowed)
+	allowed, ok = cache.Get("user2", "app1", "v1")
+	assert.True(t, ok)
+	assert.False(t, allowed)
+	_, ok = cache.Get("user1", "app2", "v1")
+	assert.False(t, ok)
+}
+
+func TestDecisionCache_OtherVersionIsInvalidated(t *testing.T) {
+	cache := NewDecisionCache(time.Minute, 10)
+	cache.Set("user1", "app1", "v1", true)
+
+	_, ok := cache.Get("user1", "app1", "v2")
+	assert.False(t, ok)
+	_, ok = cache.Get("user1", "app1", "v1")
+	assert.False(t, ok, "decision should be removed when the version changes")
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestDecisionCache_Expires(t *testing.T) {
+	now := time.Now()
+	cache := NewDecisionCache(time.Minute, 10)
+	cache.now = func() time.Time { return now }
+	cache.Set("user1", "app1", "v1", true)
+
+	now = now.Add(59 * time.Second)
+	_, ok := cache.Get("user1", "app1", "v1")
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok = cache.Get("user1", "app1", "v1")
+	assert.False(t, ok)
+}
+
+func TestDecisionCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewDecisionCache(time.Minute, 2)
+	cache.Set("user1", "app1", "v1", true)
+	cache.Set("user1", "app2", "v1", true)
+	_, _ = cache.Get("user1", "app1", "v1")
+
+	cache.Set("user1", "app3", "v1", true)
+
+	assert.Equal(t, 2, cache.Len())
+	_, ok := cache.Get("user1", "app2", "v1")
+	assert.False(t, ok)
+	_, ok = cache.Get("user1", "app1", "v1")
+	assert.True(t, ok)
+	_, ok = cache.Get("user1", "app3", "v1")
+	assert.True(t, ok)
+}
+
+func TestDecisionCache_Nil(t *testing.T) {
+	var cache *DecisionCache
+	cache.Set("user1", "app1", "v1", true)
+
+	_, ok := cache.Get("user1", "app1", "v1")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
@@ -0,0 +1,170 @@
+// Package cron parses cron expressions with the five standard fields: minute, hour, day of month, month and day of week
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears limits the search for the next time, for expressions like "0 0 30 2 *" which never match
+const maxSearchYears = 5
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField     = field{name: "minute", min: 0, max: 59}
+	hourField       = field{name: "hour", min: 0, max: 23}
+	dayOfMonthField = field{name: "day of month", min: 1, max: 31}
+	monthField      = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Both 0 and 7 are Sunday
+	dayOfWeekField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule the times matching a cron expression, in UTC
+type Schedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// When both day of month and day of week are restricted, a day matching either of them matches
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// Parse parses a cron expression with the fields minute, hour, day of month, month and day of week,
+// or one of the descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly.
+// Fields can contain *, values, ranges, steps and comma separated lists, e.g. "*/15 6-18 * * mon-fri"
+func Parse(expression string) (*Schedule, error) {
+	expression = strings.TrimSpace(expression)
+	if standard, ok := descriptors[strings.ToLower(expression)]; ok {
+		expression = standard
+	}
+	values := strings.Fields(expression)
+	if len(values) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q, expected 5 fields, got %d", expression, len(values))
+	}
+
+	var schedule Schedule
+	var err error
+	if schedule.minute, err = minuteField.parse(values[0]); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = hourField.parse(values[1]); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfMonth, err = dayOfMonthField.parse(values[2]); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = monthField.parse(values[3]); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfWeek, err = dayOfWeekField.parse(values[4]); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+	schedule.anyDayOfMonth = values[2] == "*"
+	schedule.anyDayOfWeek = values[4] == "*"
+	return &schedule, nil
+}
+
+// Next returns the first time matching the schedule after t, or the zero time when no time matches within five years
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(maxSearchYears, 0, 0)
+	for next.Before(limit) {
+		switch {
+		case !has(s.month, int(next.Month())):
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !has(s.hour, next.Hour()):
+			next = next.Truncate(time.Hour).Add(time.Hour)
+		case !has(s.minute, next.Minute()):
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	dayOfMonth, dayOfWeek := has(s.dayOfMonth, t.Day()), has(s.dayOfWeek, int(t.Weekday()))
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
+
+// parse returns the values of the field as bits
+func (f field) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(value, ",") {
+		rangeValue, stepValue, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepValue); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepValue, f.name)
+			}
+		}
+
+		low, high := f.min, f.max
+		if rangeValue != "*" {
+			lowValue, highValue, isRange := strings.Cut(rangeValue, "-")
+			var err error
+			if low, err = f.parseValue(lowValue); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if high, err = f.parseValue(highValue); err != nil {
+					return 0, err
+				}
+			case !hasStep:
+				high = low
+			}
+		}
+		if low > high {
+			return 0, fmt.Errorf("invalid range %q in %s field", rangeValue, f.name)
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f field) parseValue(value string) (int, error) {
+	if v, ok := f.names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, expected %d-%d", value, f.name, f.min, f.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Next(t *testing.T) {
+	// 2024-05-01 is a Wednesday
+	from := time.Date(2024, 5, 1, 10, 30, 15, 0, time.UTC)
+	scenarios := map[string]time.Time{
+		"* * * * *":           time.Date(2024, 5, 1, 10, 31, 0, 0, time.UTC),
+		"*/15 * * * *":        time.Date(2024, 5, 1, 10, 45, 0, 0, time.UTC),
+		"0 2 * * *":           time.Date(2024, 5, 2, 2, 0, 0, 0, time.UTC),
+		"@daily":              time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+		"@hourly":             time.Date(2024, 5, 1, 11, 0, 0, 0, time.UTC),
+		"30 22 * * mon-fri":   time.Date(2024, 5, 1, 22, 30, 0, 0, time.UTC),
+		"0 0 * * 7":           time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC),
+		"0 0 * * sat,sun":     time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC),
+		"0 12 15 * *":         time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC),
+		"0 0 1 jan *":         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 29 2 *":          time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+		"0 6-18/6 * * *":      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		"0 0 10 * fri":        time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC),
+		"0 0 30 2 *":          {},
+		"5,10 10/4 1-3 5 wed": time.Date(2024, 5, 1, 14, 5, 0, 0, time.UTC),
+	}
+
+	for expression, expected := range scenarios {
+		t.Run(expression, func(t *testing.T) {
+			schedule, err := Parse(expression)
+			require.NoError(t, err)
+			assert.Equal(t, expected, schedule.Next(from))
+		})
+	}
+}
+
+func Test_Parse_Invalid(t *testing.T) {
+	for _, expression := range []string{"", "* * * *", "* * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@often"} {
+		t.Run(expression, func(t *testing.T) {
+			_, err := Parse(expression)
+			assert.Error(t, err)
+		})
+	}
+}
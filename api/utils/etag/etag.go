@@ -0,0 +1,39 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// FromBody returns a strong entity tag for the response body
+func FromBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+}
+
+// IsConditionalRequest returns true if the request method allows answering 304 Not Modified
+func IsConditionalRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet || r.Method == http.MethodHead
+}
+
+// NoneMatch returns false if the If-None-Match header value matches etag, i.e. the client already has the current representation.
+// Comparison is weak as described in RFC 9110 section 13.1.2, so W/ prefixed tags from clients or proxies also match.
+func NoneMatch(ifNoneMatch, etag string) bool {
+	ifNoneMatch = strings.TrimSpace(ifNoneMatch)
+	if ifNoneMatch == "" {
+		return true
+	}
+	if ifNoneMatch == "*" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return false
+		}
+	}
+	return true
+}
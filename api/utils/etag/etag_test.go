@@ -0,0 +1,46 @@
+package etag_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/equinor/radix-api/api/utils/etag"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FromBody(t *testing.T) {
+	tag := etag.FromBody([]byte(`{"name":"any"}`))
+	assert.Equal(t, tag, etag.FromBody([]byte(`{"name":"any"}`)))
+	assert.NotEqual(t, tag, etag.FromBody([]byte(`{"name":"other"}`)))
+	assert.Regexp(t, `^"[A-Za-z0-9_-]+"$`, tag)
+}
+
+func Test_NoneMatch(t *testing.T) {
+	const tag = `"abc"`
+	scenarios := map[string]struct {
+		ifNoneMatch string
+		expected    bool
+	}{
+		"empty header":         {ifNoneMatch: "", expected: true},
+		"same tag":             {ifNoneMatch: `"abc"`, expected: false},
+		"weak same tag":        {ifNoneMatch: `W/"abc"`, expected: false},
+		"other tag":            {ifNoneMatch: `"def"`, expected: true},
+		"tag in list":          {ifNoneMatch: `"def", "abc"`, expected: false},
+		"tag not in list":      {ifNoneMatch: `"def", "ghi"`, expected: true},
+		"wildcard":             {ifNoneMatch: "*", expected: false},
+		"unquoted is no match": {ifNoneMatch: "abc", expected: true},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, scenario.expected, etag.NoneMatch(scenario.ifNoneMatch, tag))
+		})
+	}
+}
+
+func Test_IsConditionalRequest(t *testing.T) {
+	for method, expected := range map[string]bool{http.MethodGet: true, http.MethodHead: true, http.MethodPost: false, http.MethodPut: false, http.MethodDelete: false} {
+		r, _ := http.NewRequest(method, "/any", nil)
+		assert.Equal(t, expected, etag.IsConditionalRequest(r), method)
+	}
+}
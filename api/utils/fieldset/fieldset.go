@@ -0,0 +1,100 @@
+package fieldset
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/equinor/radix-api/api/utils/problem"
+	radixhttp "github.com/equinor/radix-common/net/http"
+)
+
+const fieldsParam = "fields"
+
+// CodeInvalidFields Error code when the fields query parameter has unknown fields
+const CodeInvalidFields problem.Code = "invalid-fields"
+
+// Fields is the set of top level fields selected with the fields query parameter, by their JSON names.
+// A nil Fields selects all fields
+type Fields map[string]struct{}
+
+// All selects all fields
+var All Fields
+
+// GetFields reads the comma separated list of fields in the fields query parameter.
+// The names must be JSON names of top level fields in model. All is returned when the parameter is not set
+func GetFields(r *http.Request, model any) (Fields, error) {
+	param := strings.TrimSpace(r.FormValue(fieldsParam))
+	if param == "" {
+		return All, nil
+	}
+
+	validNames := getJSONFieldNames(reflect.TypeOf(model))
+	fields := Fields{}
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		if !slices.Contains(validNames, name) {
+			return nil, problem.WithCode(CodeInvalidFields, radixhttp.ValidationError("Fields", fmt.Sprintf("unknown field %q, valid fields are %s", name, strings.Join(validNames, ", "))))
+		}
+		fields[name] = struct{}{}
+	}
+	return fields, nil
+}
+
+// Has returns true when any of the fields are selected
+func (f Fields) Has(names ...string) bool {
+	if f == nil {
+		return true
+	}
+	for _, name := range names {
+		if _, ok := f[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Select returns the selected top level fields of v. v is returned unchanged when all fields are selected
+func Select(v any, fields Fields) (any, error) {
+	if fields == nil {
+		return v, nil
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(body, &all); err != nil {
+		return nil, err
+	}
+	selected := make(map[string]json.RawMessage, len(fields))
+	for name, value := range all {
+		if fields.Has(name) {
+			selected[name] = value
+		}
+	}
+	return selected, nil
+}
+
+func getJSONFieldNames(t reflect.Type) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
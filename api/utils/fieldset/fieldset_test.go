@@ -0,0 +1,52 @@
+package fieldset_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/equinor/radix-api/api/utils/fieldset"
+	radixhttp "github.com/equinor/radix-common/net/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type anyModel struct {
+	Name     string   `json:"name"`
+	Status   string   `json:"status,omitempty"`
+	Items    []string `json:"items"`
+	Internal string   `json:"-"`
+}
+
+func TestGetFields(t *testing.T) {
+	fields, err := fieldset.GetFields(httptest.NewRequest("GET", "/", nil), anyModel{})
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+	assert.True(t, fields.Has("items"), "all fields are selected when not set")
+
+	fields, err = fieldset.GetFields(httptest.NewRequest("GET", "/?fields=name,%20status", nil), &anyModel{})
+	require.NoError(t, err)
+	assert.True(t, fields.Has("name"))
+	assert.True(t, fields.Has("status"))
+	assert.True(t, fields.Has("items", "status"))
+	assert.False(t, fields.Has("items"))
+
+	_, err = fieldset.GetFields(httptest.NewRequest("GET", "/?fields=name,Internal", nil), anyModel{})
+	var validationErr *radixhttp.Error
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, `unknown field "Internal", valid fields are name, status, items`, validationErr.Message)
+}
+
+func TestSelect(t *testing.T) {
+	model := anyModel{Name: "any", Status: "Running", Items: []string{"a"}}
+
+	actual, err := fieldset.Select(model, fieldset.All)
+	require.NoError(t, err)
+	assert.Equal(t, model, actual)
+
+	actual, err = fieldset.Select(&model, fieldset.Fields{"name": {}, "items": {}})
+	require.NoError(t, err)
+	body, err := json.Marshal(actual)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"any","items":["a"]}`, string(body))
+}
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// NewKubernetesCheck checks that the Kubernetes API server is ready
+func NewKubernetesCheck(client kubernetes.Interface) Check {
+	return Check{
+		Name: "kubernetes",
+		Func: func(ctx context.Context) error {
+			return client.Discovery().RESTClient().Get().AbsPath("/readyz").Do(ctx).Error()
+		},
+	}
+}
+
+// NewPrometheusCheck checks that the Prometheus server at prometheusUrl is ready.
+// The check is optional, since Prometheus is only used by the metrics routes
+func NewPrometheusCheck(httpClient *http.Client, prometheusUrl string) Check {
+	return Check{
+		Name:     "prometheus",
+		Optional: true,
+		Func: func(ctx context.Context) error {
+			_, err := get(ctx, httpClient, strings.TrimSuffix(prometheusUrl, "/")+"/-/ready")
+			return err
+		},
+	}
+}
+
+// NewJWKSCheck checks that the signing keys of the OIDC issuer can be fetched from the jwks_uri in its discovery document
+func NewJWKSCheck(httpClient *http.Client, name string, issuer url.URL) Check {
+	return Check{
+		Name: "oidc-" + name,
+		Func: func(ctx context.Context) error {
+			body, err := get(ctx, httpClient, strings.TrimSuffix(issuer.String(), "/")+"/.well-known/openid-configuration")
+			if err != nil {
+				return err
+			}
+			var discovery struct {
+				JWKSURI string `json:"jwks_uri"`
+			}
+			if err := json.Unmarshal(body, &discovery); err != nil {
+				return fmt.Errorf("failed to parse OIDC discovery document: %w", err)
+			}
+			if discovery.JWKSURI == "" {
+				return errors.New("OIDC discovery document has no jwks_uri")
+			}
+			_, err = get(ctx, httpClient, discovery.JWKSURI)
+			return err
+		},
+	}
+}
+
+func get(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+	return body, nil
+}
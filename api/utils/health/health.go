@@ -0,0 +1,162 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Status of a check or of all checks
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusFail Status = "fail"
+)
+
+// CheckFunc returns an error when the dependency is not available
+type CheckFunc func(ctx context.Context) error
+
+// Check a named dependency check
+type Check struct {
+	Name string
+	Func CheckFunc
+	// Optional checks are reported, but do not fail readiness. Used for dependencies only some routes need
+	Optional bool
+}
+
+// CheckResult result of a single check
+type CheckResult struct {
+	Name       string `json:"name"`
+	Status     Status `json:"status"`
+	Optional   bool   `json:"optional,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// Report results of all checks
+type Report struct {
+	Status    Status        `json:"status"`
+	CheckedAt time.Time     `json:"checkedAt"`
+	Checks    []CheckResult `json:"checks"`
+}
+
+// ReadinessChecker runs the dependency checks for readiness, and caches the report so frequent probes do not overload the dependencies
+type ReadinessChecker struct {
+	checks        []Check
+	timeout       time.Duration
+	cacheDuration time.Duration
+	now           func() time.Time
+
+	mu   sync.Mutex
+	last *Report
+}
+
+// NewReadinessChecker Constructor for ReadinessChecker. Each check must complete within timeout,
+// and the report is reused for cacheDuration
+func NewReadinessChecker(timeout, cacheDuration time.Duration, checks ...Check) *ReadinessChecker {
+	return &ReadinessChecker{
+		checks:        checks,
+		timeout:       timeout,
+		cacheDuration: cacheDuration,
+		now:           time.Now,
+	}
+}
+
+// Check returns the cached report, or runs all checks concurrently when the cached report has expired.
+// Concurrent calls wait for the same run
+func (c *ReadinessChecker) Check(ctx context.Context) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.last != nil && c.now().Sub(c.last.CheckedAt) < c.cacheDuration {
+		return *c.last
+	}
+
+	report := c.run(context.WithoutCancel(ctx))
+	c.last = &report
+	return report
+}
+
+// SetChecks replaces the checks, e.g. when the config of a dependency is reloaded. The cached report is discarded
+func (c *ReadinessChecker) SetChecks(checks ...Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = checks
+	c.last = nil
+}
+
+func (c *ReadinessChecker) run(ctx context.Context) Report {
+	report := Report{Status: StatusOK, CheckedAt: c.now(), Checks: make([]CheckResult, len(c.checks))}
+
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = c.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != StatusOK {
+			if !result.Optional {
+				report.Status = StatusFail
+			}
+			log.Ctx(ctx).Warn().Str("check", result.Name).Bool("optional", result.Optional).Str("error", result.Error).Msg("readiness check failed")
+		}
+	}
+	return report
+}
+
+func (c *ReadinessChecker) runCheck(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Func(ctx)
+	result := CheckResult{Name: check.Name, Status: StatusOK, Optional: check.Optional, DurationMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// NewLivenessHandler responds 200 OK as long as the process is able to serve requests
+func NewLivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, r, http.StatusOK, Report{Status: StatusOK, CheckedAt: time.Now(), Checks: []CheckResult{}})
+	})
+}
+
+// NewReadinessHandler responds with the report of the checker, with status 503 Service Unavailable when any check that is not optional fails.
+// A nil checker is always ready
+func NewReadinessHandler(checker *ReadinessChecker) http.Handler {
+	if checker == nil {
+		return NewLivenessHandler()
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := checker.Check(r.Context())
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, r, status, report)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, r *http.Request, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Ctx(r.Context()).Err(err).Msg("failed to write response")
+	}
+}
@@ -0,0 +1,142 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadinessChecker_CachesReport(t *testing.T) {
+	var calls atomic.Int32
+	failing := false
+	checker := NewReadinessChecker(time.Second, 10*time.Second,
+		Check{Name: "any", Func: func(ctx context.Context) error {
+			calls.Add(1)
+			if failing {
+				return errors.New("any error")
+			}
+			return nil
+		}},
+	)
+	now := time.Now()
+	checker.now = func() time.Time { return now }
+
+	report := checker.Check(context.Background())
+	assert.Equal(t, StatusOK, report.Status)
+	failing = true
+	report = checker.Check(context.Background())
+	assert.Equal(t, StatusOK, report.Status, "cached report should be returned")
+	assert.Equal(t, int32(1), calls.Load())
+
+	now = now.Add(10 * time.Second)
+	report = checker.Check(context.Background())
+	assert.Equal(t, StatusFail, report.Status)
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, CheckResult{Name: "any", Status: StatusFail, Error: "any error"}, report.Checks[0])
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestReadinessChecker_SetChecksDiscardsCachedReport(t *testing.T) {
+	checker := NewReadinessChecker(time.Second, time.Hour, Check{Name: "any", Func: func(context.Context) error { return nil }})
+	assert.Equal(t, StatusOK, checker.Check(context.Background()).Status)
+
+	checker.SetChecks(Check{Name: "other", Func: func(context.Context) error { return errors.New("any error") }})
+	report := checker.Check(context.Background())
+	assert.Equal(t, StatusFail, report.Status)
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, "other", report.Checks[0].Name)
+}
+
+func TestReadinessChecker_OptionalCheckDoesNotFailReadiness(t *testing.T) {
+	checker := NewReadinessChecker(time.Second, 0,
+		Check{Name: "required", Func: func(context.Context) error { return nil }},
+		Check{Name: "optional", Optional: true, Func: func(context.Context) error { return errors.New("any error") }},
+	)
+
+	report := checker.Check(context.Background())
+	assert.Equal(t, StatusOK, report.Status)
+	require.Len(t, report.Checks, 2)
+	assert.Contains(t, report.Checks, CheckResult{Name: "optional", Status: StatusFail, Optional: true, Error: "any error"})
+}
+
+func TestReadinessChecker_TimesOutChecks(t *testing.T) {
+	checker := NewReadinessChecker(10*time.Millisecond, 0,
+		Check{Name: "slow", Func: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		Check{Name: "fast", Func: func(ctx context.Context) error { return nil }},
+	)
+
+	report := checker.Check(context.Background())
+	assert.Equal(t, StatusFail, report.Status)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, "slow", report.Checks[0].Name)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks[0].Error)
+	assert.Equal(t, "fast", report.Checks[1].Name)
+	assert.Equal(t, StatusOK, report.Checks[1].Status)
+}
+
+func TestReadinessHandler(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		checker        *ReadinessChecker
+		expectedStatus int
+	}{
+		{name: "no checker", checker: nil, expectedStatus: http.StatusOK},
+		{name: "all checks ok", checker: NewReadinessChecker(time.Second, 0, Check{Name: "any", Func: func(context.Context) error { return nil }}), expectedStatus: http.StatusOK},
+		{name: "failing check", checker: NewReadinessChecker(time.Second, 0, Check{Name: "any", Func: func(context.Context) error { return errors.New("any error") }}), expectedStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, ts := range scenarios {
+		t.Run(ts.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			NewReadinessHandler(ts.checker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+			assert.Equal(t, ts.expectedStatus, w.Code)
+			var report Report
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		})
+	}
+}
+
+func TestJWKSCheck(t *testing.T) {
+	jwksStatus := http.StatusOK
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/issuer/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jwks_uri":"` + server.URL + `/keys"}`))
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(jwksStatus)
+	})
+	issuer, err := url.Parse(server.URL + "/issuer")
+	require.NoError(t, err)
+
+	check := NewJWKSCheck(server.Client(), "any", *issuer)
+	assert.Equal(t, "oidc-any", check.Name)
+	assert.NoError(t, check.Func(context.Background()))
+
+	jwksStatus = http.StatusInternalServerError
+	assert.ErrorContains(t, check.Func(context.Background()), "500 Internal Server Error")
+}
+
+func TestPrometheusCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/-/ready", r.URL.Path)
+	}))
+	defer server.Close()
+
+	check := NewPrometheusCheck(server.Client(), server.URL+"/")
+	assert.True(t, check.Optional)
+	assert.NoError(t, check.Func(context.Background()))
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"strings"
 	"time"
 )
 
@@ -36,3 +37,15 @@ func IsBefore(j, i Job) bool {
 
 	return jCreated.Before(*iCreated)
 }
+
+// CompareNewestFirst Compares job-a and job-b for sorting newest first. Jobs created at the same time are ordered by name,
+// so the order is the same on every call
+func CompareNewestFirst(a, b Job, aName, bName string) int {
+	switch {
+	case IsBefore(b, a):
+		return -1
+	case IsBefore(a, b):
+		return 1
+	}
+	return strings.Compare(aName, bName)
+}
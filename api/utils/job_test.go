@@ -48,3 +48,14 @@ func createTime(timestamp string) time.Time {
 	t, _ := time.Parse(time.RFC3339, timestamp)
 	return t
 }
+
+func TestCompareNewestFirst(t *testing.T) {
+	job1 := jobmodels.JobSummary{Name: "job-1", Created: createTime("2019-08-26T12:56:48Z")}
+	job2 := jobmodels.JobSummary{Name: "job-2", Created: createTime("2019-08-26T12:56:49Z")}
+	job3 := jobmodels.JobSummary{Name: "job-3", Created: createTime("2019-08-26T12:56:48Z")}
+
+	assert.Equal(t, 1, CompareNewestFirst(&job1, &job2, job1.Name, job2.Name))
+	assert.Equal(t, -1, CompareNewestFirst(&job2, &job1, job2.Name, job1.Name))
+	assert.Equal(t, -1, CompareNewestFirst(&job1, &job3, job1.Name, job3.Name), "jobs created at the same time should be ordered by name")
+	assert.Equal(t, 1, CompareNewestFirst(&job3, &job1, job3.Name, job1.Name))
+}
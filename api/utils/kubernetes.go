@@ -7,6 +7,7 @@ import (
 	certclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	"github.com/equinor/radix-api/api/metrics"
 	"github.com/equinor/radix-api/api/utils/logs"
+	"github.com/equinor/radix-api/api/utils/tracing"
 	"github.com/equinor/radix-api/api/utils/warningcollector"
 	radixmodels "github.com/equinor/radix-common/models"
 	radixclient "github.com/equinor/radix-operator/pkg/client/clientset/versioned"
@@ -41,6 +42,7 @@ func WithBurst(burst int) RestClientConfigOption {
 type KubeUtil interface {
 	GetUserKubernetesClient(string, radixmodels.Impersonation, ...RestClientConfigOption) (kubernetes.Interface, radixclient.Interface, kedav2.Interface, secretproviderclient.Interface, tektonclient.Interface, certclient.Interface)
 	GetServerKubernetesClient(...RestClientConfigOption) (kubernetes.Interface, radixclient.Interface, kedav2.Interface, secretproviderclient.Interface, tektonclient.Interface, certclient.Interface)
+	GetImpersonatedServerKubernetesClient(string, ...RestClientConfigOption) (kubernetes.Interface, radixclient.Interface, kedav2.Interface, secretproviderclient.Interface, tektonclient.Interface, certclient.Interface)
 }
 
 type kubeUtil struct{}
@@ -70,6 +72,13 @@ func (ku *kubeUtil) GetServerKubernetesClient(options ...RestClientConfigOption)
 	return getKubernetesClientFromConfig(config)
 }
 
+// GetImpersonatedServerKubernetesClient Gets a kubernetes client using the config of host or pod, impersonating the user without groups
+func (ku *kubeUtil) GetImpersonatedServerKubernetesClient(user string, options ...RestClientConfigOption) (kubernetes.Interface, radixclient.Interface, kedav2.Interface, secretproviderclient.Interface, tektonclient.Interface, certclient.Interface) {
+	config := getServerClientConfig(options)
+	config.Impersonate = restclient.ImpersonationConfig{UserName: user}
+	return getKubernetesClientFromConfig(config)
+}
+
 func getUserClientConfig(token string, impersonation radixmodels.Impersonation, options []RestClientConfigOption) *restclient.Config {
 	cfg := getServerClientConfig(options)
 
@@ -119,6 +128,7 @@ func addCommonConfigs(config *restclient.Config, options []RestClientConfigOptio
 	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
 		return promhttp.InstrumentRoundTripperDuration(nrRequests, rt)
 	})
+	config.Wrap(tracing.NewRoundtripTracer("kubernetes"))
 
 	config.WarningHandlerWithContext = warningcollector.NewKubernetesWarningHandler()
 
@@ -0,0 +1,40 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// Run calls run in the replica holding the lease in the namespace, so background work is done by only one replica.
+// The context passed to run is cancelled when the lease is lost. The replicas compete for the lease until the context is cancelled
+func Run(ctx context.Context, kubeClient kubernetes.Interface, namespace, leaseName, identity string, run func(ctx context.Context)) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: leaseName, Namespace: namespace},
+		Client:     kubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+	for ctx.Err() == nil {
+		leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			LeaseDuration:   15 * time.Second,
+			RenewDeadline:   10 * time.Second,
+			RetryPeriod:     2 * time.Second,
+			ReleaseOnCancel: true,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(ctx context.Context) {
+					log.Ctx(ctx).Info().Msgf("Started leading %s as %s", leaseName, identity)
+					run(ctx)
+				},
+				OnStoppedLeading: func() {
+					log.Ctx(ctx).Info().Msgf("Stopped leading %s as %s", leaseName, identity)
+				},
+			},
+		})
+	}
+}
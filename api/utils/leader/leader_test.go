@@ -0,0 +1,25 @@
+package leader
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_Run(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	ctx, cancel := context.WithCancel(context.Background())
+	var holder string
+	Run(ctx, kubeClient, "radix-api-prod", "any-lease", "replica-1", func(ctx context.Context) {
+		lease, err := kubeClient.CoordinationV1().Leases("radix-api-prod").Get(ctx, "any-lease", metav1.GetOptions{})
+		require.NoError(t, err)
+		holder = *lease.Spec.HolderIdentity
+		cancel()
+	})
+
+	assert.Equal(t, "replica-1", holder, "run should be called while holding the lease")
+}
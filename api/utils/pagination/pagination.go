@@ -0,0 +1,111 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/equinor/radix-api/api/utils/problem"
+	radixhttp "github.com/equinor/radix-common/net/http"
+)
+
+const (
+	// MaxLimit is the largest page size a client can request
+	MaxLimit = 500
+
+	limitParam    = "limit"
+	continueParam = "continue"
+
+	// CodeInvalidLimit Error code when the limit query parameter is invalid
+	CodeInvalidLimit problem.Code = "invalid-limit"
+	// CodeInvalidContinue Error code when the continue query parameter is invalid
+	CodeInvalidContinue problem.Code = "invalid-continue"
+)
+
+// Params paging parameters of a list request
+type Params struct {
+	// Limit maximum number of items to return. Zero means MaxLimit
+	Limit int
+	// Continue cursor returned as Next in the previous page
+	Continue string
+}
+
+// Page is the response envelope for a paged list request
+type Page[T any] struct {
+	// Items in the page
+	Items []T `json:"items"`
+	// Next is the cursor to pass in the continue query parameter to get the next page. Empty when there are no more items
+	Next string `json:"next,omitempty"`
+}
+
+// GetParams reads the limit and continue query parameters from the request
+func GetParams(r *http.Request) (Params, error) {
+	var params Params
+	if limit := strings.TrimSpace(r.FormValue(limitParam)); limit != "" {
+		val, err := strconv.Atoi(limit)
+		if err != nil || val < 1 || val > MaxLimit {
+			return Params{}, problem.WithCode(CodeInvalidLimit, radixhttp.ValidationError("Pagination", fmt.Sprintf("limit must be a number between 1 and %d", MaxLimit)))
+		}
+		params.Limit = val
+	}
+	params.Continue = strings.TrimSpace(r.FormValue(continueParam))
+	return params, nil
+}
+
+// Paginate returns the page of items selected by params.
+// The page has at most params.Limit items, or MaxLimit items when no limit is set.
+// The items must be sorted by a stable order, and keyFunc must return a key that is unique for each item.
+// The cursor in Page.Next is the key of the last item in the page, so the next page starts after it
+// even if items have been added to the list between the calls. When the item of the cursor has been removed,
+// a CodeInvalidContinue error is returned, and the client must restart listing without continue.
+func Paginate[T any](items []T, params Params, keyFunc func(T) string) (*Page[T], error) {
+	start := 0
+	if len(params.Continue) > 0 {
+		key, err := decodeCursor(params.Continue)
+		if err != nil {
+			return nil, err
+		}
+		index := -1
+		for i, item := range items {
+			if keyFunc(item) == key {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, invalidContinueError()
+		}
+		start = index + 1
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = MaxLimit
+	}
+	end := min(start+limit, len(items))
+
+	page := Page[T]{Items: make([]T, 0, end-start)}
+	page.Items = append(page.Items, items[start:end]...)
+	if end < len(items) && end > start {
+		page.Next = encodeCursor(keyFunc(items[end-1]))
+	}
+	return &page, nil
+}
+
+func encodeCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(key) == 0 {
+		return "", invalidContinueError()
+	}
+	return string(key), nil
+}
+
+func invalidContinueError() error {
+	return problem.WithCode(CodeInvalidContinue, radixhttp.ValidationError("Pagination", "continue is invalid or refers to an item that no longer exists, restart listing without continue"))
+}
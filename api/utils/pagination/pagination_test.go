@@ -0,0 +1,103 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/equinor/radix-api/api/utils/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func identity(s string) string { return s }
+
+func Test_GetParams(t *testing.T) {
+	scenarios := map[string]struct {
+		query       url.Values
+		expected    pagination.Params
+		expectError bool
+	}{
+		"no parameters":        {query: url.Values{}, expected: pagination.Params{}},
+		"limit":                {query: url.Values{"limit": {"10"}}, expected: pagination.Params{Limit: 10}},
+		"limit and continue":   {query: url.Values{"limit": {"10"}, "continue": {"abc"}}, expected: pagination.Params{Limit: 10, Continue: "abc"}},
+		"limit not a number":   {query: url.Values{"limit": {"any"}}, expectError: true},
+		"limit zero":           {query: url.Values{"limit": {"0"}}, expectError: true},
+		"limit above maximum":  {query: url.Values{"limit": {"501"}}, expectError: true},
+		"limit at maximum":     {query: url.Values{"limit": {"500"}}, expected: pagination.Params{Limit: 500}},
+		"continue without lim": {query: url.Values{"continue": {"abc"}}, expected: pagination.Params{Continue: "abc"}},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/any?"+scenario.query.Encode(), nil)
+			require.NoError(t, err)
+			actual, err := pagination.GetParams(req)
+			if scenario.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, scenario.expected, actual)
+		})
+	}
+}
+
+func Test_Paginate_WalksAllPages(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+
+	page, err := pagination.Paginate(items, pagination.Params{Limit: 2}, identity)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, page.Items)
+	require.NotEmpty(t, page.Next)
+
+	page, err = pagination.Paginate(items, pagination.Params{Limit: 2, Continue: page.Next}, identity)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, page.Items)
+	require.NotEmpty(t, page.Next)
+
+	page, err = pagination.Paginate(items, pagination.Params{Limit: 2, Continue: page.Next}, identity)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e"}, page.Items)
+	assert.Empty(t, page.Next)
+}
+
+func Test_Paginate_StableWhenItemsAreAdded(t *testing.T) {
+	page, err := pagination.Paginate([]string{"c", "b", "a"}, pagination.Params{Limit: 1}, identity)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, page.Items)
+
+	page, err = pagination.Paginate([]string{"d", "c", "b", "a"}, pagination.Params{Limit: 1, Continue: page.Next}, identity)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, page.Items)
+}
+
+func Test_Paginate_ExactLimitHasNoNext(t *testing.T) {
+	page, err := pagination.Paginate([]string{"a", "b"}, pagination.Params{Limit: 2}, identity)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, page.Items)
+	assert.Empty(t, page.Next)
+}
+
+func Test_Paginate_InvalidContinue(t *testing.T) {
+	_, err := pagination.Paginate([]string{"a", "b"}, pagination.Params{Limit: 1, Continue: "not base64!"}, identity)
+	assert.Error(t, err)
+
+	page, err := pagination.Paginate([]string{"a", "b", "c"}, pagination.Params{Limit: 1}, identity)
+	require.NoError(t, err)
+	_, err = pagination.Paginate([]string{"b", "c"}, pagination.Params{Limit: 1, Continue: page.Next}, identity)
+	assert.Error(t, err)
+}
+
+func Test_Paginate_DefaultLimit(t *testing.T) {
+	items := make([]string, pagination.MaxLimit+1)
+	for i := range items {
+		items[i] = strconv.Itoa(i)
+	}
+
+	page, err := pagination.Paginate(items, pagination.Params{}, identity)
+	require.NoError(t, err)
+	assert.Len(t, page.Items, pagination.MaxLimit)
+	assert.NotEmpty(t, page.Next)
+}
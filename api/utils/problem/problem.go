@@ -0,0 +1,229 @@
+package problem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/equinor/radix-api/api/middleware/logger"
+	radixhttp "github.com/equinor/radix-common/net/http"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ContentType of problem details responses, RFC 9457
+const ContentType = "application/problem+json"
+
+// TypeURIPrefix is prepended to the error code in the type of problem details
+const TypeURIPrefix = "urn:radix-api:problem:"
+
+// Code Stable machine-readable identifier of an error
+type Code string
+
+// Generic codes for errors without a more specific code
+const (
+	CodeInvalidRequest  Code = "invalid-request"
+	CodeUnauthorized    Code = "unauthorized"
+	CodeForbidden       Code = "forbidden"
+	CodeNotFound        Code = "not-found"
+	CodeAlreadyExists   Code = "already-exists"
+	CodeConflict        Code = "conflict"
+	CodeTooManyRequests Code = "too-many-requests"
+	CodeTimeout         Code = "timeout"
+	CodeInternalError   Code = "internal-error"
+)
+
+// Problem Problem details of an error response, RFC 9457
+// swagger:model Problem
+type Problem struct {
+	// Type URI identifying the kind of problem
+	//
+	// required: true
+	// example: urn:radix-api:problem:environment-not-found
+	Type string `json:"type"`
+
+	// Title Short summary of the kind of problem
+	//
+	// required: true
+	// example: Not Found
+	Title string `json:"title"`
+
+	// Status HTTP status code
+	//
+	// required: true
+	// example: 404
+	Status int `json:"status"`
+
+	// Detail Explanation of this occurrence of the problem
+	//
+	// required: false
+	Detail string `json:"detail,omitempty"`
+
+	// Instance URI of the request the problem occurred for
+	//
+	// required: false
+	Instance string `json:"instance,omitempty"`
+
+	// Code Stable machine-readable error code
+	//
+	// required: true
+	// example: environment-not-found
+	Code Code `json:"code"`
+
+	// RequestId ID of the request, as logged by Radix API
+	//
+	// required: false
+	RequestId string `json:"requestId,omitempty"`
+
+	// Message Deprecated: use Detail instead
+	//
+	// required: false
+	Message string `json:"message,omitempty"`
+
+	// Error Deprecated: underlying error message
+	//
+	// required: false
+	Error string `json:"error,omitempty"`
+}
+
+type codedError struct {
+	code Code
+	err  error
+}
+
+// WithCode attaches a stable code to err, which is used as the code in problem details responses
+func WithCode(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &codedError{code: code, err: err}
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// Cause makes the wrapped error visible to github.com/pkg/errors.Cause
+func (e *codedError) Cause() error {
+	return e.err
+}
+
+// GetCode returns the code attached to err with WithCode, or a generic code derived from the status of err
+func GetCode(err error) Code {
+	var coded *codedError
+	if errors.As(err, &coded) {
+		return coded.code
+	}
+	return getGenericCode(getStatus(err), err)
+}
+
+// FromError returns the problem details of err for the request
+func FromError(r *http.Request, err error) Problem {
+	status := getStatus(err)
+	problem := Problem{
+		Title:     http.StatusText(status),
+		Status:    status,
+		Instance:  r.URL.Path,
+		Code:      GetCode(err),
+		RequestId: logger.GetRequestId(r.Context()),
+	}
+	problem.Type = TypeURIPrefix + string(problem.Code)
+
+	// Message and Error are set like radixhttp.ErrorResponse did before problem details were introduced
+	var httpErr *radixhttp.Error
+	if !errors.As(err, &httpErr) {
+		httpErr = radixhttp.CoverAllError(err, radixhttp.User)
+	}
+	problem.Message = httpErr.Message
+	if httpErr.Err != nil {
+		problem.Error = httpErr.Err.Error()
+	}
+	problem.Detail = httpErr.Message
+	if problem.Detail == "" || problem.Detail == "Error: "+problem.Error {
+		// The message of radixhttp.CoverAllError is only the underlying error with a prefix
+		problem.Detail = err.Error()
+	}
+	return problem
+}
+
+// ErrorResponse writes err as problem details. Nothing is written when the request was cancelled by the caller
+func ErrorResponse(w http.ResponseWriter, r *http.Request, err error) error {
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+
+	problem := FromError(r, err)
+	body, err := json.Marshal(problem)
+	if err != nil {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(http.StatusInternalServerError)
+		return err
+	}
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(problem.Status)
+	_, err = w.Write(body)
+	return err
+}
+
+// getStatus returns the same status codes as radixhttp.ErrorResponse
+func getStatus(err error) int {
+	var urlErr *url.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &urlErr) {
+		return http.StatusInternalServerError
+	}
+
+	var statusErr k8serrors.APIStatus
+	if errors.As(err, &statusErr) && statusErr.Status().Code != 0 {
+		return int(statusErr.Status().Code)
+	}
+
+	var httpErr *radixhttp.Error
+	if errors.As(err, &httpErr) {
+		switch httpErr.Type {
+		case radixhttp.Missing:
+			return http.StatusNotFound
+		case radixhttp.User:
+			return http.StatusBadRequest
+		case radixhttp.Forbidden:
+			return http.StatusForbidden
+		default:
+			return http.StatusInternalServerError
+		}
+	}
+	return http.StatusBadRequest
+}
+
+func getGenericCode(status int, err error) Code {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return CodeTimeout
+	}
+	switch k8serrors.ReasonForError(err) {
+	case metav1.StatusReasonAlreadyExists:
+		return CodeAlreadyExists
+	case metav1.StatusReasonTimeout, metav1.StatusReasonServerTimeout:
+		return CodeTimeout
+	}
+
+	switch {
+	case status == http.StatusUnauthorized:
+		return CodeUnauthorized
+	case status == http.StatusForbidden:
+		return CodeForbidden
+	case status == http.StatusNotFound:
+		return CodeNotFound
+	case status == http.StatusConflict:
+		return CodeConflict
+	case status == http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case status >= 400 && status < 500:
+		return CodeInvalidRequest
+	default:
+		return CodeInternalError
+	}
+}
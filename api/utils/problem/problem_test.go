@@ -0,0 +1,99 @@
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/equinor/radix-api/api/middleware/logger"
+	"github.com/equinor/radix-api/api/utils/problem"
+	radixhttp "github.com/equinor/radix-common/net/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const anyCode problem.Code = "any-code"
+
+func TestErrorResponse(t *testing.T) {
+	var requestId string
+	handler := logger.NewZerologRequestIdMiddleware()
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/api/v1/applications/any-app?fields=name", nil), func(w http.ResponseWriter, r *http.Request) {
+		requestId = logger.GetRequestId(r.Context())
+		err := problem.WithCode(anyCode, radixhttp.TypeMissingError("any message", errors.New("any error")))
+		require.NoError(t, problem.ErrorResponse(w, r, err))
+	})
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	var actual problem.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+	assert.NotEmpty(t, requestId)
+	expected := problem.Problem{
+		Type:      "urn:radix-api:problem:any-code",
+		Title:     "Not Found",
+		Status:    http.StatusNotFound,
+		Detail:    "any message",
+		Instance:  "/api/v1/applications/any-app",
+		Code:      anyCode,
+		RequestId: requestId,
+		Message:   "any message",
+		Error:     "any error",
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestErrorResponse_CancelledRequest(t *testing.T) {
+	w := httptest.NewRecorder()
+	require.NoError(t, problem.ErrorResponse(w, httptest.NewRequest(http.MethodGet, "/", nil), fmt.Errorf("any: %w", context.Canceled)))
+	assert.Empty(t, w.Body.Bytes())
+}
+
+func TestFromError(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   problem.Code
+		expectedDetail string
+	}{
+		{name: "validation error", err: radixhttp.ValidationError("Any", "any message"), expectedStatus: http.StatusBadRequest, expectedCode: problem.CodeInvalidRequest, expectedDetail: "any message"},
+		{name: "forbidden error", err: radixhttp.ForbiddenError("any message"), expectedStatus: http.StatusForbidden, expectedCode: problem.CodeForbidden, expectedDetail: "any message"},
+		{name: "unexpected error", err: radixhttp.UnexpectedError("any message", errors.New("any error")), expectedStatus: http.StatusInternalServerError, expectedCode: problem.CodeInternalError, expectedDetail: "any message"},
+		{name: "cover all error", err: radixhttp.CoverAllError(errors.New("any error"), radixhttp.User), expectedStatus: http.StatusBadRequest, expectedCode: problem.CodeInvalidRequest, expectedDetail: "any error"},
+		{name: "plain error", err: errors.New("any error"), expectedStatus: http.StatusBadRequest, expectedCode: problem.CodeInvalidRequest, expectedDetail: "any error"},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, expectedStatus: http.StatusInternalServerError, expectedCode: problem.CodeTimeout, expectedDetail: context.DeadlineExceeded.Error()},
+		{name: "kubernetes not found", err: k8serrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "any"), expectedStatus: http.StatusNotFound, expectedCode: problem.CodeNotFound, expectedDetail: `secrets "any" not found`},
+		{name: "kubernetes already exists", err: k8serrors.NewAlreadyExists(schema.GroupResource{Resource: "secrets"}, "any"), expectedStatus: http.StatusConflict, expectedCode: problem.CodeAlreadyExists, expectedDetail: `secrets "any" already exists`},
+		{name: "kubernetes too many requests", err: k8serrors.NewTooManyRequests("any message", 1), expectedStatus: http.StatusTooManyRequests, expectedCode: problem.CodeTooManyRequests, expectedDetail: "any message"},
+		{name: "wrapped kubernetes error with code", err: problem.WithCode(anyCode, fmt.Errorf("any: %w", k8serrors.NewConflict(schema.GroupResource{Resource: "secrets"}, "any", errors.New("any error")))), expectedStatus: http.StatusConflict, expectedCode: anyCode, expectedDetail: `any: Operation cannot be fulfilled on secrets "any": any error`},
+	}
+
+	for _, ts := range scenarios {
+		t.Run(ts.name, func(t *testing.T) {
+			actual := problem.FromError(httptest.NewRequest(http.MethodGet, "/any", nil), ts.err)
+			assert.Equal(t, ts.expectedStatus, actual.Status)
+			assert.Equal(t, http.StatusText(ts.expectedStatus), actual.Title)
+			assert.Equal(t, ts.expectedCode, actual.Code)
+			assert.Equal(t, problem.TypeURIPrefix+string(ts.expectedCode), actual.Type)
+			assert.Equal(t, ts.expectedDetail, actual.Detail)
+		})
+	}
+}
+
+func TestWithCode(t *testing.T) {
+	assert.NoError(t, problem.WithCode(anyCode, nil))
+
+	err := radixhttp.ValidationError("Any", "any message")
+	coded := problem.WithCode(anyCode, err)
+	assert.Equal(t, err.Error(), coded.Error())
+	assert.ErrorIs(t, coded, err)
+	assert.Equal(t, anyCode, problem.GetCode(coded))
+	assert.Equal(t, anyCode, problem.GetCode(fmt.Errorf("any: %w", coded)))
+}
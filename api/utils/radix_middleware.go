@@ -4,13 +4,21 @@ import (
 	"net/http"
 	"time"
 
+	certclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+
 	"github.com/equinor/radix-api/api/metrics"
 	"github.com/equinor/radix-api/api/middleware/auth"
+	"github.com/equinor/radix-api/api/utils/problem"
+	"github.com/equinor/radix-api/api/utils/tracing"
 	"github.com/equinor/radix-api/models"
-	radixhttp "github.com/equinor/radix-common/net/http"
+	radixclient "github.com/equinor/radix-operator/pkg/client/clientset/versioned"
 	"github.com/gorilla/mux"
+	kedav2 "github.com/kedacore/keda/v2/pkg/generated/clientset/versioned"
 	"github.com/rs/zerolog/log"
+	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	secretproviderclient "sigs.k8s.io/secrets-store-csi-driver/pkg/client/clientset/versioned"
 )
 
 // RadixMiddleware The middleware between router and radix handler functions
@@ -22,6 +30,7 @@ type RadixMiddleware struct {
 	kubeApiQPS   float32
 	kubeApiBurst int
 	next         models.RadixHandlerFunc
+	nextName     string
 }
 
 // NewRadixMiddleware Constructor for radix middleware
@@ -34,6 +43,7 @@ func NewRadixMiddleware(kubeUtil KubeUtil, path, method string, allowUnauthentic
 		kubeApiQPS,
 		kubeApiBurst,
 		next,
+		tracing.FuncName(next),
 	}
 
 	return handler
@@ -42,6 +52,9 @@ func NewRadixMiddleware(kubeUtil KubeUtil, path, method string, allowUnauthentic
 // Handle Wraps radix handler methods
 func (handler *RadixMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	start := time.Now()
+	ctx, span := tracing.Tracer().Start(r.Context(), "RadixMiddleware.ServeHTTP")
+	defer span.End()
+	r = r.WithContext(ctx)
 
 	defer func() {
 		httpDuration := time.Since(start)
@@ -58,12 +71,9 @@ func (handler *RadixMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Request
 
 func (handler *RadixMiddleware) handleAuthorization(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
-	token := auth.CtxTokenPrincipal(r.Context()).Token()
-	impersonation := auth.CtxImpersonation(r.Context())
-
 	restOptions := handler.getRestClientOptions()
 	inClusterClient, inClusterRadixClient, inClusterKedaClient, inClusterSecretProviderClient, inClusterTektonClient, inClusterCertManagerClient := handler.kubeUtil.GetServerKubernetesClient(restOptions...)
-	outClusterClient, outClusterRadixClient, outClusterKedaClient, outClusterSecretProviderClient, outClusterTektonClient, outClusterCertManagerClient := handler.kubeUtil.GetUserKubernetesClient(token, impersonation, restOptions...)
+	outClusterClient, outClusterRadixClient, outClusterKedaClient, outClusterSecretProviderClient, outClusterTektonClient, outClusterCertManagerClient := handler.getUserKubernetesClient(r, restOptions)
 
 	accounts := models.NewAccounts(inClusterClient, inClusterRadixClient, inClusterKedaClient, inClusterSecretProviderClient, inClusterTektonClient, inClusterCertManagerClient, outClusterClient, outClusterRadixClient, outClusterKedaClient, outClusterSecretProviderClient, outClusterTektonClient, outClusterCertManagerClient)
 
@@ -71,14 +81,25 @@ func (handler *RadixMiddleware) handleAuthorization(w http.ResponseWriter, r *ht
 	if appName, exists := mux.Vars(r)["appName"]; exists {
 		if _, err := accounts.UserAccount.RadixClient.RadixV1().RadixRegistrations().Get(r.Context(), appName, metav1.GetOptions{}); err != nil {
 			logger.Warn().Err(err).Msg("authorization error")
-			if err = radixhttp.ErrorResponse(w, r, err); err != nil {
+			if err = problem.ErrorResponse(w, r, err); err != nil {
 				logger.Err(err).Msg("failed to write response")
 			}
 			return
 		}
 	}
 
-	handler.next(accounts, w, r)
+	handler.serveNext(accounts, w, r)
+}
+
+// getUserKubernetesClient Personal access tokens are not valid for the Kubernetes API. Requests with a personal access token
+// are limited to their application and scopes by auth.NewPersonalAccessTokenMiddleware, and sent to Kubernetes impersonating the creator of the token
+func (handler *RadixMiddleware) getUserKubernetesClient(r *http.Request, restOptions []RestClientConfigOption) (kubernetes.Interface, radixclient.Interface, kedav2.Interface, secretproviderclient.Interface, tektonclient.Interface, certclient.Interface) {
+	if pat, ok := auth.CtxPersonalAccessToken(r.Context()); ok {
+		return handler.kubeUtil.GetImpersonatedServerKubernetesClient(pat.CreatorUser, restOptions...)
+	}
+	token := auth.CtxTokenPrincipal(r.Context()).Token()
+	impersonation := auth.CtxImpersonation(r.Context())
+	return handler.kubeUtil.GetUserKubernetesClient(token, impersonation, restOptions...)
 }
 
 func (handler *RadixMiddleware) handleAnonymous(w http.ResponseWriter, r *http.Request) {
@@ -88,7 +109,14 @@ func (handler *RadixMiddleware) handleAnonymous(w http.ResponseWriter, r *http.R
 	sa := models.NewServiceAccount(inClusterClient, inClusterRadixClient, inClusterKedaClient, inClusterSecretProviderClient, inClusterTektonClient, inClusterCertManagerClient)
 	accounts := models.Accounts{ServiceAccount: sa}
 
-	handler.next(accounts, w, r)
+	handler.serveNext(accounts, w, r)
+}
+
+func (handler *RadixMiddleware) serveNext(accounts models.Accounts, w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.Tracer().Start(r.Context(), handler.nextName)
+	defer span.End()
+
+	handler.next(accounts, w, r.WithContext(ctx))
 }
 
 func (handler *RadixMiddleware) getRestClientOptions() []RestClientConfigOption {
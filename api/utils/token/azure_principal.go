@@ -18,6 +18,15 @@ func (c *azureClaims) Validate(_ context.Context) error {
 	return nil
 }
 
+// UserPrincipal is implemented by principals that can identify the user the token is issued to
+type UserPrincipal interface {
+	TokenPrincipal
+	// UserPrincipalName returns the user principal name of the user, or an empty string when the token is not issued to a user
+	UserPrincipalName() string
+}
+
+var _ UserPrincipal = &azurePrincipal{}
+
 type azurePrincipal struct {
 	token       string
 	claims      validator.RegisteredClaims
@@ -57,3 +66,9 @@ func (p *azurePrincipal) Name() string {
 
 	return p.claims.Subject
 }
+
+// UserPrincipalName returns the upn claim, which is only issued for users.
+// The email claim is not used, since it is not verified by Entra ID
+func (p *azurePrincipal) UserPrincipalName() string {
+	return p.azureClaims.Upn
+}
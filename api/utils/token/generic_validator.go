@@ -0,0 +1,165 @@
+package token
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/auth0/go-jwt-middleware/v2/jwks"
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/equinor/radix-common/net/http"
+)
+
+const defaultClaim = "sub"
+
+// ClaimMapping the claims used as the id and name of the principal
+type ClaimMapping struct {
+	// Id claim of the principal. Defaults to sub
+	Id string
+	// Name claim of the principal. Defaults to the Id claim
+	Name string
+}
+
+// GenericValidator validates tokens from any OIDC issuer, with the signing algorithms allowed for the issuer,
+// and maps the claims of the token to the id and name of the principal.
+// The id is prefixed with the name of the issuer and the id claim, e.g. github:sub:<value>, so principals from different issuers never share an id
+type GenericValidator struct {
+	name         string
+	validators   map[string]*validator.Validator
+	claimMapping ClaimMapping
+}
+
+var _ ValidatorInterface = &GenericValidator{}
+
+// NewGenericValidator Constructor for GenericValidator. The name must be unique among the issuers.
+// Tokens must have one of the audiences, and be signed with one of the algorithms. Algorithms defaults to RS256
+func NewGenericValidator(name string, issuerUrl url.URL, audiences []string, algorithms []string, claimMapping ClaimMapping) (*GenericValidator, error) {
+	if name == "" {
+		return nil, errors.New("the issuer name is required")
+	}
+	if len(algorithms) == 0 {
+		algorithms = []string{string(validator.RS256)}
+	}
+	if claimMapping.Id == "" {
+		claimMapping.Id = defaultClaim
+	}
+	if claimMapping.Name == "" {
+		claimMapping.Name = claimMapping.Id
+	}
+
+	provider := jwks.NewCachingProvider(&issuerUrl, 5*time.Hour)
+	validators := make(map[string]*validator.Validator, len(algorithms))
+	for _, algorithm := range algorithms {
+		v, err := validator.New(
+			provider.KeyFunc,
+			validator.SignatureAlgorithm(algorithm),
+			issuerUrl.String(),
+			audiences,
+			validator.WithCustomClaims(func() validator.CustomClaims {
+				return &genericClaims{}
+			}),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid algorithm %s: %w", algorithm, err)
+		}
+		validators[algorithm] = v
+	}
+
+	return &GenericValidator{name: name, validators: validators, claimMapping: claimMapping}, nil
+}
+
+func (v *GenericValidator) ValidateToken(ctx context.Context, token string) (TokenPrincipal, error) {
+	algorithm, err := getAlgorithm(token)
+	if err != nil {
+		return nil, err
+	}
+	algorithmValidator, ok := v.validators[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("signing algorithm %s is not allowed", algorithm)
+	}
+
+	validateToken, err := algorithmValidator.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := validateToken.(*validator.ValidatedClaims)
+	if !ok {
+		return nil, http.ForbiddenError("invalid token")
+	}
+	customClaims, ok := claims.CustomClaims.(*genericClaims)
+	if !ok || customClaims == nil {
+		return nil, http.ForbiddenError("invalid token")
+	}
+
+	id := customClaims.get(v.claimMapping.Id)
+	if id == "" {
+		return nil, http.ForbiddenError(fmt.Sprintf("token has no %s claim", v.claimMapping.Id))
+	}
+	name := customClaims.get(v.claimMapping.Name)
+	if name == "" {
+		name = id
+	}
+	return &genericPrincipal{token: token, id: fmt.Sprintf("%s:%s:%s", v.name, v.claimMapping.Id, id), name: name}, nil
+}
+
+// getAlgorithm returns the alg header of the token, without validating the token
+func getAlgorithm(token string) (string, error) {
+	header, _, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", errors.New("could not parse the token")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return "", fmt.Errorf("could not parse the token header: %w", err)
+	}
+	var values struct {
+		Algorithm string `json:"alg"`
+	}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return "", fmt.Errorf("could not parse the token header: %w", err)
+	}
+	return values.Algorithm, nil
+}
+
+type genericClaims map[string]interface{}
+
+func (c *genericClaims) Validate(_ context.Context) error {
+	return nil
+}
+
+// get returns the claim as a string, or an empty string when the claim is not set
+func (c *genericClaims) get(claim string) string {
+	switch value := (*c)[claim].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+type genericPrincipal struct {
+	token string
+	id    string
+	name  string
+}
+
+func (p *genericPrincipal) Token() string {
+	return p.token
+}
+func (p *genericPrincipal) IsAuthenticated() bool {
+	return true
+}
+func (p *genericPrincipal) Id() string {
+	return p.id
+}
+func (p *genericPrincipal) Name() string {
+	return p.name
+}
@@ -0,0 +1,59 @@
+package token
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenericValidator_ClaimMapping(t *testing.T) {
+	issuer := createServer(t)
+	v, err := NewGenericValidator("test-issuer", issuer, []string{"other-audience", test_oidc_audience}, nil, ClaimMapping{Name: "email"})
+	require.NoError(t, err)
+
+	principal, err := v.ValidateToken(context.Background(), createUser(t, issuer, test_oidc_audience, "user1"))
+	require.NoError(t, err)
+	assert.Equal(t, "test-issuer:sub:user1", principal.Id())
+	assert.Equal(t, "jane.doe@example.com", principal.Name())
+}
+
+func TestGenericValidator_MissingIdClaim(t *testing.T) {
+	issuer := createServer(t)
+	v, err := NewGenericValidator("test-issuer", issuer, []string{test_oidc_audience}, nil, ClaimMapping{Id: "repository"})
+	require.NoError(t, err)
+
+	_, err = v.ValidateToken(context.Background(), createUser(t, issuer, test_oidc_audience, "user1"))
+	assert.Error(t, err)
+}
+
+func TestGenericValidator_AlgorithmNotAllowed(t *testing.T) {
+	issuer := createServer(t)
+	v, err := NewGenericValidator("test-issuer", issuer, []string{test_oidc_audience}, []string{"ES256"}, ClaimMapping{})
+	require.NoError(t, err)
+
+	_, err = v.ValidateToken(context.Background(), createUser(t, issuer, test_oidc_audience, "user1"))
+	assert.ErrorContains(t, err, "signing algorithm RS256 is not allowed")
+}
+
+func TestNewGenericValidator_MissingName(t *testing.T) {
+	issuer := createServer(t)
+	_, err := NewGenericValidator("", issuer, []string{test_oidc_audience}, nil, ClaimMapping{})
+	assert.Error(t, err)
+}
+
+func TestNewGenericValidator_InvalidAlgorithm(t *testing.T) {
+	issuer := createServer(t)
+	_, err := NewGenericValidator("test-issuer", issuer, []string{test_oidc_audience}, []string{"none"}, ClaimMapping{})
+	assert.Error(t, err)
+}
+
+func TestGetAlgorithm(t *testing.T) {
+	algorithm, err := getAlgorithm(test_jwt)
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", algorithm)
+
+	_, err = getAlgorithm("not-a-token")
+	assert.Error(t, err)
+}
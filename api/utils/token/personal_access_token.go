@@ -0,0 +1,261 @@
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PersonalAccessTokenPrefix is the prefix of all personal access tokens, which separates them from JWTs
+const PersonalAccessTokenPrefix = "radix_pat_"
+
+// Scope of the routes a personal access token can be used for
+type Scope string
+
+const (
+	// ScopeRead allows reading the application, its environments, deployments and pipeline jobs
+	ScopeRead Scope = "read"
+	// ScopePipelinesTrigger allows triggering pipeline jobs for the application
+	ScopePipelinesTrigger Scope = "pipelines:trigger"
+)
+
+// Scopes all scopes a personal access token can have
+var Scopes = []Scope{ScopeRead, ScopePipelinesTrigger}
+
+const (
+	secretNamePrefix           = "radix-pat-"
+	personalAccessTokenLabel   = "radix-api-personal-access-token"
+	appNameLabel               = "radix-app"
+	tokenHashKey               = "hash"
+	tokenNameAnnotation        = "radix.equinor.com/token-name"
+	tokenScopesAnnotation      = "radix.equinor.com/token-scopes"
+	tokenCreatedByAnnotation   = "radix.equinor.com/token-created-by"
+	tokenCreatorUserAnnotation = "radix.equinor.com/token-creator-user"
+	tokenExpiresAnnotation     = "radix.equinor.com/token-expires"
+	tokenIdBytes               = 8
+	tokenSecretBytes           = 32
+	personalAccessTokenFormat  = PersonalAccessTokenPrefix + "<id>_<secret>"
+)
+
+var (
+	// ErrInvalidPersonalAccessToken is returned when the personal access token does not exist, is revoked or has expired
+	ErrInvalidPersonalAccessToken = errors.New("invalid personal access token")
+	errNotPersonalAccessToken     = errors.New("not a personal access token")
+)
+
+// PersonalAccessToken an API token bound to an application, with the scopes it can be used for.
+// CreatorUser is the Kubernetes user of the creator. Requests with the token are authorized as this user, without groups,
+// since the current group membership of the creator cannot be resolved when the token is used
+type PersonalAccessToken struct {
+	Id          string
+	AppName     string
+	Name        string
+	Scopes      []Scope
+	CreatedBy   string
+	CreatorUser string
+	Created     time.Time
+	Expires     time.Time
+}
+
+// HasScope returns true when the token has the scope
+func (t PersonalAccessToken) HasScope(scope Scope) bool {
+	return slices.Contains(t.Scopes, scope)
+}
+
+// PersonalAccessTokenStore stores personal access tokens as Kubernetes secrets in a namespace.
+// Only the SHA-256 hash of the secret part of the token is stored
+type PersonalAccessTokenStore struct {
+	client    kubernetes.Interface
+	namespace string
+	now       func() time.Time
+}
+
+// NewPersonalAccessTokenStore Constructor for PersonalAccessTokenStore
+func NewPersonalAccessTokenStore(client kubernetes.Interface, namespace string) *PersonalAccessTokenStore {
+	return &PersonalAccessTokenStore{client: client, namespace: namespace, now: time.Now}
+}
+
+// Create stores a new token with the AppName, Name, Scopes, CreatedBy, CreatorUser and Expires of pat.
+// The token value is returned only once, and cannot be read later
+func (s *PersonalAccessTokenStore) Create(ctx context.Context, pat PersonalAccessToken) (PersonalAccessToken, string, error) {
+	id, err := randomString(tokenIdBytes, hex.EncodeToString)
+	if err != nil {
+		return PersonalAccessToken{}, "", err
+	}
+	secret, err := randomString(tokenSecretBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return PersonalAccessToken{}, "", err
+	}
+
+	pat.Id = id
+	pat.Created = s.now().UTC().Truncate(time.Second)
+	pat.Expires = pat.Expires.UTC().Truncate(time.Second)
+	k8sSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              secretNamePrefix + id,
+			Namespace:         s.namespace,
+			CreationTimestamp: metav1.NewTime(pat.Created),
+			Labels: map[string]string{
+				personalAccessTokenLabel: "true",
+				appNameLabel:             pat.AppName,
+			},
+			Annotations: map[string]string{
+				tokenNameAnnotation:        pat.Name,
+				tokenScopesAnnotation:      joinScopes(pat.Scopes),
+				tokenCreatedByAnnotation:   pat.CreatedBy,
+				tokenCreatorUserAnnotation: pat.CreatorUser,
+				tokenExpiresAnnotation:     pat.Expires.Format(time.RFC3339),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{tokenHashKey: []byte(hashSecret(secret))},
+	}
+	if _, err := s.client.CoreV1().Secrets(s.namespace).Create(ctx, k8sSecret, metav1.CreateOptions{}); err != nil {
+		return PersonalAccessToken{}, "", err
+	}
+	return pat, PersonalAccessTokenPrefix + id + "_" + secret, nil
+}
+
+// List returns the tokens of the application, oldest first
+func (s *PersonalAccessTokenStore) List(ctx context.Context, appName string) ([]PersonalAccessToken, error) {
+	selector := labels.SelectorFromSet(labels.Set{personalAccessTokenLabel: "true", appNameLabel: appName})
+	secrets, err := s.client.CoreV1().Secrets(s.namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]PersonalAccessToken, 0, len(secrets.Items))
+	for _, secret := range secrets.Items {
+		tokens = append(tokens, toPersonalAccessToken(&secret))
+	}
+	slices.SortFunc(tokens, func(a, b PersonalAccessToken) int { return a.Created.Compare(b.Created) })
+	return tokens, nil
+}
+
+// Delete revokes the token of the application. A NotFound error is returned when the application has no token with the id
+func (s *PersonalAccessTokenStore) Delete(ctx context.Context, appName, id string) error {
+	secret, err := s.getSecret(ctx, id)
+	if err != nil {
+		return err
+	}
+	if secret.Labels[appNameLabel] != appName {
+		return kubeerrors.NewNotFound(corev1.Resource("secrets"), secretNamePrefix+id)
+	}
+	return s.client.CoreV1().Secrets(s.namespace).Delete(ctx, secret.Name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &secret.UID}})
+}
+
+// Validate returns the token when tokenValue matches an existing token which has not expired
+func (s *PersonalAccessTokenStore) Validate(ctx context.Context, tokenValue string) (PersonalAccessToken, error) {
+	id, secret, ok := parsePersonalAccessToken(tokenValue)
+	if !ok {
+		return PersonalAccessToken{}, fmt.Errorf("%w: expected format %s", ErrInvalidPersonalAccessToken, personalAccessTokenFormat)
+	}
+
+	k8sSecret, err := s.getSecret(ctx, id)
+	if kubeerrors.IsNotFound(err) {
+		return PersonalAccessToken{}, ErrInvalidPersonalAccessToken
+	}
+	if err != nil {
+		return PersonalAccessToken{}, err
+	}
+	if subtle.ConstantTimeCompare(k8sSecret.Data[tokenHashKey], []byte(hashSecret(secret))) != 1 {
+		return PersonalAccessToken{}, ErrInvalidPersonalAccessToken
+	}
+
+	pat := toPersonalAccessToken(k8sSecret)
+	if !s.now().Before(pat.Expires) {
+		return PersonalAccessToken{}, fmt.Errorf("%w: expired %s", ErrInvalidPersonalAccessToken, pat.Expires.Format(time.RFC3339))
+	}
+	return pat, nil
+}
+
+func (s *PersonalAccessTokenStore) getSecret(ctx context.Context, id string) (*corev1.Secret, error) {
+	secret, err := s.client.CoreV1().Secrets(s.namespace).Get(ctx, secretNamePrefix+id, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if secret.Labels[personalAccessTokenLabel] != "true" {
+		return nil, kubeerrors.NewNotFound(corev1.Resource("secrets"), secret.Name)
+	}
+	return secret, nil
+}
+
+// IsValidScope returns true when scope is one of Scopes
+func IsValidScope(scope Scope) bool {
+	return slices.Contains(Scopes, scope)
+}
+
+func toPersonalAccessToken(secret *corev1.Secret) PersonalAccessToken {
+	// A token with an invalid expiry time is treated as expired
+	expires, _ := time.Parse(time.RFC3339, secret.Annotations[tokenExpiresAnnotation])
+	return PersonalAccessToken{
+		Id:          strings.TrimPrefix(secret.Name, secretNamePrefix),
+		AppName:     secret.Labels[appNameLabel],
+		Name:        secret.Annotations[tokenNameAnnotation],
+		Scopes:      splitScopes(secret.Annotations[tokenScopesAnnotation]),
+		CreatedBy:   secret.Annotations[tokenCreatedByAnnotation],
+		CreatorUser: secret.Annotations[tokenCreatorUserAnnotation],
+		Created:     secret.CreationTimestamp.UTC(),
+		Expires:     expires,
+	}
+}
+
+func parsePersonalAccessToken(tokenValue string) (id, secret string, ok bool) {
+	rest, ok := strings.CutPrefix(tokenValue, PersonalAccessTokenPrefix)
+	if !ok {
+		return "", "", false
+	}
+	id, secret, ok = strings.Cut(rest, "_")
+	if !ok || len(id) != hex.EncodedLen(tokenIdBytes) || secret == "" {
+		return "", "", false
+	}
+	if _, err := hex.DecodeString(id); err != nil {
+		return "", "", false
+	}
+	return id, secret, true
+}
+
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encode(b), nil
+}
+
+func joinScopes(scopes []Scope) string {
+	values := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		values = append(values, string(scope))
+	}
+	return strings.Join(values, ",")
+}
+
+func splitScopes(value string) []Scope {
+	var scopes []Scope
+	for _, scope := range strings.Split(value, ",") {
+		if scope != "" {
+			scopes = append(scopes, Scope(scope))
+		}
+	}
+	return scopes
+}
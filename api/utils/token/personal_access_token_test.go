@@ -0,0 +1,90 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "radix-api-prod"
+
+func TestPersonalAccessTokenStore_CreateAndValidate(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	store := NewPersonalAccessTokenStore(kubeClient, testNamespace)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	created, tokenValue, err := store.Create(context.Background(), PersonalAccessToken{
+		AppName:     "any-app",
+		Name:        "ci",
+		Scopes:      []Scope{ScopePipelinesTrigger},
+		CreatedBy:   "any-user",
+		CreatorUser: "any-user@example.com",
+		Expires:     now.Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, `^radix_pat_[0-9a-f]{16}_[A-Za-z0-9_-]{43}$`, tokenValue)
+
+	secret, err := kubeClient.CoreV1().Secrets(testNamespace).Get(context.Background(), "radix-pat-"+created.Id, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, string(secret.Data[tokenHashKey]), tokenValue[len("radix_pat_")+17:], "only the hash of the secret should be stored")
+
+	principal, err := NewPersonalAccessTokenValidator(store).ValidateToken(context.Background(), tokenValue)
+	require.NoError(t, err)
+	assert.Equal(t, "pat:"+created.Id, principal.Id())
+	assert.Equal(t, "any-user", principal.Name())
+	pat := principal.(*PersonalAccessTokenPrincipal).PersonalAccessToken()
+	assert.Equal(t, created, pat)
+	assert.True(t, pat.HasScope(ScopePipelinesTrigger))
+	assert.False(t, pat.HasScope(ScopeRead))
+
+	now = now.Add(24 * time.Hour)
+	_, err = store.Validate(context.Background(), tokenValue)
+	assert.ErrorIs(t, err, ErrInvalidPersonalAccessToken, "expired token should be invalid")
+}
+
+func TestPersonalAccessTokenStore_InvalidTokens(t *testing.T) {
+	store := NewPersonalAccessTokenStore(kubefake.NewSimpleClientset(), testNamespace)
+	_, tokenValue, err := store.Create(context.Background(), PersonalAccessToken{AppName: "any-app", Expires: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+
+	for _, invalid := range []string{
+		"radix_pat_",
+		"radix_pat_0123456789abcdef",
+		"radix_pat_0123456789abcdef_anysecret",
+		tokenValue + "x",
+	} {
+		_, err := store.Validate(context.Background(), invalid)
+		assert.ErrorIs(t, err, ErrInvalidPersonalAccessToken, invalid)
+	}
+
+	_, err = NewPersonalAccessTokenValidator(store).ValidateToken(context.Background(), "eyJhbGciOiJSUzI1NiJ9.e30.sig")
+	assert.ErrorIs(t, err, errNotPersonalAccessToken)
+}
+
+func TestPersonalAccessTokenStore_ListAndDelete(t *testing.T) {
+	store := NewPersonalAccessTokenStore(kubefake.NewSimpleClientset(), testNamespace)
+	expires := time.Now().Add(time.Hour)
+	first, tokenValue, err := store.Create(context.Background(), PersonalAccessToken{AppName: "any-app", Name: "first", Expires: expires})
+	require.NoError(t, err)
+	_, _, err = store.Create(context.Background(), PersonalAccessToken{AppName: "other-app", Name: "other", Expires: expires})
+	require.NoError(t, err)
+
+	tokens, err := store.List(context.Background(), "any-app")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "first", tokens[0].Name)
+
+	err = store.Delete(context.Background(), "other-app", first.Id)
+	assert.True(t, kubeerrors.IsNotFound(err), "token of another application should not be deleted")
+
+	require.NoError(t, store.Delete(context.Background(), "any-app", first.Id))
+	_, err = store.Validate(context.Background(), tokenValue)
+	assert.ErrorIs(t, err, ErrInvalidPersonalAccessToken, "revoked token should be invalid")
+}
@@ -0,0 +1,63 @@
+package token
+
+import (
+	"context"
+	"strings"
+)
+
+// PersonalAccessTokenValidator validates personal access tokens in the PersonalAccessTokenStore. Other tokens are rejected without lookup
+type PersonalAccessTokenValidator struct {
+	store *PersonalAccessTokenStore
+}
+
+var _ ValidatorInterface = &PersonalAccessTokenValidator{}
+
+// NewPersonalAccessTokenValidator Constructor for PersonalAccessTokenValidator
+func NewPersonalAccessTokenValidator(store *PersonalAccessTokenStore) *PersonalAccessTokenValidator {
+	return &PersonalAccessTokenValidator{store: store}
+}
+
+func (v *PersonalAccessTokenValidator) ValidateToken(ctx context.Context, token string) (TokenPrincipal, error) {
+	if !strings.HasPrefix(token, PersonalAccessTokenPrefix) {
+		return nil, errNotPersonalAccessToken
+	}
+
+	pat, err := v.store.Validate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return &PersonalAccessTokenPrincipal{token: token, pat: pat}, nil
+}
+
+// PersonalAccessTokenPrincipal a principal authenticated with a personal access token.
+// The token is not valid for the Kubernetes API, so requests are sent with the service account,
+// and must be limited to the application and scopes of the token
+type PersonalAccessTokenPrincipal struct {
+	token string
+	pat   PersonalAccessToken
+}
+
+var _ TokenPrincipal = &PersonalAccessTokenPrincipal{}
+
+func (p *PersonalAccessTokenPrincipal) IsAuthenticated() bool {
+	return true
+}
+
+func (p *PersonalAccessTokenPrincipal) Token() string {
+	return p.token
+}
+
+// Id of the token, separate from the id of the user who created it
+func (p *PersonalAccessTokenPrincipal) Id() string {
+	return "pat:" + p.pat.Id
+}
+
+// Name of the user who created the token
+func (p *PersonalAccessTokenPrincipal) Name() string {
+	return p.pat.CreatedBy
+}
+
+// PersonalAccessToken returns the validated token
+func (p *PersonalAccessTokenPrincipal) PersonalAccessToken() PersonalAccessToken {
+	return p.pat
+}
@@ -0,0 +1,29 @@
+package token
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ReloadableValidator delegates to a validator that can be replaced while serving requests, e.g. when the OIDC config is reloaded
+type ReloadableValidator struct {
+	validator atomic.Pointer[ValidatorInterface]
+}
+
+var _ ValidatorInterface = &ReloadableValidator{}
+
+// NewReloadableValidator Constructor for ReloadableValidator
+func NewReloadableValidator(validator ValidatorInterface) *ReloadableValidator {
+	v := &ReloadableValidator{}
+	v.Set(validator)
+	return v
+}
+
+// Set replaces the validator used for new requests
+func (v *ReloadableValidator) Set(validator ValidatorInterface) {
+	v.validator.Store(&validator)
+}
+
+func (v *ReloadableValidator) ValidateToken(ctx context.Context, token string) (TokenPrincipal, error) {
+	return (*v.validator.Load()).ValidateToken(ctx, token)
+}
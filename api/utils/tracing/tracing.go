@@ -0,0 +1,148 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/equinor/radix-api/api/utils/logs"
+	"github.com/felixge/httpsnoop"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/negroni/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName  = "github.com/equinor/radix-api"
+	serviceName = "radix-api"
+)
+
+// propagator reads and writes the W3C traceparent and tracestate headers
+var propagator = propagation.TraceContext{}
+
+// Tracer returns the tracer for spans in radix-api. Spans are not recorded until a tracer provider is initialized with InitTracerProvider
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// InitTracerProvider Sets the global tracer provider, exporting spans to the OTLP HTTP collector at otlpEndpoint (host:port).
+// The returned function flushes and stops the exporter
+func InitTracerProvider(ctx context.Context, otlpEndpoint string, insecure bool, sampleRatio float64, attrs ...attribute.KeyValue) (func(context.Context) error, error) {
+	exporterOptions := []otlptracehttp.Option{otlptracehttp.WithEndpoint(otlpEndpoint)}
+	if insecure {
+		exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, exporterOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(append([]attribute.KeyValue{semconv.ServiceName(serviceName)}, attrs...)...),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// NewServerSpanMiddleware Starts a server span for the request, continuing the trace in the W3C traceparent header.
+// The trace_id is added to the request logger
+func NewServerSpanMiddleware() negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := Tracer().Start(ctx, r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(r.Method),
+				semconv.URLPath(r.URL.Path),
+				semconv.UserAgentOriginal(r.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		if spanContext := span.SpanContext(); spanContext.IsSampled() {
+			ctx = log.Ctx(ctx).With().Str("trace_id", spanContext.TraceID().String()).Logger().WithContext(ctx)
+		}
+
+		m := httpsnoop.CaptureMetrics(next, w, r.WithContext(ctx))
+		span.SetAttributes(semconv.HTTPResponseStatusCode(m.Code))
+		if m.Code >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(m.Code))
+		}
+	}
+}
+
+// NewRouteMiddleware Names the server span after the matched route
+func NewRouteMiddleware(method, path string) negroni.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, next http.HandlerFunc) {
+		span := trace.SpanFromContext(r.Context())
+		span.SetName(method + " " + path)
+		span.SetAttributes(semconv.HTTPRoute(path))
+		next(w, r)
+	}
+}
+
+// NewRoundtripTracer returns a http.RoundTripper that records a client span for each request to peerService, and propagates the trace in the W3C traceparent header.
+// Requests sent outside a traced request, e.g. by informers, are not traced
+func NewRoundtripTracer(peerService string) func(t http.RoundTripper) http.RoundTripper {
+	return func(t http.RoundTripper) http.RoundTripper {
+		return logs.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if !trace.SpanContextFromContext(r.Context()).IsValid() {
+				return t.RoundTrip(r)
+			}
+
+			ctx, span := Tracer().Start(r.Context(), r.Method,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					semconv.ServicePeerName(peerService),
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.URLFull(r.URL.String()),
+					semconv.ServerAddress(r.URL.Hostname()),
+				),
+			)
+			defer span.End()
+
+			r = r.Clone(ctx)
+			propagator.Inject(ctx, propagation.HeaderCarrier(r.Header))
+			resp, err := t.RoundTrip(r)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return resp, err
+			}
+			span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+			if resp.StatusCode >= http.StatusBadRequest {
+				span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+			}
+			return resp, nil
+		})
+	}
+}
+
+// FuncName returns the name of the function, without the package path, e.g. applications.(*applicationController).GetApplication
+func FuncName(fn any) string {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	return strings.TrimSuffix(name, "-fm")
+}
@@ -0,0 +1,90 @@
+package tracing_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/equinor/radix-api/api/utils/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/negroni/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func setupSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func Test_ServerSpan_ContinuesTraceAndTracesOutboundRequests(t *testing.T) {
+	recorder := setupSpanRecorder(t)
+
+	var outboundTraceparent string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outboundTraceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer backend.Close()
+	client := &http.Client{Transport: tracing.NewRoundtripTracer("kubernetes")(http.DefaultTransport)}
+
+	n := negroni.New(tracing.NewServerSpanMiddleware(), tracing.NewRouteMiddleware("GET", "/api/v1/applications/{appName}"))
+	n.UseHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, _ := http.NewRequestWithContext(r.Context(), http.MethodGet, backend.URL, nil)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/applications/any-app", nil)
+	req.Header.Set("traceparent", traceparent)
+	n.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	clientSpan, serverSpan := spans[0], spans[1]
+
+	assert.Equal(t, "GET /api/v1/applications/{appName}", serverSpan.Name())
+	assert.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", serverSpan.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", serverSpan.Parent().SpanID().String())
+	assert.Equal(t, codes.Unset, serverSpan.Status().Code, "4xx responses are not server errors")
+
+	assert.Equal(t, trace.SpanKindClient, clientSpan.SpanKind())
+	assert.Equal(t, serverSpan.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+	assert.Equal(t, codes.Error, clientSpan.Status().Code)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+clientSpan.SpanContext().SpanID().String()+"-01", outboundTraceparent)
+}
+
+func Test_RoundtripTracer_IgnoresRequestsOutsideTrace(t *testing.T) {
+	recorder := setupSpanRecorder(t)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("traceparent"))
+	}))
+	defer backend.Close()
+
+	client := &http.Client{Transport: tracing.NewRoundtripTracer("kubernetes")(http.DefaultTransport)}
+	resp, err := client.Get(backend.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Empty(t, recorder.Ended())
+}
+
+type anyController struct{}
+
+func (anyController) GetApplication() {}
+
+func Test_FuncName(t *testing.T) {
+	assert.Equal(t, "tracing_test.anyController.GetApplication", tracing.FuncName(anyController{}.GetApplication))
+	assert.Equal(t, "tracing_test.Test_FuncName", tracing.FuncName(Test_FuncName))
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	alertingModels "github.com/equinor/radix-api/api/alerting/models"
+)
+
+// AlertingService alerting of applications and environments
+type AlertingService struct {
+	client *Client
+}
+
+// GetApplicationConfig gets the alerting config of the application
+func (s *AlertingService) GetApplicationConfig(ctx context.Context, appName string) (*alertingModels.AlertingConfig, error) {
+	return s.alerting(ctx, newRequest(http.MethodGet, pathf("/applications/%s/alerting", appName)))
+}
+
+// UpdateApplicationConfig updates the alerting config of the application
+func (s *AlertingService) UpdateApplicationConfig(ctx context.Context, appName string, config alertingModels.UpdateAlertingConfig) (*alertingModels.AlertingConfig, error) {
+	req := newRequest(http.MethodPut, pathf("/applications/%s/alerting", appName))
+	req.body = config
+	return s.alerting(ctx, req)
+}
+
+// EnableApplication enables alerting for the application
+func (s *AlertingService) EnableApplication(ctx context.Context, appName string) (*alertingModels.AlertingConfig, error) {
+	return s.alerting(ctx, newRequest(http.MethodPost, pathf("/applications/%s/alerting/enable", appName)))
+}
+
+// DisableApplication disables alerting for the application
+func (s *AlertingService) DisableApplication(ctx context.Context, appName string) (*alertingModels.AlertingConfig, error) {
+	return s.alerting(ctx, newRequest(http.MethodPost, pathf("/applications/%s/alerting/disable", appName)))
+}
+
+// GetEnvironmentConfig gets the alerting config of the environment
+func (s *AlertingService) GetEnvironmentConfig(ctx context.Context, appName, envName string) (*alertingModels.AlertingConfig, error) {
+	return s.alerting(ctx, newRequest(http.MethodGet, pathf("/applications/%s/environments/%s/alerting", appName, envName)))
+}
+
+// UpdateEnvironmentConfig updates the alerting config of the environment
+func (s *AlertingService) UpdateEnvironmentConfig(ctx context.Context, appName, envName string, config alertingModels.UpdateAlertingConfig) (*alertingModels.AlertingConfig, error) {
+	req := newRequest(http.MethodPut, pathf("/applications/%s/environments/%s/alerting", appName, envName))
+	req.body = config
+	return s.alerting(ctx, req)
+}
+
+// EnableEnvironment enables alerting for the environment
+func (s *AlertingService) EnableEnvironment(ctx context.Context, appName, envName string) (*alertingModels.AlertingConfig, error) {
+	return s.alerting(ctx, newRequest(http.MethodPost, pathf("/applications/%s/environments/%s/alerting/enable", appName, envName)))
+}
+
+// DisableEnvironment disables alerting for the environment
+func (s *AlertingService) DisableEnvironment(ctx context.Context, appName, envName string) (*alertingModels.AlertingConfig, error) {
+	return s.alerting(ctx, newRequest(http.MethodPost, pathf("/applications/%s/environments/%s/alerting/disable", appName, envName)))
+}
+
+func (s *AlertingService) alerting(ctx context.Context, req *request) (*alertingModels.AlertingConfig, error) {
+	var config alertingModels.AlertingConfig
+	if err := s.client.do(ctx, req, &config); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	applicationModels "github.com/equinor/radix-api/api/applications/models"
+	jobModels "github.com/equinor/radix-api/api/jobs/models"
+)
+
+// ApplicationsService registration, details and pipelines of applications
+type ApplicationsService struct {
+	client *Client
+}
+
+// ListApplicationsOptions filters the applications
+type ListApplicationsOptions struct {
+	// SSHRepo only list the applications with this repository, e.g. git@github.com:equinor/radix-api.git
+	SSHRepo string
+}
+
+// SearchApplicationsOptions selects the applications to search for, and what to include in the summaries
+type SearchApplicationsOptions struct {
+	Names []string
+	// Filter selects applications by registration and status, see applicationModels.ApplicationSearchFilter
+	Filter                  *applicationModels.ApplicationSearchFilter
+	IncludeLatestJobSummary bool
+	IncludeEnvironments     bool
+}
+
+// List the applications the user has access to
+func (s *ApplicationsService) List(ctx context.Context, options ListApplicationsOptions) ([]applicationModels.ApplicationSummary, error) {
+	req := newRequest(http.MethodGet, "/applications")
+	if options.SSHRepo != "" {
+		req.query.Set("sshRepo", options.SSHRepo)
+	}
+	var apps []applicationModels.ApplicationSummary
+	return apps, s.client.do(ctx, req, &apps)
+}
+
+// Search gets the summaries of the applications by name, and by filter when set
+func (s *ApplicationsService) Search(ctx context.Context, options SearchApplicationsOptions) ([]applicationModels.ApplicationSummary, error) {
+	var req *request
+	if options.Filter != nil {
+		req = newRequest(http.MethodPost, "/applications/_search")
+		req.body = applicationModels.ApplicationsSearchRequest{
+			Names:  options.Names,
+			Filter: options.Filter,
+			IncludeFields: applicationModels.ApplicationSearchIncludeFields{
+				LatestJobSummary: options.IncludeLatestJobSummary,
+				Environments:     options.IncludeEnvironments,
+			},
+		}
+	} else {
+		req = newRequest(http.MethodGet, "/applications/_search")
+		req.query.Set("apps", strings.Join(options.Names, ","))
+		req.query.Set("includeLatestJobSummary", strconv.FormatBool(options.IncludeLatestJobSummary))
+		req.query.Set("includeEnvironments", strconv.FormatBool(options.IncludeEnvironments))
+	}
+	var apps []applicationModels.ApplicationSummary
+	return apps, s.client.do(ctx, req, &apps)
+}
+
+// Get the application. When fields are set, only those fields are returned
+func (s *ApplicationsService) Get(ctx context.Context, appName string, fields ...string) (*applicationModels.Application, error) {
+	req := newRequest(http.MethodGet, pathf("/applications/%s", appName))
+	if len(fields) > 0 {
+		req.query.Set("fields", strings.Join(fields, ","))
+	}
+	var app applicationModels.Application
+	if err := s.client.do(ctx, req, &app); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// Register a new application
+func (s *ApplicationsService) Register(ctx context.Context, registration applicationModels.ApplicationRegistrationRequest) (*applicationModels.ApplicationRegistrationUpsertResponse, error) {
+	req := newRequest(http.MethodPost, "/applications")
+	req.body = registration
+	return s.upsertRegistration(ctx, req)
+}
+
+// ChangeRegistration replaces the registration of the application
+func (s *ApplicationsService) ChangeRegistration(ctx context.Context, appName string, registration applicationModels.ApplicationRegistrationRequest) (*applicationModels.ApplicationRegistrationUpsertResponse, error) {
+	req := newRequest(http.MethodPut, pathf("/applications/%s", appName))
+	req.body = registration
+	return s.upsertRegistration(ctx, req)
+}
+
+// ModifyRegistration changes the fields set in the patch of the registration of the application
+func (s *ApplicationsService) ModifyRegistration(ctx context.Context, appName string, patch applicationModels.ApplicationRegistrationPatchRequest) (*applicationModels.ApplicationRegistrationUpsertResponse, error) {
+	req := newRequest(http.MethodPatch, pathf("/applications/%s", appName))
+	req.body = patch
+	return s.upsertRegistration(ctx, req)
+}
+
+// Clone registers a new application from the registration of the application, and copies the settings selected in the request
+func (s *ApplicationsService) Clone(ctx context.Context, appName string, cloneRequest applicationModels.ApplicationCloneRequest) (*applicationModels.ApplicationCloneResponse, error) {
+	req := newRequest(http.MethodPost, pathf("/applications/%s/clone", appName))
+	req.body = cloneRequest
+	var response applicationModels.ApplicationCloneResponse
+	if err := s.client.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Export everything radix-api manages for the application, without secret values
+func (s *ApplicationsService) Export(ctx context.Context, appName string) (*applicationModels.ApplicationBundle, error) {
+	var bundle applicationModels.ApplicationBundle
+	if err := s.client.do(ctx, newRequest(http.MethodGet, pathf("/applications/%s/export", appName)), &bundle); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// Import recreates the application from a bundle returned by Export, and reports items which need manual secret input
+func (s *ApplicationsService) Import(ctx context.Context, importRequest applicationModels.ApplicationImportRequest) (*applicationModels.ApplicationImportResponse, error) {
+	req := newRequest(http.MethodPost, "/applications/_import")
+	req.body = importRequest
+	var response applicationModels.ApplicationImportResponse
+	if err := s.client.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (s *ApplicationsService) upsertRegistration(ctx context.Context, req *request) (*applicationModels.ApplicationRegistrationUpsertResponse, error) {
+	var response applicationModels.ApplicationRegistrationUpsertResponse
+	if err := s.client.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Delete the application
+func (s *ApplicationsService) Delete(ctx context.Context, appName string) error {
+	return s.client.do(ctx, newRequest(http.MethodDelete, pathf("/applications/%s", appName)), nil)
+}
+
+// DeletionPlan lists the resources which are deleted with the application, without deleting it
+func (s *ApplicationsService) DeletionPlan(ctx context.Context, appName string) (*applicationModels.ApplicationDeletionPlan, error) {
+	req := newRequest(http.MethodDelete, pathf("/applications/%s", appName))
+	req.query.Set("dryRun", "true")
+	var plan applicationModels.ApplicationDeletionPlan
+	if err := s.client.do(ctx, req, &plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// Decommission stops all environments of the application, and deletes it when the grace period has passed
+func (s *ApplicationsService) Decommission(ctx context.Context, appName string) (*applicationModels.ApplicationDecommission, error) {
+	return s.decommission(ctx, newRequest(http.MethodPost, pathf("/applications/%s/decommission", appName)))
+}
+
+// GetDecommission gets the decommission of the application
+func (s *ApplicationsService) GetDecommission(ctx context.Context, appName string) (*applicationModels.ApplicationDecommission, error) {
+	return s.decommission(ctx, newRequest(http.MethodGet, pathf("/applications/%s/decommission", appName)))
+}
+
+// CancelDecommission cancels the decommission of the application, and starts the environments stopped by it
+func (s *ApplicationsService) CancelDecommission(ctx context.Context, appName string) error {
+	return s.client.do(ctx, newRequest(http.MethodDelete, pathf("/applications/%s/decommission", appName)), nil)
+}
+
+func (s *ApplicationsService) decommission(ctx context.Context, req *request) (*applicationModels.ApplicationDecommission, error) {
+	var decommission applicationModels.ApplicationDecommission
+	if err := s.client.do(ctx, req, &decommission); err != nil {
+		return nil, err
+	}
+	return &decommission, nil
+}
+
+// ProposeOwnershipTransfer proposes a new owner and admin groups for the application
+func (s *ApplicationsService) ProposeOwnershipTransfer(ctx context.Context, appName string, transferRequest applicationModels.OwnershipTransferRequest) (*applicationModels.OwnershipTransfer, error) {
+	req := newRequest(http.MethodPost, pathf("/applications/%s/ownership-transfer", appName))
+	req.body = transferRequest
+	var transfer applicationModels.OwnershipTransfer
+	if err := s.client.do(ctx, req, &transfer); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// GetOwnershipTransfer gets the proposed ownership transfer of the application
+func (s *ApplicationsService) GetOwnershipTransfer(ctx context.Context, appName string) (*applicationModels.OwnershipTransfer, error) {
+	var transfer applicationModels.OwnershipTransfer
+	if err := s.client.do(ctx, newRequest(http.MethodGet, pathf("/applications/%s/ownership-transfer", appName)), &transfer); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+// CancelOwnershipTransfer cancels the proposed ownership transfer of the application
+func (s *ApplicationsService) CancelOwnershipTransfer(ctx context.Context, appName string) error {
+	return s.client.do(ctx, newRequest(http.MethodDelete, pathf("/applications/%s/ownership-transfer", appName)), nil)
+}
+
+// AcceptOwnershipTransfer accepts the proposed ownership transfer of the application, changing its owner and admin groups
+func (s *ApplicationsService) AcceptOwnershipTransfer(ctx context.Context, appName string) (*applicationModels.ApplicationRegistration, error) {
+	var registration applicationModels.ApplicationRegistration
+	if err := s.client.do(ctx, newRequest(http.MethodPost, pathf("/applications/%s/ownership-transfer/accept", appName)), &registration); err != nil {
+		return nil, err
+	}
+	return &registration, nil
+}
+
+// TriggerBuild triggers a pipeline job building the branch
+func (s *ApplicationsService) TriggerBuild(ctx context.Context, appName string, parameters applicationModels.PipelineParametersBuild, options ...RequestOption) (*jobModels.JobSummary, error) {
+	return s.triggerPipeline(ctx, appName, "build", parameters, options)
+}
+
+// TriggerBuildDeploy triggers a pipeline job building and deploying the branch
+func (s *ApplicationsService) TriggerBuildDeploy(ctx context.Context, appName string, parameters applicationModels.PipelineParametersBuild, options ...RequestOption) (*jobModels.JobSummary, error) {
+	return s.triggerPipeline(ctx, appName, "build-deploy", parameters, options)
+}
+
+// TriggerDeploy triggers a pipeline job deploying to an environment without building
+func (s *ApplicationsService) TriggerDeploy(ctx context.Context, appName string, parameters applicationModels.PipelineParametersDeploy, options ...RequestOption) (*jobModels.JobSummary, error) {
+	return s.triggerPipeline(ctx, appName, "deploy", parameters, options)
+}
+
+// TriggerPromote triggers a pipeline job promoting a deployment to an environment
+func (s *ApplicationsService) TriggerPromote(ctx context.Context, appName string, parameters applicationModels.PipelineParametersPromote, options ...RequestOption) (*jobModels.JobSummary, error) {
+	return s.triggerPipeline(ctx, appName, "promote", parameters, options)
+}
+
+// TriggerApplyConfig triggers a pipeline job applying the radixconfig without deploying
+func (s *ApplicationsService) TriggerApplyConfig(ctx context.Context, appName string, parameters applicationModels.PipelineParametersApplyConfig, options ...RequestOption) (*jobModels.JobSummary, error) {
+	return s.triggerPipeline(ctx, appName, "apply-config", parameters, options)
+}
+
+func (s *ApplicationsService) triggerPipeline(ctx context.Context, appName, pipeline string, parameters any, options []RequestOption) (*jobModels.JobSummary, error) {
+	req := newRequest(http.MethodPost, pathf("/applications/%s/pipelines/%s", appName, pipeline), options...)
+	req.body = parameters
+	var job jobModels.JobSummary
+	if err := s.client.do(ctx, req, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
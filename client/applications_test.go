@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	applicationModels "github.com/equinor/radix-api/api/applications/models"
+	jobModels "github.com/equinor/radix-api/api/jobs/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplications_TriggerBuildDeploy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v1/applications/any-app/pipelines/build-deploy", r.URL.Path)
+		assert.Equal(t, "any-key", r.Header.Get("Idempotency-Key"))
+		var parameters applicationModels.PipelineParametersBuild
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&parameters))
+		assert.Equal(t, "main", parameters.Branch)
+		_ = json.NewEncoder(w).Encode(jobModels.JobSummary{Name: "any-job", AppName: "any-app", Branch: parameters.Branch})
+	}))
+	defer server.Close()
+	c, err := New(server.URL + "/api/v1")
+	require.NoError(t, err)
+
+	job, err := c.Applications.TriggerBuildDeploy(context.Background(), "any-app", applicationModels.PipelineParametersBuild{Branch: "main"}, WithIdempotencyKey("any-key"))
+	require.NoError(t, err)
+	assert.Equal(t, "any-job", job.Name)
+}
+
+func TestApplications_Get(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/applications/any-app", r.URL.Path)
+		assert.Equal(t, "name,environments", r.URL.Query().Get("fields"))
+		_ = json.NewEncoder(w).Encode(applicationModels.Application{Name: "any-app"})
+	}))
+	defer server.Close()
+	c, err := New(server.URL)
+	require.NoError(t, err)
+
+	app, err := c.Applications.Get(context.Background(), "any-app", "name", "environments")
+	require.NoError(t, err)
+	assert.Equal(t, "any-app", app.Name)
+}
@@ -0,0 +1,239 @@
+// Package client is a Go client for the Radix API, using the same request and response models as the API.
+//
+// Errors returned by the API are returned as *Error, with the problem details of the response:
+//
+//	c, err := client.New("https://api.radix.equinor.com/api/v1", client.WithToken(token))
+//	app, err := c.Applications.Get(ctx, "my-app")
+//	if client.IsNotFound(err) {
+//		...
+//	}
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	impersonateUserHeader  = "Impersonate-User"
+	impersonateGroupHeader = "Impersonate-Group"
+	idempotencyKeyHeader   = "Idempotency-Key"
+)
+
+// TokenSource returns the bearer token for a request
+type TokenSource func(ctx context.Context) (string, error)
+
+// Option configures the Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests. Defaults to http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken authenticates all requests with a static bearer token
+func WithToken(token string) Option {
+	return WithTokenSource(func(context.Context) (string, error) { return token, nil })
+}
+
+// WithTokenSource authenticates each request with the bearer token returned by tokenSource, e.g. to refresh expired tokens
+func WithTokenSource(tokenSource TokenSource) Option {
+	return func(c *Client) {
+		c.tokenSource = tokenSource
+	}
+}
+
+// WithUserAgent sets the User-Agent header of all requests
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithImpersonation sends all requests as the user and groups. Works only with custom setup of cluster
+func WithImpersonation(user string, groups ...string) Option {
+	return func(c *Client) {
+		c.impersonateUser = user
+		c.impersonateGroups = groups
+	}
+}
+
+// RequestOption configures a single request
+type RequestOption func(*request)
+
+// WithIdempotencyKey sets the Idempotency-Key header, so a retried request to trigger a pipeline returns the job of the first request
+func WithIdempotencyKey(key string) RequestOption {
+	return func(r *request) {
+		r.header.Set(idempotencyKeyHeader, key)
+	}
+}
+
+// Client for the Radix API
+type Client struct {
+	baseURL           *url.URL
+	httpClient        *http.Client
+	tokenSource       TokenSource
+	userAgent         string
+	impersonateUser   string
+	impersonateGroups []string
+
+	Applications      *ApplicationsService
+	Environments      *EnvironmentsService
+	Jobs              *JobsService
+	Deployments       *DeploymentsService
+	Secrets           *SecretsService
+	Alerting          *AlertingService
+	PipelineSchedules *PipelineSchedulesService
+}
+
+// New Constructor for Client. baseURL is the URL of the API including the version, e.g. https://api.radix.equinor.com/api/v1
+func New(baseURL string, options ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: must be an absolute URL", baseURL)
+	}
+
+	c := &Client{baseURL: u, httpClient: http.DefaultClient}
+	for _, option := range options {
+		option(c)
+	}
+
+	c.Applications = &ApplicationsService{client: c}
+	c.Environments = &EnvironmentsService{client: c}
+	c.Jobs = &JobsService{client: c}
+	c.Deployments = &DeploymentsService{client: c}
+	c.Secrets = &SecretsService{client: c}
+	c.Alerting = &AlertingService{client: c}
+	c.PipelineSchedules = &PipelineSchedulesService{client: c}
+	return c, nil
+}
+
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	header http.Header
+	body   any
+}
+
+func newRequest(method, path string, options ...RequestOption) *request {
+	r := &request{method: method, path: path, query: url.Values{}, header: http.Header{}}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// pathf formats the path, escaping each of the path segments in args
+func pathf(format string, args ...string) string {
+	escaped := make([]any, 0, len(args))
+	for _, arg := range args {
+		escaped = append(escaped, url.PathEscape(arg))
+	}
+	return fmt.Sprintf(format, escaped...)
+}
+
+// do sends the request, and decodes the JSON response into out unless out is nil
+func (c *Client) do(ctx context.Context, req *request, out any) error {
+	resp, err := c.send(ctx, req, "application/json")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response of %s %s: %w", req.method, req.path, err)
+	}
+	return nil
+}
+
+// doText sends the request, and returns the response body as text
+func (c *Client) doText(ctx context.Context, req *request) (string, error) {
+	resp, err := c.send(ctx, req, "text/plain")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// send sends the request, and returns the response when the status is successful. The caller must close the response body
+func (c *Client) send(ctx context.Context, req *request, accept string) (*http.Response, error) {
+	httpReq, err := c.newHTTPRequest(ctx, req, accept)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer func() { _ = resp.Body.Close() }()
+		return nil, newError(resp)
+	}
+	return resp, nil
+}
+
+func (c *Client) newHTTPRequest(ctx context.Context, req *request, accept string) (*http.Request, error) {
+	u := c.baseURL.JoinPath()
+	u.RawPath = c.baseURL.EscapedPath() + req.path
+	u.Path, _ = url.PathUnescape(u.RawPath)
+	u.RawQuery = req.query.Encode()
+
+	var body io.Reader
+	if req.body != nil {
+		data, err := json.Marshal(req.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range req.header {
+		httpReq.Header[name] = values
+	}
+	httpReq.Header.Set("Accept", accept)
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if c.userAgent != "" {
+		httpReq.Header.Set("User-Agent", c.userAgent)
+	}
+	if c.impersonateUser != "" {
+		httpReq.Header.Set(impersonateUserHeader, c.impersonateUser)
+		httpReq.Header.Set(impersonateGroupHeader, strings.Join(c.impersonateGroups, ","))
+	}
+	if c.tokenSource != nil {
+		token, err := c.tokenSource(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token: %w", err)
+		}
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	return httpReq, nil
+}
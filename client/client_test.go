@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/equinor/radix-api/api/utils/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_InvalidBaseURL(t *testing.T) {
+	_, err := New("api/v1")
+	assert.Error(t, err)
+}
+
+func TestClient_SendsRequest(t *testing.T) {
+	var actual *http.Request
+	var actualBody map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actual = r
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&actualBody))
+		_, _ = w.Write([]byte(`{"name":"any-value"}`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL+"/api/v1/", WithToken("any-token"), WithUserAgent("any-agent"), WithImpersonation("any-user", "group1", "group2"))
+	require.NoError(t, err)
+	req := newRequest(http.MethodPost, pathf("/applications/%s/any", "app/name"), WithIdempotencyKey("any-key"))
+	req.query.Set("param", "value")
+	req.body = map[string]string{"key": "value"}
+	var out struct{ Name string }
+	require.NoError(t, c.do(context.Background(), req, &out))
+
+	assert.Equal(t, "any-value", out.Name)
+	assert.Equal(t, http.MethodPost, actual.Method)
+	assert.Equal(t, "/api/v1/applications/app%2Fname/any", actual.URL.EscapedPath())
+	assert.Equal(t, "value", actual.URL.Query().Get("param"))
+	assert.Equal(t, map[string]string{"key": "value"}, actualBody)
+	assert.Equal(t, "Bearer any-token", actual.Header.Get("Authorization"))
+	assert.Equal(t, "any-agent", actual.Header.Get("User-Agent"))
+	assert.Equal(t, "any-user", actual.Header.Get("Impersonate-User"))
+	assert.Equal(t, "group1,group2", actual.Header.Get("Impersonate-Group"))
+	assert.Equal(t, "any-key", actual.Header.Get("Idempotency-Key"))
+	assert.Equal(t, "application/json", actual.Header.Get("Content-Type"))
+}
+
+func TestClient_TokenSourceError(t *testing.T) {
+	c, err := New("http://localhost", WithTokenSource(func(context.Context) (string, error) { return "", errors.New("any error") }))
+	require.NoError(t, err)
+
+	err = c.do(context.Background(), newRequest(http.MethodGet, "/any"), nil)
+	assert.ErrorContains(t, err, "any error")
+}
+
+func TestClient_ReturnsTypedErrors(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		contentType    string
+		body           string
+		status         int
+		expectedCode   problem.Code
+		expectedDetail string
+	}{
+		{
+			name:           "problem details",
+			contentType:    problem.ContentType,
+			body:           `{"type":"urn:radix-api:problem:application-not-found","title":"Not Found","status":404,"detail":"application any-app not found","code":"application-not-found","requestId":"any-id"}`,
+			status:         http.StatusNotFound,
+			expectedCode:   "application-not-found",
+			expectedDetail: "application any-app not found",
+		},
+		{
+			name:           "plain text",
+			contentType:    "text/plain",
+			body:           "any message\n",
+			status:         http.StatusBadGateway,
+			expectedDetail: "any message",
+		},
+	}
+
+	for _, ts := range scenarios {
+		t.Run(ts.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", ts.contentType)
+				w.WriteHeader(ts.status)
+				_, _ = w.Write([]byte(ts.body))
+			}))
+			defer server.Close()
+			c, err := New(server.URL)
+			require.NoError(t, err)
+
+			err = c.do(context.Background(), newRequest(http.MethodGet, "/any"), nil)
+
+			var apiErr *Error
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, ts.status, apiErr.StatusCode)
+			assert.Equal(t, ts.status, apiErr.Problem.Status)
+			assert.Equal(t, http.StatusText(ts.status), apiErr.Problem.Title)
+			assert.Equal(t, ts.expectedCode, apiErr.Code())
+			assert.Equal(t, ts.expectedDetail, apiErr.Problem.Detail)
+			assert.True(t, HasCode(err, ts.expectedCode))
+			assert.Equal(t, ts.status == http.StatusNotFound, IsNotFound(err))
+		})
+	}
+}
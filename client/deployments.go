@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	deploymentModels "github.com/equinor/radix-api/api/deployments/models"
+	"github.com/equinor/radix-api/api/utils/pagination"
+)
+
+// DeploymentsService deployments of an application
+type DeploymentsService struct {
+	client *Client
+}
+
+// ListDeploymentsOptions filters the deployments
+type ListDeploymentsOptions struct {
+	// Environment only list the deployments to this environment
+	Environment string
+	// Latest only list the latest deployment to each environment
+	Latest bool
+}
+
+func (o ListDeploymentsOptions) apply(r *request) {
+	if o.Environment != "" {
+		r.query.Set("environment", o.Environment)
+	}
+	if o.Latest {
+		r.query.Set("latest", "true")
+	}
+}
+
+// List the deployments of the application, newest first
+func (s *DeploymentsService) List(ctx context.Context, appName string, options ListDeploymentsOptions) ([]deploymentModels.DeploymentSummary, error) {
+	req := newRequest(http.MethodGet, pathf("/applications/%s/deployments", appName))
+	options.apply(req)
+	var deployments []deploymentModels.DeploymentSummary
+	return deployments, s.client.do(ctx, req, &deployments)
+}
+
+// ListPage lists a page of the deployments of the application. Pass the Next of the page as Continue to get the following page
+func (s *DeploymentsService) ListPage(ctx context.Context, appName string, options ListDeploymentsOptions, params pagination.Params) (*pagination.Page[deploymentModels.DeploymentSummary], error) {
+	req := newRequest(http.MethodGet, pathf("/applications/%s/deployments/_page", appName))
+	options.apply(req)
+	setPageParams(req, params)
+	var page pagination.Page[deploymentModels.DeploymentSummary]
+	if err := s.client.do(ctx, req, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Get the deployment
+func (s *DeploymentsService) Get(ctx context.Context, appName, deploymentName string) (*deploymentModels.Deployment, error) {
+	var deployment deploymentModels.Deployment
+	if err := s.client.do(ctx, newRequest(http.MethodGet, pathf("/applications/%s/deployments/%s", appName, deploymentName)), &deployment); err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+// ListComponents lists the components of the deployment
+func (s *DeploymentsService) ListComponents(ctx context.Context, appName, deploymentName string) ([]deploymentModels.Component, error) {
+	var components []deploymentModels.Component
+	return components, s.client.do(ctx, newRequest(http.MethodGet, pathf("/applications/%s/deployments/%s/components", appName, deploymentName)), &components)
+}
+
+// GetReplicaLog gets the log of a replica of a component in the deployment
+func (s *DeploymentsService) GetReplicaLog(ctx context.Context, appName, deploymentName, componentName, podName string, options LogOptions) (string, error) {
+	return s.client.getLog(ctx, deploymentReplicaLogPath(appName, deploymentName, componentName, podName), options)
+}
+
+// StreamReplicaLog follows the log of a replica of a component in the deployment
+func (s *DeploymentsService) StreamReplicaLog(ctx context.Context, appName, deploymentName, componentName, podName string, options LogOptions) (*Stream[string], error) {
+	return s.client.streamLog(ctx, deploymentReplicaLogPath(appName, deploymentName, componentName, podName), options)
+}
+
+func deploymentReplicaLogPath(appName, deploymentName, componentName, podName string) string {
+	return pathf("/applications/%s/deployments/%s/components/%s/replicas/%s/logs", appName, deploymentName, componentName, podName)
+}
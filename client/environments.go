@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	environmentModels "github.com/equinor/radix-api/api/environments/models"
+)
+
+// EnvironmentsService environments of an application, and their components and scheduled jobs
+type EnvironmentsService struct {
+	client *Client
+}
+
+// List the environments of the application
+func (s *EnvironmentsService) List(ctx context.Context, appName string) ([]environmentModels.EnvironmentSummary, error) {
+	var envs []environmentModels.EnvironmentSummary
+	return envs, s.client.do(ctx, newRequest(http.MethodGet, pathf("/applications/%s/environments", appName)), &envs)
+}
+
+// Get the environment. When fields are set, only those fields are returned
+func (s *EnvironmentsService) Get(ctx context.Context, appName, envName string, fields ...string) (*environmentModels.Environment, error) {
+	req := newRequest(http.MethodGet, pathf("/applications/%s/environments/%s", appName, envName))
+	if len(fields) > 0 {
+		req.query.Set("fields", strings.Join(fields, ","))
+	}
+	var env environmentModels.Environment
+	if err := s.client.do(ctx, req, &env); err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+// Create an orphaned environment, which is not in the radixconfig
+func (s *EnvironmentsService) Create(ctx context.Context, appName, envName string) error {
+	return s.client.do(ctx, newRequest(http.MethodPost, pathf("/applications/%s/environments/%s", appName, envName)), nil)
+}
+
+// Delete an orphaned environment
+func (s *EnvironmentsService) Delete(ctx context.Context, appName, envName string) error {
+	return s.client.do(ctx, newRequest(http.MethodDelete, pathf("/applications/%s/environments/%s", appName, envName)), nil)
+}
+
+// Watch streams the changes of the environment, starting with the current state
+func (s *EnvironmentsService) Watch(ctx context.Context, appName, envName string) (*Stream[environmentModels.EnvironmentEvent], error) {
+	body, err := s.client.stream(ctx, newRequest(http.MethodGet, pathf("/applications/%s/environments/%s/watch", appName, envName)))
+	if err != nil {
+		return nil, err
+	}
+	return newStream(body, func(e event) (environmentModels.EnvironmentEvent, error) {
+		var envEvent environmentModels.EnvironmentEvent
+		err := json.Unmarshal([]byte(e.data), &envEvent)
+		return envEvent, err
+	}), nil
+}
+
+// Stop all components in the environment
+func (s *EnvironmentsService) Stop(ctx context.Context, appName, envName string) error {
+	return s.environmentAction(ctx, appName, envName, "stop")
+}
+
+// Start all components in the environment
+func (s *EnvironmentsService) Start(ctx context.Context, appName, envName string) error {
+	return s.environmentAction(ctx, appName, envName, "start")
+}
+
+// Restart all components in the environment
+func (s *EnvironmentsService) Restart(ctx context.Context, appName, envName string) error {
+	return s.environmentAction(ctx, appName, envName, "restart")
+}
+
+// ResetScale resets all manually scaled components in the environment to the replicas in the radixconfig
+func (s *EnvironmentsService) ResetScale(ctx context.Context, appName, envName string) error {
+	return s.environmentAction(ctx, appName, envName, "reset-scale")
+}
+
+func (s *EnvironmentsService) environmentAction(ctx context.Context, appName, envName, action string) error {
+	return s.client.do(ctx, newRequest(http.MethodPost, pathf("/applications/%s/environments/%s/", appName, envName)+action), nil)
+}
+
+// StopComponent stops the component
+func (s *EnvironmentsService) StopComponent(ctx context.Context, appName, envName, componentName string) error {
+	return s.componentAction(ctx, appName, envName, componentName, "stop")
+}
+
+// StartComponent starts the stopped component
+func (s *EnvironmentsService) StartComponent(ctx context.Context, appName, envName, componentName string) error {
+	return s.componentAction(ctx, appName, envName, componentName, "start")
+}
+
+// RestartComponent restarts the replicas of the component
+func (s *EnvironmentsService) RestartComponent(ctx context.Context, appName, envName, componentName string) error {
+	return s.componentAction(ctx, appName, envName, componentName, "restart")
+}
+
+// ResetScaleComponent resets the manually scaled component to the replicas in the radixconfig
+func (s *EnvironmentsService) ResetScaleComponent(ctx context.Context, appName, envName, componentName string) error {
+	return s.componentAction(ctx, appName, envName, componentName, "reset-scale")
+}
+
+// ScaleComponent manually scales the component to the number of replicas
+func (s *EnvironmentsService) ScaleComponent(ctx context.Context, appName, envName, componentName string, replicas int) error {
+	return s.componentAction(ctx, appName, envName, componentName, "scale/"+strconv.Itoa(replicas))
+}
+
+func (s *EnvironmentsService) componentAction(ctx context.Context, appName, envName, componentName, action string) error {
+	return s.client.do(ctx, newRequest(http.MethodPost, pathf("/applications/%s/environments/%s/components/%s/", appName, envName, componentName)+action), nil)
+}
+
+// GetReplicaLog gets the log of a replica of the component
+func (s *EnvironmentsService) GetReplicaLog(ctx context.Context, appName, envName, componentName, podName string, options LogOptions) (string, error) {
+	return s.client.getLog(ctx, replicaLogPath(appName, envName, componentName, podName), options)
+}
+
+// StreamReplicaLog follows the log of a replica of the component
+func (s *EnvironmentsService) StreamReplicaLog(ctx context.Context, appName, envName, componentName, podName string, options LogOptions) (*Stream[string], error) {
+	return s.client.streamLog(ctx, replicaLogPath(appName, envName, componentName, podName), options)
+}
+
+// GetScheduledJobLog gets the log of a scheduled job of the job component
+func (s *EnvironmentsService) GetScheduledJobLog(ctx context.Context, appName, envName, jobComponentName, scheduledJobName string, options LogOptions) (string, error) {
+	return s.client.getLog(ctx, scheduledJobLogPath(appName, envName, jobComponentName, scheduledJobName), options)
+}
+
+// StreamScheduledJobLog follows the log of a scheduled job of the job component
+func (s *EnvironmentsService) StreamScheduledJobLog(ctx context.Context, appName, envName, jobComponentName, scheduledJobName string, options LogOptions) (*Stream[string], error) {
+	return s.client.streamLog(ctx, scheduledJobLogPath(appName, envName, jobComponentName, scheduledJobName), options)
+}
+
+func replicaLogPath(appName, envName, componentName, podName string) string {
+	return pathf("/applications/%s/environments/%s/components/%s/replicas/%s/logs", appName, envName, componentName, podName)
+}
+
+func scheduledJobLogPath(appName, envName, jobComponentName, scheduledJobName string) string {
+	return pathf("/applications/%s/environments/%s/jobcomponents/%s/scheduledjobs/%s/logs", appName, envName, jobComponentName, scheduledJobName)
+}
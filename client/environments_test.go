@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	environmentModels "github.com/equinor/radix-api/api/environments/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironments_Watch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/applications/any-app/environments/dev/watch", r.URL.Path)
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "event: started\n\n")
+		_, _ = fmt.Fprint(w, "event: ComponentStatusChanged\ndata: {\"type\":\"ComponentStatusChanged\",\"component\":\"web\"}\n\n")
+		_, _ = fmt.Fprint(w, "event: completed\n\n")
+	}))
+	defer server.Close()
+	c, err := New(server.URL)
+	require.NoError(t, err)
+
+	stream, err := c.Environments.Watch(context.Background(), "any-app", "dev")
+	require.NoError(t, err)
+	defer func() { _ = stream.Close() }()
+
+	var events []environmentModels.EnvironmentEvent
+	for stream.Next() {
+		events = append(events, stream.Value())
+	}
+	require.NoError(t, stream.Err())
+	assert.Equal(t, []environmentModels.EnvironmentEvent{{Type: environmentModels.ComponentStatusChanged, Component: "web"}}, events)
+}
+
+func TestEnvironments_ScaleComponent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/applications/any-app/environments/dev/components/web/scale/3", r.URL.Path)
+		_, _ = fmt.Fprint(w, `"Success"`)
+	}))
+	defer server.Close()
+	c, err := New(server.URL)
+	require.NoError(t, err)
+
+	assert.NoError(t, c.Environments.ScaleComponent(context.Background(), "any-app", "dev", "web", 3))
+}
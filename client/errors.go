@@ -0,0 +1,94 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/equinor/radix-api/api/utils/problem"
+)
+
+// maxErrorBodySize limits how much of an error response is read
+const maxErrorBodySize = 1 << 20
+
+// Error is returned when the API responds with an unsuccessful status
+type Error struct {
+	// StatusCode HTTP status code of the response
+	StatusCode int
+	// Problem details of the response. Title and Detail are set from the status and the body when the response is not problem details
+	Problem problem.Problem
+}
+
+func (e *Error) Error() string {
+	if e.Problem.Detail != "" {
+		return fmt.Sprintf("radix-api: %d %s: %s", e.StatusCode, e.Problem.Title, e.Problem.Detail)
+	}
+	return fmt.Sprintf("radix-api: %d %s", e.StatusCode, e.Problem.Title)
+}
+
+// Code returns the stable error code of the problem
+func (e *Error) Code() problem.Code {
+	return e.Problem.Code
+}
+
+// HasCode returns true when err is an *Error with the error code
+func HasCode(err error, code problem.Code) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code() == code
+}
+
+// HasStatus returns true when err is an *Error with the HTTP status code
+func HasStatus(err error, statusCode int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// IsNotFound returns true when err is a 404 Not Found response
+func IsNotFound(err error) bool {
+	return HasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict returns true when err is a 409 Conflict response
+func IsConflict(err error) bool {
+	return HasStatus(err, http.StatusConflict)
+}
+
+// IsForbidden returns true when err is a 403 Forbidden response
+func IsForbidden(err error) bool {
+	return HasStatus(err, http.StatusForbidden)
+}
+
+// IsUnauthorized returns true when err is a 401 Unauthorized response
+func IsUnauthorized(err error) bool {
+	return HasStatus(err, http.StatusUnauthorized)
+}
+
+// IsTooManyRequests returns true when err is a 429 Too Many Requests response
+func IsTooManyRequests(err error) bool {
+	return HasStatus(err, http.StatusTooManyRequests)
+}
+
+func newError(resp *http.Response) error {
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == problem.ContentType || mediaType == "application/json" {
+		_ = json.Unmarshal(body, &apiErr.Problem)
+	}
+	if apiErr.Problem.Title == "" {
+		apiErr.Problem.Title = http.StatusText(resp.StatusCode)
+	}
+	if apiErr.Problem.Detail == "" {
+		apiErr.Problem.Detail = strings.TrimSpace(apiErr.Problem.Message)
+	}
+	if apiErr.Problem.Detail == "" && mediaType != problem.ContentType {
+		apiErr.Problem.Detail = strings.TrimSpace(string(body))
+	}
+	apiErr.Problem.Status = resp.StatusCode
+	return apiErr
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	jobModels "github.com/equinor/radix-api/api/jobs/models"
+	"github.com/equinor/radix-api/api/utils/pagination"
+)
+
+// JobsService pipeline jobs of an application
+type JobsService struct {
+	client *Client
+}
+
+// List all pipeline jobs of the application, newest first
+func (s *JobsService) List(ctx context.Context, appName string) ([]jobModels.JobSummary, error) {
+	var jobs []jobModels.JobSummary
+	return jobs, s.client.do(ctx, newRequest(http.MethodGet, pathf("/applications/%s/jobs", appName)), &jobs)
+}
+
+// ListPage lists a page of the pipeline jobs of the application. Pass the Next of the page as Continue to get the following page
+func (s *JobsService) ListPage(ctx context.Context, appName string, params pagination.Params) (*pagination.Page[jobModels.JobSummary], error) {
+	req := newRequest(http.MethodGet, pathf("/applications/%s/jobs/_page", appName))
+	setPageParams(req, params)
+	var page pagination.Page[jobModels.JobSummary]
+	if err := s.client.do(ctx, req, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Get the pipeline job
+func (s *JobsService) Get(ctx context.Context, appName, jobName string) (*jobModels.Job, error) {
+	var job jobModels.Job
+	if err := s.client.do(ctx, newRequest(http.MethodGet, pathf("/applications/%s/jobs/%s", appName, jobName)), &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Stop the running pipeline job
+func (s *JobsService) Stop(ctx context.Context, appName, jobName string) error {
+	return s.client.do(ctx, newRequest(http.MethodPost, pathf("/applications/%s/jobs/%s/stop", appName, jobName)), nil)
+}
+
+// Rerun the failed or stopped pipeline job
+func (s *JobsService) Rerun(ctx context.Context, appName, jobName string) error {
+	return s.client.do(ctx, newRequest(http.MethodPost, pathf("/applications/%s/jobs/%s/rerun", appName, jobName)), nil)
+}
+
+// GetStepLog gets the log of a step of the pipeline job
+func (s *JobsService) GetStepLog(ctx context.Context, appName, jobName, stepName string, options LogOptions) (string, error) {
+	return s.client.getLog(ctx, pathf("/applications/%s/jobs/%s/logs/%s", appName, jobName, stepName), options)
+}
+
+// StreamStepLog follows the log of a step of the pipeline job
+func (s *JobsService) StreamStepLog(ctx context.Context, appName, jobName, stepName string, options LogOptions) (*Stream[string], error) {
+	return s.client.streamLog(ctx, pathf("/applications/%s/jobs/%s/logs/%s", appName, jobName, stepName), options)
+}
+
+// setPageParams sets the paging query parameters. The page has pagination.MaxLimit items when no limit is set
+func setPageParams(req *request, params pagination.Params) {
+	if params.Limit > 0 {
+		req.query.Set("limit", strconv.Itoa(params.Limit))
+	}
+	if params.Continue != "" {
+		req.query.Set("continue", params.Continue)
+	}
+}
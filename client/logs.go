@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// LogOptions selects the log lines to get
+type LogOptions struct {
+	// SinceTime only get log lines after this time
+	SinceTime *time.Time
+	// Lines only get this number of the last log lines
+	Lines *int64
+	// Previous get the log of the previous container, e.g. after a restart. Not supported for all logs
+	Previous bool
+}
+
+func (o LogOptions) apply(r *request) {
+	if o.SinceTime != nil {
+		r.query.Set("sinceTime", o.SinceTime.Format(time.RFC3339))
+	}
+	if o.Lines != nil {
+		r.query.Set("lines", strconv.FormatInt(*o.Lines, 10))
+	}
+	if o.Previous {
+		r.query.Set("previous", "true")
+	}
+}
+
+func (c *Client) getLog(ctx context.Context, path string, options LogOptions) (string, error) {
+	req := newRequest(http.MethodGet, path)
+	options.apply(req)
+	return c.doText(ctx, req)
+}
+
+func (c *Client) streamLog(ctx context.Context, path string, options LogOptions) (*Stream[string], error) {
+	req := newRequest(http.MethodGet, path)
+	options.apply(req)
+	req.query.Set("follow", "true")
+	return c.streamLines(ctx, req)
+}
@@ -0,0 +1,51 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	pipelineScheduleModels "github.com/equinor/radix-api/api/pipelineschedules/models"
+)
+
+// PipelineSchedulesService schedules starting pipeline jobs for applications
+type PipelineSchedulesService struct {
+	client *Client
+}
+
+// List lists the pipeline schedules of the application, sorted by name
+func (s *PipelineSchedulesService) List(ctx context.Context, appName string) ([]pipelineScheduleModels.PipelineSchedule, error) {
+	var schedules []pipelineScheduleModels.PipelineSchedule
+	return schedules, s.client.do(ctx, newRequest(http.MethodGet, pathf("/applications/%s/pipelineschedules", appName)), &schedules)
+}
+
+// Get gets a pipeline schedule of the application
+func (s *PipelineSchedulesService) Get(ctx context.Context, appName, scheduleName string) (*pipelineScheduleModels.PipelineSchedule, error) {
+	return s.schedule(ctx, newRequest(http.MethodGet, pathf("/applications/%s/pipelineschedules/%s", appName, scheduleName)))
+}
+
+// Create creates a pipeline schedule for the application
+func (s *PipelineSchedulesService) Create(ctx context.Context, appName string, schedule pipelineScheduleModels.PipelineScheduleRequest) (*pipelineScheduleModels.PipelineSchedule, error) {
+	req := newRequest(http.MethodPost, pathf("/applications/%s/pipelineschedules", appName))
+	req.body = schedule
+	return s.schedule(ctx, req)
+}
+
+// Update changes a pipeline schedule of the application
+func (s *PipelineSchedulesService) Update(ctx context.Context, appName, scheduleName string, schedule pipelineScheduleModels.PipelineScheduleRequest) (*pipelineScheduleModels.PipelineSchedule, error) {
+	req := newRequest(http.MethodPut, pathf("/applications/%s/pipelineschedules/%s", appName, scheduleName))
+	req.body = schedule
+	return s.schedule(ctx, req)
+}
+
+// Delete deletes a pipeline schedule of the application
+func (s *PipelineSchedulesService) Delete(ctx context.Context, appName, scheduleName string) error {
+	return s.client.do(ctx, newRequest(http.MethodDelete, pathf("/applications/%s/pipelineschedules/%s", appName, scheduleName)), nil)
+}
+
+func (s *PipelineSchedulesService) schedule(ctx context.Context, req *request) (*pipelineScheduleModels.PipelineSchedule, error) {
+	var schedule pipelineScheduleModels.PipelineSchedule
+	if err := s.client.do(ctx, req, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
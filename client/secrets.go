@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	secretModels "github.com/equinor/radix-api/api/secrets/models"
+)
+
+// SecretsService secrets and TLS certificates of components
+type SecretsService struct {
+	client *Client
+}
+
+// ChangeComponentSecret sets the value of a secret of the component
+func (s *SecretsService) ChangeComponentSecret(ctx context.Context, appName, envName, componentName, secretName, secretValue string) error {
+	req := newRequest(http.MethodPut, pathf("/applications/%s/environments/%s/components/%s/secrets/%s", appName, envName, componentName, secretName))
+	req.body = secretModels.SecretParameters{SecretValue: secretValue}
+	return s.client.do(ctx, req, nil)
+}
+
+// GetAzureKeyVaultSecretVersions lists the versions of an Azure Key Vault secret used by the replicas of the component
+func (s *SecretsService) GetAzureKeyVaultSecretVersions(ctx context.Context, appName, envName, componentName, azureKeyVaultName, secretName string) ([]secretModels.AzureKeyVaultSecretVersion, error) {
+	req := newRequest(http.MethodGet, pathf("/applications/%s/environments/%s/components/%s/secrets/azure/keyvault/%s", appName, envName, componentName, azureKeyVaultName))
+	req.query.Set("secretName", secretName)
+	var versions []secretModels.AzureKeyVaultSecretVersion
+	return versions, s.client.do(ctx, req, &versions)
+}
+
+// UpdateExternalDNSTLS sets the TLS certificate and private key of an external DNS alias of the component
+func (s *SecretsService) UpdateExternalDNSTLS(ctx context.Context, appName, envName, componentName, fqdn string, tls secretModels.UpdateExternalDNSTLSRequest) error {
+	req := newRequest(http.MethodPut, pathf("/applications/%s/environments/%s/components/%s/externaldns/%s/tls", appName, envName, componentName, fqdn))
+	req.body = tls
+	return s.client.do(ctx, req, nil)
+}
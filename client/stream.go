@@ -0,0 +1,161 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	eventStarted   = "started"
+	eventCompleted = "completed"
+	eventError     = "error"
+)
+
+// ErrStreamFailed is returned by Stream.Err when the API reports that the stream failed
+var ErrStreamFailed = errors.New("radix-api: stream failed")
+
+// event a server-sent event
+type event struct {
+	name string
+	data string
+}
+
+// Stream of server-sent events from the API, read one value at a time:
+//
+//	for stream.Next() {
+//		fmt.Println(stream.Value())
+//	}
+//	if err := stream.Err(); err != nil {
+//		...
+//	}
+//
+// The stream must be closed when done
+type Stream[T any] struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+	decode  func(event) (T, error)
+
+	value T
+	err   error
+	done  bool
+}
+
+func newStream[T any](body io.ReadCloser, decode func(event) (T, error)) *Stream[T] {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &Stream[T]{body: body, scanner: scanner, decode: decode}
+}
+
+// Next reads the next value. It returns false when the stream is completed, or when it failed
+func (s *Stream[T]) Next() bool {
+	if s.done {
+		return false
+	}
+
+	for {
+		e, err := s.readEvent()
+		if err != nil {
+			s.finish(err)
+			return false
+		}
+
+		switch e.name {
+		case eventStarted:
+			continue
+		case eventCompleted:
+			s.finish(nil)
+			return false
+		case eventError:
+			s.finish(ErrStreamFailed)
+			return false
+		}
+
+		value, err := s.decode(e)
+		if err != nil {
+			s.finish(fmt.Errorf("failed to decode event: %w", err))
+			return false
+		}
+		s.value = value
+		return true
+	}
+}
+
+// Value returns the value read by the last call to Next
+func (s *Stream[T]) Value() T {
+	return s.value
+}
+
+// Err returns the error that stopped the stream, or nil when the stream was completed by the API
+func (s *Stream[T]) Err() error {
+	return s.err
+}
+
+// Close closes the connection to the API
+func (s *Stream[T]) Close() error {
+	s.done = true
+	return s.body.Close()
+}
+
+func (s *Stream[T]) finish(err error) {
+	s.done = true
+	s.err = err
+}
+
+// readEvent reads lines until the blank line ending an event. Comments, e.g. healthchecks, are skipped
+func (s *Stream[T]) readEvent() (event, error) {
+	var e event
+	var data []string
+	hasFields := false
+
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		if line == "" {
+			if !hasFields {
+				continue
+			}
+			e.data = strings.Join(data, "\n")
+			return e, nil
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			e.name = value
+			hasFields = true
+		case "data":
+			data = append(data, value)
+			hasFields = true
+		}
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return event{}, err
+	}
+	// The API always ends a stream with a completed or error event
+	return event{}, io.ErrUnexpectedEOF
+}
+
+func (c *Client) stream(ctx context.Context, req *request) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, req, "text/event-stream")
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// streamLines streams the lines of a log
+func (c *Client) streamLines(ctx context.Context, req *request) (*Stream[string], error) {
+	body, err := c.stream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return newStream(body, func(e event) (string, error) { return e.data, nil }), nil
+}
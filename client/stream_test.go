@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamLog(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		body          string
+		expectedLines []string
+		expectedErr   error
+	}{
+		{
+			name:          "completed",
+			body:          "event: started\n\ndata: line 1\n\n: healthcheck\n\ndata: \n\ndata: line 3\n\nevent: completed\n\n",
+			expectedLines: []string{"line 1", "", "line 3"},
+		},
+		{
+			name:          "failed",
+			body:          "event: started\n\ndata: line 1\n\nevent: error\n\n",
+			expectedLines: []string{"line 1"},
+			expectedErr:   ErrStreamFailed,
+		},
+		{
+			name:          "connection closed",
+			body:          "event: started\n\ndata: line 1\n\n",
+			expectedLines: []string{"line 1"},
+			expectedErr:   io.ErrUnexpectedEOF,
+		},
+	}
+
+	for _, ts := range scenarios {
+		t.Run(ts.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "true", r.URL.Query().Get("follow"))
+				assert.Equal(t, "10", r.URL.Query().Get("lines"))
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = fmt.Fprint(w, ts.body)
+			}))
+			defer server.Close()
+			c, err := New(server.URL)
+			require.NoError(t, err)
+
+			lines := int64(10)
+			stream, err := c.streamLog(context.Background(), "/any/logs", LogOptions{Lines: &lines})
+			require.NoError(t, err)
+			defer func() { _ = stream.Close() }()
+
+			var actualLines []string
+			for stream.Next() {
+				actualLines = append(actualLines, stream.Value())
+			}
+			assert.Equal(t, ts.expectedLines, actualLines)
+			assert.ErrorIs(t, stream.Err(), ts.expectedErr)
+			assert.False(t, stream.Next())
+		})
+	}
+}
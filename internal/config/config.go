@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/rs/zerolog/log"
@@ -14,6 +16,7 @@ type Config struct {
 	UseProfiler    bool   `envconfig:"USE_PROFILER" default:"false" desc:"Enable Profiler"`
 	LogLevel       string `envconfig:"LOG_LEVEL" default:"info"`
 	LogPrettyPrint bool   `envconfig:"LOG_PRETTY" default:"false"`
+	ConfigFile     string `envconfig:"CONFIG_FILE" desc:"Optional YAML file with settings overlaying the environment variables. Changes are reloaded without restart"`
 
 	AppName            string   `envconfig:"RADIX_APP" required:"true" desc:"Should be radix-api"`
 	EnvironmentName    string   `envconfig:"RADIX_ENVIRONMENT" required:"true" desc:"Should be qa or prod"`
@@ -22,9 +25,50 @@ type Config struct {
 	ClusterEgressIps   []string `envconfig:"CLUSTER_EGRESS_IPS" required:"true" desc:"Comma separated list of Egress IPs of the cluster, e.g. 192.168.84.0/30,10.0.0.0/30"`
 	ClusterOidcIssuers []string `envconfig:"CLUSTER_OIDC_ISSUERS" required:"true" desc:"Comma separated list of OIDC issuers of the cluster, e.g. https://login.microsoftonline.com/72f988bf-86f1-41af-91ab-2d7cd011db47/v2.0,http://localhost:5000"`
 
-	AzureOidc      Oidc   `envconfig:"OIDC_AZURE" required:"true"`
-	KubernetesOidc Oidc   `envconfig:"OIDC_KUBERNETES" required:"true"`
-	PrometheusUrl  string `envconfig:"PROMETHEUS_URL" required:"true"`
+	AzureOidc      Oidc        `envconfig:"OIDC_AZURE" required:"true"`
+	KubernetesOidc Oidc        `envconfig:"OIDC_KUBERNETES" required:"true"`
+	OidcIssuers    OidcIssuers `envconfig:"OIDC_ISSUERS" desc:"JSON list of additional OIDC issuers, e.g. [{\"name\":\"github\",\"issuer\":\"https://token.actions.githubusercontent.com\",\"audiences\":[\"radix-api\"],\"nameClaim\":\"repository\"}]"`
+	PrometheusUrl  string      `envconfig:"PROMETHEUS_URL" required:"true"`
+
+	UseRateLimit               bool            `envconfig:"USE_RATE_LIMIT" default:"true" desc:"Limit the number of requests each user, or IP address for anonymous requests, can send to each route"`
+	RateLimitRequestsPerSecond float64         `envconfig:"RATE_LIMIT_REQUESTS_PER_SECOND" default:"20" desc:"Default number of requests per second each user can send to a route"`
+	RateLimitBurst             int             `envconfig:"RATE_LIMIT_BURST" default:"100" desc:"Default number of requests each user can send to a route in a burst"`
+	RateLimitTrustedProxies    int             `envconfig:"RATE_LIMIT_TRUSTED_PROXIES" default:"1" desc:"Number of reverse proxies, like the ingress controller, appending the client IP of anonymous requests to X-Forwarded-For"`
+	RateLimitRoutes            RateLimitRoutes `envconfig:"RATE_LIMIT_ROUTES" desc:"JSON object with the budget of routes, by method and path, overriding the default and the budget set in code, e.g. {\"POST /api/v1/applications/{appName}/pipelines/build\":{\"requestsPerSecond\":1,\"burst\":10}}"`
+
+	Tracing Tracing `envconfig:"TRACING"`
+
+	AuditSink                string `envconfig:"AUDIT_SINK" default:"configmap" desc:"Where to write the audit log of mutating operations: none, stdout, file or configmap. The audit log can only be read with the API with configmap"`
+	AuditFilePath            string `envconfig:"AUDIT_FILE_PATH" default:"/var/log/radix-api/audit.log" desc:"Path of the audit log of the replica when AUDIT_SINK is file"`
+	AuditConfigMapMaxEntries int    `envconfig:"AUDIT_CONFIGMAP_MAX_ENTRIES" default:"500" desc:"Number of audit entries kept for each application when AUDIT_SINK is configmap"`
+
+	IdempotencyKeyTimeWindow time.Duration `envconfig:"IDEMPOTENCY_KEY_TIME_WINDOW" default:"24h" desc:"How long a pipeline job request with an Idempotency-Key header can be replayed"`
+
+	HealthCheckTimeout       time.Duration `envconfig:"HEALTH_CHECK_TIMEOUT" default:"5s" desc:"Timeout of each dependency check in /health/ready"`
+	HealthCheckCacheDuration time.Duration `envconfig:"HEALTH_CHECK_CACHE_DURATION" default:"15s" desc:"How long the result of the dependency checks in /health/ready is reused"`
+
+	PersonalAccessTokenMaxLifetime time.Duration `envconfig:"PERSONAL_ACCESS_TOKEN_MAX_LIFETIME" default:"2160h" desc:"Maximum lifetime of personal access tokens, and the lifetime of tokens created without an expiry time"`
+
+	ImpersonationEnabled           bool     `envconfig:"IMPERSONATION_ENABLED" default:"false" desc:"Allow impersonation with the Impersonate-User and Impersonate-Group headers, by the principals in IMPERSONATION_ALLOWED_PRINCIPALS"`
+	ImpersonationAllowedPrincipals []string `envconfig:"IMPERSONATION_ALLOWED_PRINCIPALS" desc:"Comma separated list of ids of principals allowed to impersonate, as glob patterns. Required when impersonation is enabled"`
+	ImpersonationAllowedUsers      []string `envconfig:"IMPERSONATION_ALLOWED_USERS" desc:"Comma separated list of users that can be impersonated, as glob patterns. All users are allowed when empty"`
+	ImpersonationAllowedGroups     []string `envconfig:"IMPERSONATION_ALLOWED_GROUPS" desc:"Comma separated list of groups that can be impersonated, as glob patterns. All groups are allowed when empty"`
+
+	MaintenanceMode   bool   `envconfig:"MAINTENANCE_MODE" default:"false" desc:"Read-only maintenance mode, rejecting all requests that change resources with 503 Service Unavailable"`
+	MaintenanceReason string `envconfig:"MAINTENANCE_REASON" desc:"Reason shown to users when maintenance mode is enabled"`
+
+	UseKubeCache          bool          `envconfig:"USE_KUBE_CACHE" default:"true" desc:"Use informer cache for RadixRegistrations, RadixJobs, RadixEnvironments and RadixDeployments read with the service account"`
+	KubeCacheResyncPeriod time.Duration `envconfig:"KUBE_CACHE_RESYNC_PERIOD" default:"10m" desc:"Resync period for the informer cache"`
+
+	AccessDecisionCacheTTL        time.Duration `envconfig:"ACCESS_DECISION_CACHE_TTL" default:"1m" desc:"How long the access of each user to an application is cached when listing applications. 0 disables the cache"`
+	AccessDecisionCacheMaxEntries int           `envconfig:"ACCESS_DECISION_CACHE_MAX_ENTRIES" default:"100000" desc:"Maximum number of access decisions in the cache"`
+
+	DecommissionerEnabled     bool          `envconfig:"DECOMMISSIONER_ENABLED" default:"false" desc:"Delete decommissioned applications when the grace period has passed. Decommissioned applications are kept, with all environments stopped, while disabled"`
+	DecommissionGracePeriod   time.Duration `envconfig:"DECOMMISSION_GRACE_PERIOD" default:"168h" desc:"How long a decommissioned application is kept, with all environments stopped, before it is deleted"`
+	DecommissionCheckInterval time.Duration `envconfig:"DECOMMISSION_CHECK_INTERVAL" default:"5m" desc:"How often decommissioned applications are checked, and deleted when the grace period has passed"`
+
+	PipelineSchedulerEnabled  bool          `envconfig:"PIPELINE_SCHEDULER_ENABLED" default:"true" desc:"Start the pipeline jobs of pipeline schedules. The replicas elect a leader with a Lease in the namespace of the API, which starts the jobs"`
+	PipelineSchedulerInterval time.Duration `envconfig:"PIPELINE_SCHEDULER_INTERVAL" default:"30s" desc:"How often pipeline schedules are checked for due jobs"`
 }
 
 type Oidc struct {
@@ -32,6 +76,14 @@ type Oidc struct {
 	Audience string  `envconfig:"Audience" required:"true"`
 }
 
+// Tracing configures export of OpenTelemetry traces, read from the TRACING_ prefixed environment variables
+type Tracing struct {
+	Enabled      bool    `envconfig:"ENABLED" default:"false" desc:"Export OpenTelemetry traces"`
+	OtlpEndpoint string  `envconfig:"OTLP_ENDPOINT" default:"localhost:4318" desc:"host:port of the OTLP HTTP collector"`
+	OtlpInsecure bool    `envconfig:"OTLP_INSECURE" default:"false" desc:"Use HTTP instead of HTTPS for the OTLP collector"`
+	SampleRatio  float64 `envconfig:"SAMPLE_RATIO" default:"1" desc:"Ratio of requests to trace, when not set by the caller in the traceparent header"`
+}
+
 func MustParse() Config {
 	var s Config
 	err := envconfig.Process("", &s)
@@ -40,5 +92,36 @@ func MustParse() Config {
 		log.Fatal().Msg(err.Error())
 	}
 
-	return s
+	c, err := applyConfigFile(s)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("failed to read config file %s", s.ConfigFile)
+	}
+	if err := c.validate(); err != nil {
+		log.Fatal().Err(err).Msg("invalid config")
+	}
+	return c
+}
+
+// parse reads the environment variables and the config file
+func parse() (Config, error) {
+	var s Config
+	if err := envconfig.Process("", &s); err != nil {
+		return Config{}, err
+	}
+	c, err := applyConfigFile(s)
+	if err != nil {
+		return Config{}, err
+	}
+	return c, c.validate()
+}
+
+// validate checks settings that depend on each other, or cannot be checked when they are decoded
+func (c Config) validate() error {
+	if c.UseRateLimit {
+		budget := RateLimitBudget{RequestsPerSecond: c.RateLimitRequestsPerSecond, Burst: c.RateLimitBurst}
+		if err := budget.validate(); err != nil {
+			return fmt.Errorf("invalid RATE_LIMIT_REQUESTS_PER_SECOND or RATE_LIMIT_BURST: %w", err)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/rs/zerolog"
+	"sigs.k8s.io/yaml"
+)
+
+// File settings in the YAML config file. Settings in the file overlay the environment variables,
+// and are reloaded without restart when the file changes
+type File struct {
+	LogLevel           *string          `json:"logLevel,omitempty"`
+	ClusterEgressIps   []string         `json:"clusterEgressIps,omitempty"`
+	ClusterOidcIssuers []string         `json:"clusterOidcIssuers,omitempty"`
+	PrometheusUrl      *string          `json:"prometheusUrl,omitempty"`
+	AzureOidc          *FileOidc        `json:"azureOidc,omitempty"`
+	KubernetesOidc     *FileOidc        `json:"kubernetesOidc,omitempty"`
+	OidcIssuers        OidcIssuers      `json:"oidcIssuers,omitempty"`
+	Maintenance        *FileMaintenance `json:"maintenance,omitempty"`
+	RateLimitRoutes    RateLimitRoutes  `json:"rateLimitRoutes,omitempty"`
+}
+
+type FileMaintenance struct {
+	Enabled *bool   `json:"enabled,omitempty"`
+	Reason  *string `json:"reason,omitempty"`
+}
+
+type FileOidc struct {
+	Issuer   *string `json:"issuer,omitempty"`
+	Audience *string `json:"audience,omitempty"`
+}
+
+// ReadFile reads the YAML config file. Unknown settings are not allowed
+func ReadFile(path string) (File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, err
+	}
+	var file File
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return File{}, err
+	}
+	return file, nil
+}
+
+// Apply returns a copy of c with the settings in the file
+func (f File) Apply(c Config) (Config, error) {
+	if f.LogLevel != nil {
+		if _, err := zerolog.ParseLevel(*f.LogLevel); err != nil {
+			return Config{}, fmt.Errorf("invalid logLevel: %w", err)
+		}
+		c.LogLevel = *f.LogLevel
+	}
+	if f.ClusterEgressIps != nil {
+		c.ClusterEgressIps = f.ClusterEgressIps
+	}
+	if f.ClusterOidcIssuers != nil {
+		c.ClusterOidcIssuers = f.ClusterOidcIssuers
+	}
+	if f.PrometheusUrl != nil {
+		if _, err := parseUrl(*f.PrometheusUrl); err != nil {
+			return Config{}, fmt.Errorf("invalid prometheusUrl: %w", err)
+		}
+		c.PrometheusUrl = *f.PrometheusUrl
+	}
+	var err error
+	if c.AzureOidc, err = f.AzureOidc.apply(c.AzureOidc); err != nil {
+		return Config{}, fmt.Errorf("invalid azureOidc: %w", err)
+	}
+	if c.KubernetesOidc, err = f.KubernetesOidc.apply(c.KubernetesOidc); err != nil {
+		return Config{}, fmt.Errorf("invalid kubernetesOidc: %w", err)
+	}
+	if f.OidcIssuers != nil {
+		if err := f.OidcIssuers.validate(); err != nil {
+			return Config{}, fmt.Errorf("invalid oidcIssuers: %w", err)
+		}
+		c.OidcIssuers = f.OidcIssuers
+	}
+	if f.RateLimitRoutes != nil {
+		if err := f.RateLimitRoutes.validate(); err != nil {
+			return Config{}, fmt.Errorf("invalid rateLimitRoutes: %w", err)
+		}
+		c.RateLimitRoutes = f.RateLimitRoutes
+	}
+	if f.Maintenance != nil {
+		if f.Maintenance.Enabled != nil {
+			c.MaintenanceMode = *f.Maintenance.Enabled
+		}
+		if f.Maintenance.Reason != nil {
+			c.MaintenanceReason = *f.Maintenance.Reason
+		}
+	}
+	return c, nil
+}
+
+func (f *FileOidc) apply(oidc Oidc) (Oidc, error) {
+	if f == nil {
+		return oidc, nil
+	}
+	if f.Issuer != nil {
+		issuer, err := parseUrl(*f.Issuer)
+		if err != nil {
+			return Oidc{}, fmt.Errorf("invalid issuer: %w", err)
+		}
+		oidc.Issuer = *issuer
+	}
+	if f.Audience != nil {
+		if *f.Audience == "" {
+			return Oidc{}, errors.New("audience cannot be empty")
+		}
+		oidc.Audience = *f.Audience
+	}
+	return oidc, nil
+}
+
+func parseUrl(rawUrl string) (*url.URL, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute URL", rawUrl)
+	}
+	return u, nil
+}
+
+func applyConfigFile(c Config) (Config, error) {
+	if c.ConfigFile == "" {
+		return c, nil
+	}
+	file, err := ReadFile(c.ConfigFile)
+	if err != nil {
+		return Config{}, err
+	}
+	return file.Apply(c)
+}
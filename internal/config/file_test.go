@@ -0,0 +1,82 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_Apply(t *testing.T) {
+	issuer, _ := url.Parse("https://issuer.example.com")
+	c := Config{
+		LogLevel:         "info",
+		ClusterEgressIps: []string{"1.1.1.1"},
+		PrometheusUrl:    "http://prometheus:9090",
+		AzureOidc:        Oidc{Issuer: *issuer, Audience: "azure-audience"},
+		KubernetesOidc:   Oidc{Issuer: *issuer, Audience: "kubernetes-audience"},
+	}
+	path := writeFile(t, `
+logLevel: debug
+clusterEgressIps: [2.2.2.2, 3.3.3.3]
+azureOidc:
+  issuer: https://other-issuer.example.com
+oidcIssuers:
+- name: github
+  issuer: https://token.actions.githubusercontent.com
+  audiences: [radix-api]
+  nameClaim: repository
+maintenance:
+  enabled: true
+  reason: Cluster migration
+rateLimitRoutes:
+  POST /api/v1/applications/{appName}/pipelines/build:
+    requestsPerSecond: 0.5
+    burst: 5
+`)
+
+	file, err := ReadFile(path)
+	require.NoError(t, err)
+	actual, err := file.Apply(c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "debug", actual.LogLevel)
+	assert.Equal(t, []string{"2.2.2.2", "3.3.3.3"}, actual.ClusterEgressIps)
+	assert.Equal(t, "http://prometheus:9090", actual.PrometheusUrl)
+	assert.Equal(t, "https://other-issuer.example.com", actual.AzureOidc.Issuer.String())
+	assert.Equal(t, "azure-audience", actual.AzureOidc.Audience)
+	assert.Equal(t, c.KubernetesOidc, actual.KubernetesOidc)
+	assert.Equal(t, OidcIssuers{{Name: "github", Issuer: "https://token.actions.githubusercontent.com", Audiences: []string{"radix-api"}, NameClaim: "repository"}}, actual.OidcIssuers)
+	assert.True(t, actual.MaintenanceMode)
+	assert.Equal(t, "Cluster migration", actual.MaintenanceReason)
+	assert.Equal(t, RateLimitRoutes{"POST /api/v1/applications/{appName}/pipelines/build": {RequestsPerSecond: 0.5, Burst: 5}}, actual.RateLimitRoutes)
+	assert.Equal(t, "info", c.LogLevel, "config should not be modified")
+}
+
+func TestFile_InvalidSettings(t *testing.T) {
+	scenarios := map[string]string{
+		"unknown setting":     "port: 1234",
+		"invalid log level":   "logLevel: loud",
+		"relative url":        "prometheusUrl: prometheus:9090/metrics",
+		"empty audience":      "kubernetesOidc:\n  audience: ''",
+		"invalid issuer url":  "azureOidc:\n  issuer: /issuer",
+		"issuer without name": "oidcIssuers:\n- issuer: https://issuer.example.com\n  audiences: [any]",
+		"route without burst": "rateLimitRoutes:\n  GET /api/v1/applications:\n    requestsPerSecond: 1",
+	}
+
+	for name, content := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			_, err := applyConfigFile(Config{ConfigFile: writeFile(t, content)})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// OidcIssuer an OIDC issuer trusted in addition to the Azure and Kubernetes issuers, e.g. GitHub Actions
+type OidcIssuer struct {
+	// Name of the issuer, used in logs and health checks, and as prefix of the ids of its principals
+	Name string `json:"name"`
+	// Issuer URL, which must match the iss claim of the tokens
+	Issuer string `json:"issuer"`
+	// Audiences tokens must have one of the audiences
+	Audiences []string `json:"audiences"`
+	// Algorithms signing algorithms allowed for the tokens. Defaults to RS256
+	Algorithms []string `json:"algorithms,omitempty"`
+	// IdClaim claim used as the id of the principal, prefixed with the name of the issuer and the claim, e.g. github:sub:<value>. Defaults to sub
+	IdClaim string `json:"idClaim,omitempty"`
+	// NameClaim claim used as the name of the principal. Defaults to IdClaim
+	NameClaim string `json:"nameClaim,omitempty"`
+}
+
+// IssuerUrl returns the parsed Issuer. The issuer is validated when the config is parsed
+func (i OidcIssuer) IssuerUrl() url.URL {
+	issuer, _ := url.Parse(i.Issuer)
+	if issuer == nil {
+		return url.URL{}
+	}
+	return *issuer
+}
+
+// OidcIssuers a list of OIDC issuers, set as a JSON list in the environment variable
+type OidcIssuers []OidcIssuer
+
+// Decode implements envconfig.Decoder
+func (i *OidcIssuers) Decode(value string) error {
+	var issuers OidcIssuers
+	if err := json.Unmarshal([]byte(value), &issuers); err != nil {
+		return err
+	}
+	if err := issuers.validate(); err != nil {
+		return err
+	}
+	*i = issuers
+	return nil
+}
+
+func (i OidcIssuers) validate() error {
+	names := make(map[string]bool, len(i))
+	for _, issuer := range i {
+		if issuer.Name == "" {
+			return errors.New("name cannot be empty")
+		}
+		if names[issuer.Name] {
+			return fmt.Errorf("duplicate issuer name %s", issuer.Name)
+		}
+		names[issuer.Name] = true
+		if _, err := parseUrl(issuer.Issuer); err != nil {
+			return fmt.Errorf("invalid issuer %s: %w", issuer.Name, err)
+		}
+		if len(issuer.Audiences) == 0 {
+			return fmt.Errorf("issuer %s must have at least one audience", issuer.Name)
+		}
+	}
+	return nil
+}
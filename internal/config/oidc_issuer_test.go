@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOidcIssuers_Decode(t *testing.T) {
+	var issuers OidcIssuers
+	err := issuers.Decode(`[{"name":"github","issuer":"https://token.actions.githubusercontent.com","audiences":["radix-api"],"algorithms":["RS256","ES256"],"idClaim":"repository_id","nameClaim":"repository"}]`)
+	require.NoError(t, err)
+
+	require.Len(t, issuers, 1)
+	assert.Equal(t, OidcIssuer{
+		Name:       "github",
+		Issuer:     "https://token.actions.githubusercontent.com",
+		Audiences:  []string{"radix-api"},
+		Algorithms: []string{"RS256", "ES256"},
+		IdClaim:    "repository_id",
+		NameClaim:  "repository",
+	}, issuers[0])
+	assert.Equal(t, "token.actions.githubusercontent.com", issuers[0].IssuerUrl().Host)
+}
+
+func TestOidcIssuers_DecodeInvalid(t *testing.T) {
+	scenarios := map[string]string{
+		"invalid json":     `{"name":"github"}`,
+		"missing name":     `[{"issuer":"https://issuer.example.com","audiences":["any"]}]`,
+		"duplicate name":   `[{"name":"any","issuer":"https://issuer.example.com","audiences":["any"]},{"name":"any","issuer":"https://other.example.com","audiences":["any"]}]`,
+		"relative issuer":  `[{"name":"any","issuer":"/issuer","audiences":["any"]}]`,
+		"missing audience": `[{"name":"any","issuer":"https://issuer.example.com"}]`,
+	}
+
+	for name, value := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			var issuers OidcIssuers
+			assert.Error(t, issuers.Decode(value))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RateLimitBudget number of requests per second, and burst of requests, each principal can send to a route
+type RateLimitBudget struct {
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	Burst             int     `json:"burst"`
+}
+
+// RateLimitRoutes budgets of routes, by the method and path of the route, e.g. "POST /api/v1/applications/{appName}/pipelines/build".
+// Set as a JSON object in the environment variable
+type RateLimitRoutes map[string]RateLimitBudget
+
+// Decode implements envconfig.Decoder
+func (r *RateLimitRoutes) Decode(value string) error {
+	var routes RateLimitRoutes
+	if err := json.Unmarshal([]byte(value), &routes); err != nil {
+		return err
+	}
+	if err := routes.validate(); err != nil {
+		return err
+	}
+	*r = routes
+	return nil
+}
+
+func (r RateLimitRoutes) validate() error {
+	for route, budget := range r {
+		method, path, ok := strings.Cut(route, " ")
+		if !ok || method != strings.ToUpper(method) || !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("route %q must be the method and path of the route, e.g. %s /api/v1/applications", route, http.MethodGet)
+		}
+		if err := budget.validate(); err != nil {
+			return fmt.Errorf("invalid budget of route %s: %w", route, err)
+		}
+	}
+	return nil
+}
+
+func (b RateLimitBudget) validate() error {
+	if b.RequestsPerSecond <= 0 {
+		return errors.New("requests per second must be greater than 0")
+	}
+	if b.Burst <= 0 {
+		return errors.New("burst must be greater than 0")
+	}
+	return nil
+}
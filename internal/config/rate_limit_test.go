@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitRoutes_Decode(t *testing.T) {
+	var routes RateLimitRoutes
+	err := routes.Decode(`{"POST /api/v1/applications/{appName}/pipelines/build":{"requestsPerSecond":0.5,"burst":5}}`)
+	require.NoError(t, err)
+
+	assert.Equal(t, RateLimitRoutes{"POST /api/v1/applications/{appName}/pipelines/build": {RequestsPerSecond: 0.5, Burst: 5}}, routes)
+}
+
+func TestRateLimitRoutes_DecodeInvalid(t *testing.T) {
+	scenarios := map[string]string{
+		"invalid json":          `[]`,
+		"missing method":        `{"/api/v1/applications":{"requestsPerSecond":1,"burst":1}}`,
+		"relative path":         `{"GET api/v1/applications":{"requestsPerSecond":1,"burst":1}}`,
+		"zero requests per sec": `{"GET /api/v1/applications":{"requestsPerSecond":0,"burst":1}}`,
+		"negative burst":        `{"GET /api/v1/applications":{"requestsPerSecond":1,"burst":-1}}`,
+	}
+
+	for name, value := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			var routes RateLimitRoutes
+			assert.Error(t, routes.Decode(value))
+		})
+	}
+}
+
+func TestConfig_ValidateRateLimit(t *testing.T) {
+	scenarios := map[string]struct {
+		config      Config
+		expectError bool
+	}{
+		"valid":                     {config: Config{UseRateLimit: true, RateLimitRequestsPerSecond: 20, RateLimitBurst: 100}},
+		"zero requests per second":  {config: Config{UseRateLimit: true, RateLimitRequestsPerSecond: 0, RateLimitBurst: 100}, expectError: true},
+		"zero burst":                {config: Config{UseRateLimit: true, RateLimitRequestsPerSecond: 20, RateLimitBurst: 0}, expectError: true},
+		"rate limit disabled":       {config: Config{UseRateLimit: false}},
+		"negative requests per sec": {config: Config{UseRateLimit: true, RateLimitRequestsPerSecond: -1, RateLimitBurst: 100}, expectError: true},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := scenario.config.validate()
+			if scenario.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
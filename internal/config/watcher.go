@@ -0,0 +1,121 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog/log"
+)
+
+// reloadDelay groups the events of a single change of the config file, e.g. when a mounted ConfigMap is updated
+var reloadDelay = time.Second
+
+var reloadCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "radix_api_config_reloads_total",
+	Help: "The number of reloads of the config file, by result",
+}, []string{"result"})
+
+// Watcher reloads the config file when it changes, and notifies the subscribers when the config has changed
+type Watcher struct {
+	parse    func() (Config, error)
+	reloadMu sync.Mutex
+
+	mu          sync.RWMutex
+	current     Config
+	subscribers []func(Config)
+}
+
+// NewWatcher Constructor for Watcher, starting with the config c
+func NewWatcher(c Config) *Watcher {
+	return &Watcher{parse: parse, current: c}
+}
+
+// Current returns the latest config
+func (w *Watcher) Current() Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// Subscribe registers fn to be called with the new config after each reload that changes the config
+func (w *Watcher) Subscribe(fn func(Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Reload reads the environment variables and the config file. The current config is kept when it fails.
+// Subscribers are called sequentially, one reload at a time
+func (w *Watcher) Reload() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	c, err := w.parse()
+	if err != nil {
+		reloadCounter.WithLabelValues("failure").Inc()
+		return err
+	}
+
+	w.mu.Lock()
+	if reflect.DeepEqual(c, w.current) {
+		w.mu.Unlock()
+		return nil
+	}
+	w.current = c
+	subscribers := append([]func(Config){}, w.subscribers...)
+	w.mu.Unlock()
+
+	for _, fn := range subscribers {
+		fn(c)
+	}
+	reloadCounter.WithLabelValues("success").Inc()
+	log.Info().Msg("config reloaded")
+	return nil
+}
+
+// Watch reloads the config file when it changes, until ctx is done. The directory of the file is watched,
+// since mounted ConfigMaps are updated by replacing a symlink. Watch returns immediately when no config file is configured
+func (w *Watcher) Watch(ctx context.Context) error {
+	configFile := w.Current().ConfigFile
+	if configFile == "" {
+		return nil
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fsWatcher.Close() }()
+	if err := fsWatcher.Add(filepath.Dir(configFile)); err != nil {
+		return err
+	}
+
+	reload := time.AfterFunc(reloadDelay, func() {
+		if err := w.Reload(); err != nil {
+			log.Error().Err(err).Msgf("failed to reload config file %s, keeping the current config", configFile)
+			return
+		}
+	})
+	reload.Stop()
+	defer reload.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-fsWatcher.Events:
+			if event.Has(fsnotify.Chmod) {
+				continue
+			}
+			reload.Reset(reloadDelay)
+		case err := <-fsWatcher.Errors:
+			log.Warn().Err(err).Msgf("error watching config file %s", configFile)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher_Reload(t *testing.T) {
+	var next Config
+	var parseErr error
+	w := NewWatcher(Config{LogLevel: "info"})
+	w.parse = func() (Config, error) { return next, parseErr }
+	var notified []Config
+	w.Subscribe(func(c Config) { notified = append(notified, c) })
+
+	next = Config{LogLevel: "info"}
+	require.NoError(t, w.Reload())
+	assert.Empty(t, notified, "subscribers should not be notified when the config is unchanged")
+
+	next = Config{LogLevel: "debug"}
+	require.NoError(t, w.Reload())
+	assert.Equal(t, []Config{{LogLevel: "debug"}}, notified)
+	assert.Equal(t, "debug", w.Current().LogLevel)
+
+	parseErr = errors.New("any error")
+	next = Config{LogLevel: "warn"}
+	assert.Error(t, w.Reload())
+	assert.Len(t, notified, 1)
+	assert.Equal(t, "debug", w.Current().LogLevel, "current config should be kept when reload fails")
+}
+
+func TestWatcher_WatchReloadsChangedFile(t *testing.T) {
+	reloadDelay = 10 * time.Millisecond
+	path := writeFile(t, "logLevel: info")
+	w := NewWatcher(Config{LogLevel: "info", ConfigFile: path})
+	w.parse = func() (Config, error) { return applyConfigFile(Config{ConfigFile: path}) }
+	notified := make(chan Config, 1)
+	w.Subscribe(func(c Config) { notified <- c })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watchErr := make(chan error, 1)
+	go func() { watchErr <- w.Watch(ctx) }()
+	// Wait for the watcher to be added before changing the file
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, os.WriteFile(path, []byte("logLevel: debug"), 0o600))
+
+	select {
+	case c := <-notified:
+		assert.Equal(t, "debug", c.LogLevel)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "config was not reloaded")
+	}
+
+	cancel()
+	assert.NoError(t, <-watchErr)
+}
@@ -9,33 +9,47 @@ import (
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
+	"reflect"
 	"sync"
 	"syscall"
 	"time"
 
+	"github.com/equinor/radix-api/api/accesstokens"
 	"github.com/equinor/radix-api/api/alerting"
 	"github.com/equinor/radix-api/api/applications"
+	"github.com/equinor/radix-api/api/audit"
 	"github.com/equinor/radix-api/api/buildsecrets"
 	"github.com/equinor/radix-api/api/buildstatus"
 	buildModels "github.com/equinor/radix-api/api/buildstatus/models"
 	"github.com/equinor/radix-api/api/configuration"
+	"github.com/equinor/radix-api/api/currentuser"
 	"github.com/equinor/radix-api/api/deployments"
 	"github.com/equinor/radix-api/api/environments"
 	"github.com/equinor/radix-api/api/environmentvariables"
 	"github.com/equinor/radix-api/api/jobs"
+	"github.com/equinor/radix-api/api/kubequery"
 	"github.com/equinor/radix-api/api/metrics"
 	"github.com/equinor/radix-api/api/metrics/prometheus"
+	"github.com/equinor/radix-api/api/middleware/auth"
+	"github.com/equinor/radix-api/api/middleware/maintenance"
+	"github.com/equinor/radix-api/api/middleware/ratelimit"
+	"github.com/equinor/radix-api/api/pipelineschedules"
 	"github.com/equinor/radix-api/api/privateimagehubs"
 	"github.com/equinor/radix-api/api/router"
 	"github.com/equinor/radix-api/api/secrets"
 	"github.com/equinor/radix-api/api/utils"
+	"github.com/equinor/radix-api/api/utils/access"
+	"github.com/equinor/radix-api/api/utils/health"
 	"github.com/equinor/radix-api/api/utils/tlsvalidation"
 	token "github.com/equinor/radix-api/api/utils/token"
+	"github.com/equinor/radix-api/api/utils/tracing"
 	_ "github.com/equinor/radix-api/docs"
 	"github.com/equinor/radix-api/internal/config"
 	"github.com/equinor/radix-api/models"
+	operatorUtils "github.com/equinor/radix-operator/pkg/apis/utils"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
 )
 
 //go:generate swagger generate spec
@@ -44,8 +58,22 @@ func main() {
 	setupLogger(c.LogLevel, c.LogPrettyPrint)
 	log.Info().Any("config", c).Msgf("Starting radix-api %s in %s environment", c.AppName, c.EnvironmentName)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	shutdownTracing := initializeTracing(ctx, c)
+	defer shutdownTracing()
+	cache := initializeCache(ctx, c)
+	watcher := initializeConfigWatcher(ctx, c)
+	maintenanceMode := initializeMaintenanceMode(watcher)
+	initializeDecommissioner(ctx, c, maintenanceMode)
+	pipelineScheduleStore := initializePipelineScheduleStore(c)
+	initializePipelineScheduler(ctx, c, pipelineScheduleStore, maintenanceMode)
+
+	auditSink, closeAuditSink := initializeAuditSink(c)
+	defer closeAuditSink()
+
 	servers := []*http.Server{
-		initializeServer(c),
+		initializeServer(watcher, cache, pipelineScheduleStore, maintenanceMode, auditSink),
 		initializeMetricsServer(c),
 	}
 
@@ -58,14 +86,16 @@ func main() {
 	shutdownServersGracefulOnSignal(servers...)
 }
 
-func initializeServer(c config.Config) *http.Server {
-	jwtValidator := initializeTokenValidator(c)
-	controllers, err := getControllers(c)
+func initializeServer(watcher *config.Watcher, cache *kubequery.Cache, pipelineScheduleStore *pipelineschedules.Store, maintenanceMode *maintenance.Mode, auditSink audit.Sink) *http.Server {
+	c := watcher.Current()
+	personalAccessTokenStore := initializePersonalAccessTokenStore(c)
+	jwtValidator := initializeTokenValidator(watcher, personalAccessTokenStore)
+	controllers, err := getControllers(watcher, cache, auditSink, personalAccessTokenStore, pipelineScheduleStore)
 	if err != nil {
 		log.Fatal().Err(err).Msgf("failed to initialize controllers: %v", err)
 	}
 
-	handler := router.NewAPIHandler(jwtValidator, utils.NewKubeUtil(), controllers...)
+	handler := router.NewAPIHandler(jwtValidator, utils.NewKubeUtil(), initializeRateLimiter(watcher), auditSink, initializeReadinessChecker(watcher), maintenanceMode, initializeImpersonationPolicy(c), controllers...)
 	srv := &http.Server{
 		Addr:    fmt.Sprintf(":%d", c.Port),
 		Handler: handler,
@@ -74,19 +104,253 @@ func initializeServer(c config.Config) *http.Server {
 	return srv
 }
 
-func initializeTokenValidator(c config.Config) token.ValidatorInterface {
+// initializeRateLimiter limits the requests of each principal to each route. The budgets of routes are reloaded when the config file changes
+func initializeRateLimiter(watcher *config.Watcher) *ratelimit.RateLimiter {
+	c := watcher.Current()
+	if !c.UseRateLimit {
+		return nil
+	}
+	rateLimiter := ratelimit.NewRateLimiter(ratelimit.Budget{RequestsPerSecond: c.RateLimitRequestsPerSecond, Burst: c.RateLimitBurst}, c.RateLimitTrustedProxies)
+	rateLimiter.SetRouteBudgets(getRouteBudgets(c))
+	watcher.Subscribe(func(c config.Config) {
+		rateLimiter.SetRouteBudgets(getRouteBudgets(c))
+	})
+	return rateLimiter
+}
+
+func getRouteBudgets(c config.Config) map[string]ratelimit.Budget {
+	budgets := make(map[string]ratelimit.Budget, len(c.RateLimitRoutes))
+	for route, budget := range c.RateLimitRoutes {
+		budgets[route] = ratelimit.Budget(budget)
+	}
+	return budgets
+}
+
+// initializeImpersonationPolicy restricts which principals can impersonate, and which users and groups they can impersonate
+func initializeImpersonationPolicy(c config.Config) *auth.ImpersonationPolicy {
+	policy, err := auth.NewImpersonationPolicy(c.ImpersonationEnabled, c.ImpersonationAllowedPrincipals, c.ImpersonationAllowedUsers, c.ImpersonationAllowedGroups)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid impersonation policy")
+	}
+	return policy
+}
+
+// initializeMaintenanceMode enables or disables read-only maintenance mode when the config is reloaded
+func initializeMaintenanceMode(watcher *config.Watcher) *maintenance.Mode {
+	c := watcher.Current()
+	mode := maintenance.NewMode(c.MaintenanceMode, c.MaintenanceReason)
+	if c.MaintenanceMode {
+		log.Warn().Msgf("Maintenance mode is enabled: %s", mode.Status().Reason)
+	}
+	watcher.Subscribe(func(c config.Config) {
+		if c.MaintenanceMode != mode.Status().Enabled {
+			log.Warn().Msgf("Maintenance mode enabled: %t", c.MaintenanceMode)
+		}
+		mode.Set(c.MaintenanceMode, c.MaintenanceReason)
+	})
+	return mode
+}
+
+// initializeConfigWatcher reloads the log level when the config file changes. Other reloadable settings are subscribed where they are used
+func initializeConfigWatcher(ctx context.Context, c config.Config) *config.Watcher {
+	watcher := config.NewWatcher(c)
+	watcher.Subscribe(func(c config.Config) {
+		setLogLevel(c.LogLevel)
+	})
+
+	go func() {
+		if err := watcher.Watch(ctx); err != nil {
+			log.Error().Err(err).Msgf("failed to watch config file %s, changes will not be reloaded", c.ConfigFile)
+		}
+	}()
+	return watcher
+}
+
+// initializeReadinessChecker checks the Kubernetes API, Prometheus and the JWKS endpoints of the OIDC issuers in /health/ready
+func initializeReadinessChecker(watcher *config.Watcher) *health.ReadinessChecker {
+	kubeClient, _, _, _, _, _ := utils.NewKubeUtil().GetServerKubernetesClient()
+	httpClient := &http.Client{}
+	getChecks := func(c config.Config) []health.Check {
+		checks := []health.Check{
+			health.NewKubernetesCheck(kubeClient),
+			health.NewPrometheusCheck(httpClient, c.PrometheusUrl),
+			health.NewJWKSCheck(httpClient, "azure", c.AzureOidc.Issuer),
+			health.NewJWKSCheck(httpClient, "kubernetes", c.KubernetesOidc.Issuer),
+		}
+		for _, issuer := range c.OidcIssuers {
+			checks = append(checks, health.NewJWKSCheck(httpClient, issuer.Name, issuer.IssuerUrl()))
+		}
+		return checks
+	}
+
+	c := watcher.Current()
+	checker := health.NewReadinessChecker(c.HealthCheckTimeout, c.HealthCheckCacheDuration, getChecks(c)...)
+	watcher.Subscribe(func(c config.Config) {
+		checker.SetChecks(getChecks(c)...)
+	})
+	return checker
+}
+
+// initializeAuditSink creates the sink of the audit log. The returned function writes the entries queued by the sink
+func initializeAuditSink(c config.Config) (audit.Sink, func()) {
+	switch c.AuditSink {
+	case "none":
+		return nil, func() {}
+	case "stdout":
+		return audit.NewStdoutSink(), func() {}
+	case "file":
+		sink, err := audit.NewFileSink(c.AuditFilePath)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to open audit log file")
+		}
+		return sink, func() {}
+	case "configmap":
+		kubeClient, _, _, _, _, _ := utils.NewKubeUtil().GetServerKubernetesClient()
+		sink := audit.NewConfigMapSink(kubeClient, operatorUtils.GetEnvironmentNamespace(c.AppName, c.EnvironmentName), c.AuditConfigMapMaxEntries)
+		return sink, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			if err := sink.Close(ctx); err != nil {
+				log.Warn().Err(err).Msg("failed to write queued audit entries")
+			}
+		}
+	default:
+		log.Fatal().Msgf("invalid audit sink %s", c.AuditSink)
+		return nil, nil
+	}
+}
+
+// initializeTracing sets up export of OpenTelemetry traces. The returned function flushes the remaining spans
+func initializeTracing(ctx context.Context, c config.Config) func() {
+	if !c.Tracing.Enabled {
+		return func() {}
+	}
+
+	log.Info().Msgf("Exporting traces to %s", c.Tracing.OtlpEndpoint)
+	shutdown, err := tracing.InitTracerProvider(ctx, c.Tracing.OtlpEndpoint, c.Tracing.OtlpInsecure, c.Tracing.SampleRatio,
+		semconv.DeploymentEnvironmentName(c.EnvironmentName),
+		semconv.K8SClusterName(c.ClusterName),
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to initialize tracing")
+	}
+
+	return func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdown(shutdownCtx); err != nil {
+			log.Warn().Err(err).Msg("failed to flush traces")
+		}
+	}
+}
+
+// initializeCache starts the informer cache for service account reads in the background.
+// Reads go directly to the Kubernetes API until the cache is synced
+func initializeCache(ctx context.Context, c config.Config) *kubequery.Cache {
+	if !c.UseKubeCache {
+		return nil
+	}
+
+	_, radixClient, _, _, _, _ := utils.NewKubeUtil().GetServerKubernetesClient()
+	cache, err := kubequery.NewCache(radixClient, c.KubeCacheResyncPeriod)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create Kubernetes cache")
+	}
+
+	go func() {
+		start := time.Now()
+		if err := cache.Start(ctx); err != nil {
+			log.Error().Err(err).Msg("failed to start Kubernetes cache, reading from the Kubernetes API")
+			return
+		}
+		log.Info().Msgf("Kubernetes cache synced in %s", time.Since(start))
+	}()
+	return cache
+}
+
+// initializeDecommissioner deletes decommissioned applications in the background when their grace period has passed, in the replica elected as leader
+func initializeDecommissioner(ctx context.Context, c config.Config, maintenanceMode *maintenance.Mode) {
+	if !c.DecommissionerEnabled {
+		return
+	}
+	identity, err := os.Hostname()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to get hostname for the decommissioner leader election")
+	}
+	kubeClient, radixClient, _, _, _, _ := utils.NewKubeUtil().GetServerKubernetesClient()
+	go applications.NewDecommissioner(radixClient, maintenanceMode).RunWithLeaderElection(ctx, kubeClient, operatorUtils.GetEnvironmentNamespace(c.AppName, c.EnvironmentName), identity, c.DecommissionCheckInterval)
+}
+
+// initializePipelineScheduleStore stores pipeline schedules in the namespace of the API
+func initializePipelineScheduleStore(c config.Config) *pipelineschedules.Store {
+	kubeClient, _, _, _, _, _ := utils.NewKubeUtil().GetServerKubernetesClient()
+	return pipelineschedules.NewStore(kubeClient, operatorUtils.GetEnvironmentNamespace(c.AppName, c.EnvironmentName))
+}
+
+// initializePipelineScheduler starts the jobs of pipeline schedules in the background, in the replica elected as leader
+func initializePipelineScheduler(ctx context.Context, c config.Config, store *pipelineschedules.Store, maintenanceMode *maintenance.Mode) {
+	if !c.PipelineSchedulerEnabled {
+		return
+	}
+	identity, err := os.Hostname()
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to get hostname for the pipeline scheduler leader election")
+	}
+	kubeClient, radixClient, _, _, _, _ := utils.NewKubeUtil().GetServerKubernetesClient()
+	go pipelineschedules.NewScheduler(store, kubeClient, radixClient, maintenanceMode).RunWithLeaderElection(ctx, kubeClient, operatorUtils.GetEnvironmentNamespace(c.AppName, c.EnvironmentName), identity, c.PipelineSchedulerInterval)
+}
+
+// initializePersonalAccessTokenStore stores personal access tokens in the namespace of the API
+func initializePersonalAccessTokenStore(c config.Config) *token.PersonalAccessTokenStore {
+	kubeClient, _, _, _, _, _ := utils.NewKubeUtil().GetServerKubernetesClient()
+	return token.NewPersonalAccessTokenStore(kubeClient, operatorUtils.GetEnvironmentNamespace(c.AppName, c.EnvironmentName))
+}
+
+// initializeTokenValidator validates personal access tokens, and tokens from the Azure, Kubernetes and additional OIDC issuers. The validators are recreated when the OIDC config is reloaded
+func initializeTokenValidator(watcher *config.Watcher, personalAccessTokenStore *token.PersonalAccessTokenStore) token.ValidatorInterface {
+	c := watcher.Current()
+	chainedValidator, err := newChainedValidator(c, personalAccessTokenStore)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Error creating JWT OIDC validator")
+	}
+
+	validator := token.NewReloadableValidator(chainedValidator)
+	watcher.Subscribe(func(newConfig config.Config) {
+		if newConfig.AzureOidc == c.AzureOidc && newConfig.KubernetesOidc == c.KubernetesOidc && reflect.DeepEqual(newConfig.OidcIssuers, c.OidcIssuers) {
+			return
+		}
+		chainedValidator, err := newChainedValidator(newConfig, personalAccessTokenStore)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create JWT OIDC validator from reloaded config, keeping the current validator")
+			return
+		}
+		validator.Set(chainedValidator)
+		c = newConfig
+	})
+	return validator
+}
+
+func newChainedValidator(c config.Config, personalAccessTokenStore *token.PersonalAccessTokenStore) (token.ValidatorInterface, error) {
 	azureValidator, err := token.NewValidator(c.AzureOidc.Issuer, c.AzureOidc.Audience)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Error creating JWT Azure OIDC validator")
+		return nil, fmt.Errorf("error creating JWT Azure OIDC validator: %w", err)
 	}
 
 	kubernetesValidator, err := token.NewValidator(c.KubernetesOidc.Issuer, c.KubernetesOidc.Audience)
 	if err != nil {
-		log.Fatal().Err(err).Msg("Error creating JWT Kubernetes OIDC validator")
+		return nil, fmt.Errorf("error creating JWT Kubernetes OIDC validator: %w", err)
 	}
 
-	chainedValidator := token.NewChainedValidator(azureValidator, kubernetesValidator)
-	return chainedValidator
+	validators := []token.ValidatorInterface{token.NewPersonalAccessTokenValidator(personalAccessTokenStore), azureValidator, kubernetesValidator}
+	for _, issuer := range c.OidcIssuers {
+		validator, err := token.NewGenericValidator(issuer.Name, issuer.IssuerUrl(), issuer.Audiences, issuer.Algorithms, token.ClaimMapping{Id: issuer.IdClaim, Name: issuer.NameClaim})
+		if err != nil {
+			return nil, fmt.Errorf("error creating JWT %s OIDC validator: %w", issuer.Name, err)
+		}
+		validators = append(validators, validator)
+	}
+
+	return token.NewChainedValidator(validators...), nil
 }
 
 func initializeMetricsServer(c config.Config) *http.Server {
@@ -135,6 +399,20 @@ func shutdownServersGracefulOnSignal(servers ...*http.Server) {
 }
 
 func setupLogger(logLevelStr string, prettyPrint bool) {
+	var logWriter io.Writer = os.Stderr
+	if prettyPrint {
+		logWriter = &zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.TimeOnly}
+	}
+
+	logger := zerolog.New(logWriter).With().Timestamp().Logger()
+
+	log.Logger = logger
+	zerolog.DefaultContextLogger = &logger
+	setLogLevel(logLevelStr)
+}
+
+// setLogLevel sets the global log level, so it can be changed when the config is reloaded
+func setLogLevel(logLevelStr string) {
 	if len(logLevelStr) == 0 {
 		logLevelStr = zerolog.LevelInfoValue
 	}
@@ -145,36 +423,69 @@ func setupLogger(logLevelStr string, prettyPrint bool) {
 		log.Warn().Msgf("Invalid log level '%s', fallback to '%s'", logLevelStr, logLevel.String())
 	}
 
-	var logWriter io.Writer = os.Stderr
-	if prettyPrint {
-		logWriter = &zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.TimeOnly}
-	}
-
-	logger := zerolog.New(logWriter).Level(logLevel).With().Timestamp().Logger()
-
-	log.Logger = logger
-	zerolog.DefaultContextLogger = &logger
+	zerolog.SetGlobalLevel(logLevel)
 }
 
-func getControllers(config config.Config) ([]models.Controller, error) {
+func getControllers(watcher *config.Watcher, cache *kubequery.Cache, auditSink audit.Sink, personalAccessTokenStore *token.PersonalAccessTokenStore, pipelineScheduleStore *pipelineschedules.Store) ([]models.Controller, error) {
+	config := watcher.Current()
 	buildStatus := buildModels.NewPipelineBadge()
-	applicationFactory := applications.NewApplicationHandlerFactory(config)
-	prometheusClient, err := prometheus.NewPrometheusClient(config.PrometheusUrl)
+	applicationFactory := applications.NewApplicationHandlerFactory(config, applications.WithCache(cache), applications.WithAccessDecisionCache(initializeAccessDecisionCache(config)))
+	prometheusApi, err := initializePrometheusQueryAPI(watcher)
 	if err != nil {
 		return nil, err
 	}
-	metricsHandler := metrics.NewHandler(prometheusClient)
-	return []models.Controller{
+	metricsHandler := metrics.NewHandler(prometheus.NewClient(prometheusApi))
+	controllers := []models.Controller{
 		applications.NewApplicationController(nil, applicationFactory, metricsHandler),
 		deployments.NewDeploymentController(),
 		jobs.NewJobController(),
-		environments.NewEnvironmentController(environments.NewEnvironmentHandlerFactory()),
+		environments.NewEnvironmentController(environments.NewEnvironmentHandlerFactory(environments.WithCache(cache))),
 		environmentvariables.NewEnvVarsController(),
 		privateimagehubs.NewPrivateImageHubController(),
 		buildsecrets.NewBuildSecretsController(),
-		buildstatus.NewBuildStatusController(buildStatus),
+		buildstatus.NewBuildStatusController(buildStatus, cache),
 		alerting.NewAlertingController(),
 		secrets.NewSecretController(tlsvalidation.DefaultValidator()),
-		configuration.NewConfigurationController(configuration.Init(config)),
-	}, nil
+		configuration.NewConfigurationController(configuration.InitWithWatcher(watcher)),
+		accesstokens.NewAccessTokenController(personalAccessTokenStore, config.PersonalAccessTokenMaxLifetime),
+		pipelineschedules.NewPipelineScheduleController(pipelineScheduleStore),
+		currentuser.NewCurrentUserController(),
+	}
+	// The audit log can only be read when the sink can read back the entries of all replicas
+	if querier, ok := auditSink.(audit.Querier); ok {
+		controllers = append(controllers, audit.NewAuditController(audit.NewHandler(querier)))
+	}
+	return controllers, nil
+}
+
+// initializeAccessDecisionCache caches the access reviews of each user when listing applications. The cache is disabled when the TTL is 0
+func initializeAccessDecisionCache(c config.Config) *access.DecisionCache {
+	if c.AccessDecisionCacheTTL <= 0 {
+		return nil
+	}
+	return access.NewDecisionCache(c.AccessDecisionCacheTTL, c.AccessDecisionCacheMaxEntries)
+}
+
+// initializePrometheusQueryAPI creates the Prometheus query API, and recreates it when the Prometheus URL is reloaded
+func initializePrometheusQueryAPI(watcher *config.Watcher) (prometheus.QueryAPI, error) {
+	c := watcher.Current()
+	api, err := prometheus.NewQueryAPI(c.PrometheusUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	reloadableApi := prometheus.NewReloadableQueryAPI(api)
+	watcher.Subscribe(func(newConfig config.Config) {
+		if newConfig.PrometheusUrl == c.PrometheusUrl {
+			return
+		}
+		api, err := prometheus.NewQueryAPI(newConfig.PrometheusUrl)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create Prometheus client from reloaded config, keeping the current client")
+			return
+		}
+		reloadableApi.Set(api)
+		c = newConfig
+	})
+	return reloadableApi, nil
 }
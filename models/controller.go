@@ -3,6 +3,7 @@ package models
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -10,6 +11,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/equinor/radix-api/api/utils/etag"
+	"github.com/equinor/radix-api/api/utils/problem"
 	radixhttp "github.com/equinor/radix-common/net/http"
 	"github.com/rs/zerolog/log"
 )
@@ -30,14 +33,14 @@ type DefaultController struct {
 func (c *DefaultController) ErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
 
 	logError(r.Context(), err)
-	err = radixhttp.ErrorResponse(w, r, err)
+	err = problem.ErrorResponse(w, r, err)
 	if err != nil {
 		log.Ctx(r.Context()).Error().Err(err).Msg("failed to write response")
 	}
 }
 
 func logError(ctx context.Context, err error) {
-	event := log.Ctx(ctx).Warn().Err(err)
+	event := log.Ctx(ctx).Warn().Err(err).Str("code", string(problem.GetCode(err)))
 
 	var httpErr *radixhttp.Error
 	if errors.As(err, &httpErr) {
@@ -47,10 +50,34 @@ func logError(ctx context.Context, err error) {
 	event.Msg("controller error")
 }
 
-// JSONResponse Marshals response with header
+// JSONResponse Marshals response with header.
+// GET and HEAD responses get an ETag computed from the body, and 304 Not Modified is returned when it matches If-None-Match
 func (c *DefaultController) JSONResponse(w http.ResponseWriter, r *http.Request, result interface{}) {
-	err := radixhttp.JSONResponse(w, r, result)
+	if !etag.IsConditionalRequest(r) {
+		err := radixhttp.JSONResponse(w, r, result)
+		if err != nil {
+			log.Ctx(r.Context()).Err(err).Msg("failed to write response")
+		}
+		return
+	}
+
+	body, err := json.Marshal(result)
 	if err != nil {
+		c.ErrorResponse(w, r, err)
+		return
+	}
+
+	tag := etag.FromBody(body)
+	w.Header().Set("ETag", tag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	if !etag.NoneMatch(r.Header.Get("If-None-Match"), tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if _, err = w.Write(body); err != nil {
 		log.Ctx(r.Context()).Err(err).Msg("failed to write response")
 	}
 }
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// JSONEventStreamResponse writes each value received from events to the response as a server-sent event,
+// with the name returned by eventName and the JSON encoded value as data.
+// Will stop when the events channel is closed, or when the client disconnects.
+// Every 15 seconds a healthcheck comment is sent to keep the connection alive.
+func JSONEventStreamResponse[T any](w http.ResponseWriter, r *http.Request, events <-chan T, eventName func(T) string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		log.Ctx(r.Context()).Err(errors.New("streaming unsupported")).Msg("failed to write response")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable buffering for nginx
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = fmt.Fprintf(w, "event: started\n\n")
+	flusher.Flush()
+
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+			_, _ = fmt.Fprintf(w, ": healthcheck\n\n") // sends an event comment
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				_, _ = fmt.Fprintf(w, "event: completed\n\n")
+				flusher.Flush()
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Ctx(r.Context()).Err(err).Msg("failed to marshal event")
+				_, _ = fmt.Fprintf(w, "event: error\n\n")
+				flusher.Flush()
+				return
+			}
+			_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventName(event), data)
+			flusher.Flush()
+		}
+	}
+}
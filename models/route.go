@@ -1,11 +1,20 @@
 package models
 
+import "github.com/equinor/radix-api/api/utils/token"
+
 // KubeApiConfig configuration for K8s API REST client
 type KubeApiConfig struct {
 	QPS   float32
 	Burst int
 }
 
+// RateLimitConfig Number of requests per second, and burst of requests, each principal can send to a route.
+// The default budget is used when not set
+type RateLimitConfig struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
 // Routes Holder of all routes
 type Routes []Route
 
@@ -16,4 +25,7 @@ type Route struct {
 	HandlerFunc               RadixHandlerFunc
 	AllowUnauthenticatedUsers bool
 	KubeApiConfig             KubeApiConfig
+	RateLimit                 RateLimitConfig
+	// TokenScope the scope a personal access token must have to access the route. Personal access tokens are rejected when not set
+	TokenScope token.Scope
 }